@@ -0,0 +1,476 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║      ██████╗██╗  ██╗ █████╗ ██████╗                                                ║
+// ║     ██╔════╝██║  ██║██╔══██╗██╔══██╗                                               ║
+// ║     ██║     ███████║███████║██████╔╝                                               ║
+// ║     ██║     ██╔══██║██╔══██║██╔══██╗                                               ║
+// ║     ╚██████╗██║  ██║██║  ██║██║  ██║                                               ║
+// ║      ╚═════╝╚═╝  ╚═╝╚═╝  ╚═╝╚═╝  ╚═╝                                               ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Vectorized string operations on unicode and bytes arrays,                         ║
+// ║  the counterpart of NumPy's np.char module.                                        ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/char/char.go             ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package char
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+// asciiSpace is what bytes.strip and bytes.split remove by default in Python.
+const asciiSpace = " \t\n\r\v\f"
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Upper, Lower – Change the case of every element                            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.char.upper(a)` and `np.char.lower(a)`.                         ║
+// ║                                                                                    ║
+// ║   - Unicode elements map every letter (strings.ToUpper / ToLower);                 ║
+// ║     bytes elements only ASCII letters, like Python's bytes.upper                   ║
+// ║   - The result keeps a's dtype. Case maps rune for rune, so unlike                 ║
+// ║     Python "ß" stays "ß" rather than becoming "SS"                                 ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – error unless a is unicode or bytes                  ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = ["Oslo" "new york"] (<U8)                                                    ║
+// ║   Upper(a) → ["OSLO" "NEW YORK"] (<U8)                                             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Upper(a *ndarray.NDArray) (*ndarray.NDArray, error) {
+	return mapped(a, true, func(s string, isBytes bool) string {
+		if isBytes {
+			return mapASCII(s, 'a', 'z', 'A'-'a')
+		}
+		return strings.ToUpper(s)
+	})
+}
+
+func Lower(a *ndarray.NDArray) (*ndarray.NDArray, error) {
+	return mapped(a, true, func(s string, isBytes bool) string {
+		if isBytes {
+			return mapASCII(s, 'A', 'Z', 'a'-'A')
+		}
+		return strings.ToLower(s)
+	})
+}
+
+// mapASCII shifts the bytes of s within [lo, hi] by delta, leaving the rest
+// (including invalid UTF-8) untouched.
+func mapASCII(s string, lo, hi byte, delta int) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= lo && c <= hi {
+			b[i] = byte(int(c) + delta)
+		}
+	}
+	return string(b)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Strip, LStrip, RStrip – Remove leading and / or trailing characters        ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.char.strip(a, chars)`, `lstrip` and `rstrip`.                  ║
+// ║                                                                                    ║
+// ║   - Removes any of the characters in `chars`; an empty `chars` means               ║
+// ║     whitespace (Unicode spaces, or ASCII ones for bytes), like None                ║
+// ║   - The result keeps a's dtype                                                     ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – error unless a is unicode or bytes                  ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = ["  id " "-x-"]                                                              ║
+// ║   Strip(a, "")     → ["id" "-x-"]                                                  ║
+// ║   RStrip(a, "- ")  → ["  id" "-x"]                                                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Strip(a *ndarray.NDArray, chars string) (*ndarray.NDArray, error) {
+	return stripped(a, chars, strings.Trim, strings.TrimSpace)
+}
+
+func LStrip(a *ndarray.NDArray, chars string) (*ndarray.NDArray, error) {
+	return stripped(a, chars, strings.TrimLeft, func(s string) string {
+		return strings.TrimLeftFunc(s, unicode.IsSpace)
+	})
+}
+
+func RStrip(a *ndarray.NDArray, chars string) (*ndarray.NDArray, error) {
+	return stripped(a, chars, strings.TrimRight, func(s string) string {
+		return strings.TrimRightFunc(s, unicode.IsSpace)
+	})
+}
+
+// stripped applies trim with the cutset chars, or space when chars is empty.
+func stripped(a *ndarray.NDArray, chars string, trim func(s, cutset string) string, space func(string) string) (*ndarray.NDArray, error) {
+	return mapped(a, true, func(s string, isBytes bool) string {
+		switch {
+		case chars != "":
+			return trim(s, chars)
+		case isBytes:
+			return trim(s, asciiSpace)
+		default:
+			return space(s)
+		}
+	})
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Replace – Substitute occurrences of a substring                            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.char.replace(a, old, new, count)`.                             ║
+// ║                                                                                    ║
+// ║   - Replaces the first `count` non-overlapping occurrences of `old`                ║
+// ║     with `replacement`, or all of them when count < 0                              ║
+// ║   - The result is as wide as its longest element, of a's kind                      ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – error unless a is unicode or bytes                  ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = ["a-b-c" "d"] (<U5)                                                          ║
+// ║   Replace(a, "-", "::", -1) → ["a::b::c" "d"] (<U7)                                ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Replace(a *ndarray.NDArray, old, replacement string, count int) (*ndarray.NDArray, error) {
+	return mapped(a, false, func(s string, _ bool) string {
+		return strings.Replace(s, old, replacement, count)
+	})
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Join – Put a separator between the characters of every element             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.char.join(sep, a)`: each element is treated as                 ║
+// ║   the sequence of its characters (its bytes, for bytes arrays), and                ║
+// ║   `sep` goes between them. It does not join elements together.                     ║
+// ║                                                                                    ║
+// ║   - The result is as wide as its longest element, of a's kind                      ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – error unless a is unicode or bytes                  ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = ["abc" "xy"]                                                                 ║
+// ║   Join("-", a) → ["a-b-c" "x-y"] (<U5)                                             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Join(sep string, a *ndarray.NDArray) (*ndarray.NDArray, error) {
+	return mapped(a, false, func(s string, isBytes bool) string {
+		if isBytes {
+			parts := make([]string, len(s))
+			for i := 0; i < len(s); i++ {
+				parts[i] = s[i : i+1]
+			}
+			return strings.Join(parts, sep)
+		}
+		return strings.Join(strings.Split(s, ""), sep)
+	})
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Split – Break every element into words                                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.char.split(a, sep, maxsplit)`. NumPy returns an                ║
+// ║   object array of lists; arrays here hold fixed-width elements only,               ║
+// ║   so the lists come back as Go slices, in row-major (C) order.                     ║
+// ║                                                                                    ║
+// ║   - A non-empty `sep` splits at each occurrence of it, like                        ║
+// ║     strings.Split; an empty one splits at runs of whitespace and                   ║
+// ║     drops empty words, like Python's split(None)                                   ║
+// ║   - At most `maxsplit` splits are made per element (all when < 0);                 ║
+// ║     the rest of the element is the last word                                       ║
+// ║                                                                                    ║
+// ║   Returns: ([][]string, error) – one slice of words per element;                   ║
+// ║            error unless a is unicode or bytes                                      ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = ["a,b,,c" " x  y "]                                                          ║
+// ║   Split(a, ",", 1)  → [["a" "b,,c"] [" x  y "]]                                    ║
+// ║   Split(a, "", -1)  → [["a,b,,c"] ["x" "y"]]                                       ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Split(a *ndarray.NDArray, sep string, maxsplit int) ([][]string, error) {
+
+	values, _, err := elements(a)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([][]string, len(values))
+	for i, s := range values {
+		switch {
+		case sep == "":
+			out[i] = fields(s, maxsplit)
+		case maxsplit < 0:
+			out[i] = strings.Split(s, sep)
+		default:
+			out[i] = strings.SplitN(s, sep, maxsplit+1)
+		}
+	}
+	return out, nil
+}
+
+// fields splits s at runs of whitespace, at most maxsplit times when it is not
+// negative; the last word keeps any trailing whitespace, as in Python.
+func fields(s string, maxsplit int) []string {
+
+	words := []string{}
+	s = strings.TrimLeftFunc(s, unicode.IsSpace)
+	for s != "" {
+		end := strings.IndexFunc(s, unicode.IsSpace)
+		if end < 0 || len(words) == maxsplit {
+			return append(words, s)
+		}
+		words = append(words, s[:end])
+		s = strings.TrimLeftFunc(s[end:], unicode.IsSpace)
+	}
+	return words
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: StartsWith, EndsWith – Test the ends of every element                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.char.startswith(a, prefix)` and                                ║
+// ║   `np.char.endswith(a, suffix)`.                                                   ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – Bool array of a's shape; error unless               ║
+// ║            a is unicode or bytes                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = ["img_01.png" "doc.txt"]                                                     ║
+// ║   StartsWith(a, "img_") → [true false]                                             ║
+// ║   EndsWith(a, ".txt")   → [false true]                                             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func StartsWith(a *ndarray.NDArray, prefix string) (*ndarray.NDArray, error) {
+	return tested(a, func(s string, _ bool) bool { return strings.HasPrefix(s, prefix) })
+}
+
+func EndsWith(a *ndarray.NDArray, suffix string) (*ndarray.NDArray, error) {
+	return tested(a, func(s string, _ bool) bool { return strings.HasSuffix(s, suffix) })
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Find – Position of the first occurrence of a substring                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.char.find(a, sub)`.                                            ║
+// ║                                                                                    ║
+// ║   - Positions count characters for unicode arrays and bytes for                    ║
+// ║     bytes arrays; -1 when `sub` does not occur                                     ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – Int64 array of a's shape; error unless              ║
+// ║            a is unicode or bytes                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = ["naïve" "bird"]                                                             ║
+// ║   Find(a, "v") → [3 -1]                                                            ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Find(a *ndarray.NDArray, sub string) (*ndarray.NDArray, error) {
+
+	values, isBytes, err := elements(a)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]int64, len(values))
+	for i, s := range values {
+		at := strings.Index(s, sub)
+		if at > 0 && !isBytes {
+			at = utf8.RuneCountInString(s[:at])
+		}
+		out[i] = int64(at)
+	}
+	return shapedLike(a, out)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Equal, NotEqual, Less, LessEqual, Greater, GreaterEqual                    ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.char.equal(a, b)` and the other comparisons:                   ║
+// ║   element-wise, with NumPy broadcasting.                                           ║
+// ║                                                                                    ║
+// ║   - As in np.char (unlike the plain comparison ufuncs), trailing                   ║
+// ║     whitespace is stripped from both sides first                                   ║
+// ║   - Elements order by code point for unicode and by byte value for                 ║
+// ║     bytes; a and b must be of the same kind                                        ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – Bool array of the broadcast shape;                  ║
+// ║            error for non-text operands or shapes that do not broadcast             ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = ["b" "apple  " "Zoo"], b = ["apple"]                                         ║
+// ║   Equal(a, b)   → [false true false]                                               ║
+// ║   Greater(a, b) → [true false false]   ('Z' sorts before 'a')                      ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Equal(a, b *ndarray.NDArray) (*ndarray.NDArray, error) {
+	return compared(a, b, func(c int) bool { return c == 0 })
+}
+
+func NotEqual(a, b *ndarray.NDArray) (*ndarray.NDArray, error) {
+	return compared(a, b, func(c int) bool { return c != 0 })
+}
+
+func Less(a, b *ndarray.NDArray) (*ndarray.NDArray, error) {
+	return compared(a, b, func(c int) bool { return c < 0 })
+}
+
+func LessEqual(a, b *ndarray.NDArray) (*ndarray.NDArray, error) {
+	return compared(a, b, func(c int) bool { return c <= 0 })
+}
+
+func Greater(a, b *ndarray.NDArray) (*ndarray.NDArray, error) {
+	return compared(a, b, func(c int) bool { return c > 0 })
+}
+
+func GreaterEqual(a, b *ndarray.NDArray) (*ndarray.NDArray, error) {
+	return compared(a, b, func(c int) bool { return c >= 0 })
+}
+
+// compared broadcasts a and b and keeps the outcome of test on each comparison.
+func compared(a, b *ndarray.NDArray, test func(c int) bool) (*ndarray.NDArray, error) {
+
+	operands, err := ndarray.BroadcastArrays(a, b)
+	if err != nil {
+		return nil, err
+	}
+	x, xBytes, err := elements(operands[0])
+	if err != nil {
+		return nil, err
+	}
+	y, yBytes, err := elements(operands[1])
+	if err != nil {
+		return nil, err
+	}
+	if xBytes != yBytes {
+		return nil, fmt.Errorf("cannot compare %s with %s", a.DType(), b.DType())
+	}
+
+	out := make([]bool, len(x))
+	for i := range x {
+		out[i] = test(strings.Compare(strings.TrimRightFunc(x[i], unicode.IsSpace), strings.TrimRightFunc(y[i], unicode.IsSpace)))
+	}
+	return shapedLike(operands[0], out)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: elements, mapped, tested, shapedLike – Shared plumbing                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Every operation reads the elements of its text array as Go                       ║
+// ║   strings, in row-major order, computes one result per element and                 ║
+// ║   wraps the results back in an array of the input's shape.                         ║
+// ║                                                                                    ║
+// ║   - elements : the strings, and whether the array holds bytes                      ║
+// ║   - mapped   : string results, in a's dtype when `keep` is set and                 ║
+// ║                otherwise as wide as the longest result                             ║
+// ║   - tested   : Bool results                                                        ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func elements(a *ndarray.NDArray) ([]string, bool, error) {
+
+	values, err := a.Strings()
+	if err != nil {
+		return nil, false, fmt.Errorf("string operation on non-string array of type %s", a.DType())
+	}
+	d := a.DType()
+	return values, d == ndarray.Bytes(d.ItemSize()), nil
+}
+
+func mapped(a *ndarray.NDArray, keep bool, fn func(s string, isBytes bool) string) (*ndarray.NDArray, error) {
+
+	values, isBytes, err := elements(a)
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range values {
+		values[i] = fn(s, isBytes)
+	}
+
+	var out *ndarray.NDArray
+	switch {
+	case len(values) == 0:
+		return a.Copy(), nil
+	case isBytes:
+		raw := make([][]byte, len(values))
+		for i, s := range values {
+			raw[i] = []byte(s)
+		}
+		out, err = ndarray.FromByteStrings(raw)
+	default:
+		out, err = ndarray.FromStrings(values)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if out, err = reshaped(out, a); err != nil || !keep {
+		return out, err
+	}
+	return out.AsType(a.DType())
+}
+
+func tested(a *ndarray.NDArray, fn func(s string, isBytes bool) bool) (*ndarray.NDArray, error) {
+
+	values, isBytes, err := elements(a)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]bool, len(values))
+	for i, s := range values {
+		out[i] = fn(s, isBytes)
+	}
+	return shapedLike(a, out)
+}
+
+// shapedLike wraps one result per element of like in an array of its shape.
+func shapedLike[T bool | int64](like *ndarray.NDArray, values []T) (*ndarray.NDArray, error) {
+
+	if len(values) == 0 {
+		d := ndarray.Bool
+		if _, ok := any(values).([]int64); ok {
+			d = ndarray.Int64
+		}
+		return like.AsType(d)
+	}
+
+	out, err := ndarray.FromSliceOf(values)
+	if err != nil {
+		return nil, err
+	}
+	return reshaped(out, like)
+}
+
+// reshaped gives a flat array the shape of like, which may be 0-d.
+func reshaped(out, like *ndarray.NDArray) (*ndarray.NDArray, error) {
+	if shape := like.Shape(); len(shape) > 0 {
+		return out.Reshape(shape...)
+	}
+	return out.Squeeze()
+}
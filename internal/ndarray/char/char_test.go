@@ -0,0 +1,178 @@
+package char_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+	"github.com/arnaizaitor/gondor/internal/ndarray/char"
+)
+
+// texts builds a unicode array, failing the test on error.
+func texts(t *testing.T, values []string, shape ...int) *ndarray.NDArray {
+	t.Helper()
+	a, err := ndarray.FromStrings(values, shape...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return a
+}
+
+func TestCase(t *testing.T) {
+	a := texts(t, []string{"Oslo", "new york", "straße", "ǅ"}, 2, 2)
+	up, err := char.Upper(a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := up.String(), `NDArray(shape=[2 2], data=["OSLO" "NEW YORK" "STRAßE" "Ǆ"])`; got != want || up.DType() != a.DType() {
+		t.Errorf("upper: got %s (%s), want %s", got, up.DType(), want)
+	}
+	low, _ := char.Lower(a)
+	if got, want := low.String(), `NDArray(shape=[2 2], data=["oslo" "new york" "straße" "ǆ"])`; got != want {
+		t.Errorf("lower: got %s, want %s", got, want)
+	}
+
+	b, _ := ndarray.FromByteStrings([][]byte{[]byte("Ab\xffé")})
+	up, _ = char.Upper(b)
+	if got, _ := up.Strings(); got[0] != "AB\xff\xc3\xa9" || up.DType() != b.DType() {
+		t.Errorf("upper bytes: got %q (%s)", got, up.DType())
+	}
+
+	if _, err := char.Upper(ndarray.Scalar(1)); err == nil {
+		t.Errorf("expected error for a float64 array")
+	}
+}
+
+func TestStripReplaceJoin(t *testing.T) {
+	a := texts(t, []string{"  id ", "-x-", "\tnaïve\n"})
+	tests := []struct {
+		name string
+		fn   func() (*ndarray.NDArray, error)
+		want string
+	}{
+		{"strip", func() (*ndarray.NDArray, error) { return char.Strip(a, "") }, `["id" "-x-" "naïve"]`},
+		{"lstrip", func() (*ndarray.NDArray, error) { return char.LStrip(a, "") }, `["id " "-x-" "naïve\n"]`},
+		{"rstrip chars", func() (*ndarray.NDArray, error) { return char.RStrip(a, "- ") }, `["  id" "-x" "\tnaïve\n"]`},
+		{"replace", func() (*ndarray.NDArray, error) { return char.Replace(a, "-", "::", -1) }, `["  id " "::x::" "\tnaïve\n"]`},
+		{"replace once", func() (*ndarray.NDArray, error) { return char.Replace(a, "-", "", 1) }, `["  id " "x-" "\tnaïve\n"]`},
+		{"join", func() (*ndarray.NDArray, error) { return char.Join(".", a) }, `[" . .i.d. " "-.x.-" "\t.n.a.ï.v.e.\n"]`},
+	}
+	for _, tt := range tests {
+		out, err := tt.fn()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if got, _ := out.Strings(); fmt.Sprintf("%q", got) != tt.want {
+			t.Errorf("%s: got %q, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	// Strip keeps the dtype, Replace and Join fit the longest result
+	if s, _ := char.Strip(a, ""); s.DType() != ndarray.Unicode(7) {
+		t.Errorf("strip: got %s, want <U7", s.DType())
+	}
+	if r, _ := char.Replace(a, "-", "::", -1); r.DType() != ndarray.Unicode(7) {
+		t.Errorf("replace: got %s, want <U7", r.DType())
+	}
+	if j, _ := char.Join(".", a); j.DType() != ndarray.Unicode(13) {
+		t.Errorf("join: got %s, want <U13", j.DType())
+	}
+
+	raw, _ := ndarray.FromByteStrings([][]byte{[]byte("é")})
+	if j, _ := char.Join("|", raw); j.DType() != ndarray.Bytes(3) {
+		t.Errorf("join bytes: got %s, want |S3", j.DType())
+	}
+}
+
+func TestSplit(t *testing.T) {
+	a := texts(t, []string{"a,b,,c", " x  y ", ""})
+	tests := []struct {
+		sep      string
+		maxsplit int
+		want     string
+	}{
+		{",", -1, `[["a" "b" "" "c"] [" x  y "] [""]]`},
+		{",", 1, `[["a" "b,,c"] [" x  y "] [""]]`},
+		{"", -1, `[["a,b,,c"] ["x" "y"] []]`},
+		{"", 1, `[["a,b,,c"] ["x" "y "] []]`},
+		{"", 0, `[["a,b,,c"] ["x  y "] []]`},
+	}
+	for _, tt := range tests {
+		got, err := char.Split(a, tt.sep, tt.maxsplit)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprintf("%q", got) != tt.want {
+			t.Errorf("Split(%q, %d): got %q, want %s", tt.sep, tt.maxsplit, got, tt.want)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	a := texts(t, []string{"img_01.png", "naïve.txt", "bird"}, 3, 1)
+	starts, err := char.StartsWith(a, "img_")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := starts.String(), "NDArray(shape=[3 1], data=[true false false])"; got != want {
+		t.Errorf("startswith: got %s, want %s", got, want)
+	}
+	ends, _ := char.EndsWith(a, ".txt")
+	if got, want := ends.String(), "NDArray(shape=[3 1], data=[false true false])"; got != want {
+		t.Errorf("endswith: got %s, want %s", got, want)
+	}
+
+	found, _ := char.Find(a, "v")
+	if got, want := found.String(), "NDArray(shape=[3 1], data=[-1 3 -1])"; got != want || found.DType() != ndarray.Int64 {
+		t.Errorf("find: got %s (%s), want %s", got, found.DType(), want)
+	}
+	raw, _ := a.AsType(ndarray.Bytes(12))
+	if found, _ := char.Find(raw, "v"); found.String() != "NDArray(shape=[3 1], data=[-1 4 -1])" {
+		t.Errorf("find in bytes: got %s", found)
+	}
+
+	// 0-d arrays stay 0-d
+	s, _ := ndarray.ScalarOf("abc")
+	if f, _ := char.Find(s, "c"); len(f.Shape()) != 0 || f.String() != "NDArray(shape=[], data=[2])" {
+		t.Errorf("find on a scalar: got %s", f)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := texts(t, []string{"b", "apple  ", "Zoo"})
+	b := texts(t, []string{"apple"})
+	tests := []struct {
+		name string
+		fn   func(a, b *ndarray.NDArray) (*ndarray.NDArray, error)
+		want string
+	}{
+		{"equal", char.Equal, "[false true false]"},
+		{"not equal", char.NotEqual, "[true false true]"},
+		{"less", char.Less, "[false false true]"},
+		{"less equal", char.LessEqual, "[false true true]"},
+		{"greater", char.Greater, "[true false false]"},
+		{"greater equal", char.GreaterEqual, "[true true false]"},
+	}
+	for _, tt := range tests {
+		out, err := tt.fn(a, b)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if got, want := out.String(), "NDArray(shape=[3], data="+tt.want+")"; got != want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, want)
+		}
+	}
+
+	col := texts(t, []string{"a", "b"}, 2, 1)
+	if eq, _ := char.Equal(col, texts(t, []string{"a", "b", "c"})); eq.String() != "NDArray(shape=[2 3], data=[true false false false true false])" {
+		t.Errorf("broadcast equal: got %s", eq)
+	}
+
+	raw, _ := ndarray.FromByteStrings([][]byte{[]byte("apple")})
+	if _, err := char.Equal(a, raw); err == nil {
+		t.Errorf("expected error comparing unicode with bytes")
+	}
+	if _, err := char.Equal(a, texts(t, []string{"x", "y"})); err == nil {
+		t.Errorf("expected error for shapes that do not broadcast")
+	}
+}
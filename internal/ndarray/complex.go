@@ -0,0 +1,360 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║      ██████╗ ██████╗ ███╗   ███╗██████╗ ██╗     ███████╗██╗  ██╗                   ║
+// ║     ██╔════╝██╔═══██╗████╗ ████║██╔══██╗██║     ██╔════╝╚██╗██╔╝                   ║
+// ║     ██║     ██║   ██║██╔████╔██║██████╔╝██║     █████╗   ╚███╔╝                    ║
+// ║     ██║     ██║   ██║██║╚██╔╝██║██╔═══╝ ██║     ██╔══╝   ██╔██╗                    ║
+// ║     ╚██████╗╚██████╔╝██║ ╚═╝ ██║██║     ███████╗███████╗██╔╝ ██╗                   ║
+// ║      ╚═════╝ ╚═════╝ ╚═╝     ╚═╝╚═╝     ╚══════╝╚══════╝╚═╝  ╚═╝                   ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Complex numbers: real / imaginary views, conjugate, angle                         ║
+// ║  and the complex kernels, loops and folds behind the ufuncs.                       ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/complex.go               ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"math"
+	"math/cmplx"
+	"strconv"
+	"strings"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Real, Imag – Views of the real and imaginary parts                         ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a.real` and `a.imag`. For Complex128 (Complex64)                  ║
+// ║   arrays they are writeable Float64 (Float32) views that share memory              ║
+// ║   with `a`: element i of a complex buffer is stored as two floats, so              ║
+// ║   the parts are strided views of the same bytes.                                   ║
+// ║                                                                                    ║
+// ║   - Real of a real array is a plain view of `a`                                    ║
+// ║   - Imag of a real array is a read-only array of zeros                             ║
+// ║                                                                                    ║
+// ║   Returns: *NDArray                                                                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   z = [1+2i 3-4i] (complex128)                                                     ║
+// ║   z.Real()                        → [1 3]  (float64)                               ║
+// ║   z.Imag()                        → [2 -4]                                         ║
+// ║   Negative(z.Imag(), WithOut(z.Imag()))   → z = [1-2i 3+4i]                        ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Real() *NDArray {
+	if a.dtype.kind() != 'c' {
+		return a.view(a.shape, a.strides, a.offset)
+	}
+	return a.part(0)
+}
+
+func (a *NDArray) Imag() *NDArray {
+	if a.dtype.kind() != 'c' {
+		zeros := allocOf(append([]int(nil), a.shape...), a.dtype)
+		zeros.flags.Writeable = false
+		return zeros
+	}
+	return a.part(1)
+}
+
+// part views the real (0) or imaginary (1) halves of a complex array's elements.
+func (a *NDArray) part(which int) *NDArray {
+
+	if f, ok := a.data.(fieldBuf); ok {
+		return a.viewOf(f.part(which))
+	}
+
+	strides := make([]int, len(a.strides))
+	for k, st := range a.strides {
+		strides[k] = 2 * st
+	}
+
+	v := a.view(append([]int(nil), a.shape...), strides, 2*a.offset+which)
+	switch buf := a.data.(type) {
+	case complexBuf[complex128]:
+		v.data = buf.parts()
+	case complexBuf[complex64]:
+		v.data = buf.parts()
+	}
+	v.dtype = v.data.dtype()
+	return v
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Conj, Angle – Complex conjugate and argument                               ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Elementwise application of ConjUfunc (`np.conjugate`) and AngleUfunc             ║
+// ║   (`np.angle`, in radians).                                                        ║
+// ║                                                                                    ║
+// ║   - Conj keeps the dtype; real inputs are returned unchanged                       ║
+// ║   - Angle gives a real array: atan2(imag, real), so negative real                  ║
+// ║     numbers have angle π and the others 0                                          ║
+// ║   - Accept WithOut and WithWhere                                                   ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Conj([1+2i -1])      → [1-2i -1+0i]                                              ║
+// ║   Angle([1i -1 1])     → [1.5707… 3.1415… 0]                                       ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Conj(a *NDArray, opts ...Option) (*NDArray, error) {
+	return ConjUfunc.Call([]*NDArray{a}, opts...)
+}
+
+func Angle(a *NDArray, opts ...Option) (*NDArray, error) {
+	return AngleUfunc.Call([]*NDArray{a}, opts...)
+}
+
+// realPart returns the float dtype holding one half of complex dtype d.
+func realPart(d DType) DType {
+	if d == Complex64 {
+		return Float32
+	}
+	return Float64
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: complexKernel – Scalar kernel of a ufunc for complex dtypes                ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Operands arrive as complex128 whatever their dtype; unary kernels                ║
+// ║   ignore y. Predicates return 0 or 1, real-valued ufuncs (Abs, Angle)              ║
+// ║   put their result in the real part.                                               ║
+// ║                                                                                    ║
+// ║   Complex values are ordered lexicographically, real part first, as                ║
+// ║   in NumPy: Less, Maximum, Minimum and friends follow that order.                  ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   complexLess(1+5i, 2+0i) → true                                                   ║
+// ║   complexLess(1+1i, 1+2i) → true                                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type complexKernel func(x, y complex128) complex128
+
+func complexAdd(x, y complex128) complex128  { return x + y }
+func complexSub(x, y complex128) complex128  { return x - y }
+func complexMul(x, y complex128) complex128  { return x * y }
+func complexDiv(x, y complex128) complex128  { return x / y }
+func complexNeg(x, _ complex128) complex128  { return -x }
+func complexSq(x, _ complex128) complex128   { return x * x }
+func complexAbs(x, _ complex128) complex128  { return complex(cmplx.Abs(x), 0) }
+func complexConj(x, _ complex128) complex128 { return cmplx.Conj(x) }
+
+// complexAngle is np.angle: atan2(imag, real), in the real part.
+func complexAngle(x, _ complex128) complex128 {
+	return complex(math.Atan2(imag(x), real(x)), 0)
+}
+
+// complexPow uses repeated squaring for small integral exponents, like NumPy,
+// so (1i)**2 is exactly -1; other exponents go through cmplx.Pow.
+func complexPow(x, y complex128) complex128 {
+
+	n := real(y)
+	if imag(y) != 0 || n != math.Trunc(n) || math.Abs(n) > 100 {
+		return cmplx.Pow(x, y)
+	}
+
+	result, base := complex128(1), x
+	for e := int(math.Abs(n)); e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result *= base
+		}
+		base *= base
+	}
+	if n < 0 {
+		return 1 / result
+	}
+	return result
+}
+
+// complexUnary adapts a math/cmplx function to a complexKernel.
+func complexUnary(f func(complex128) complex128) complexKernel {
+	return func(x, _ complex128) complex128 { return f(x) }
+}
+
+// complexLess orders complex numbers by real part, then imaginary part.
+func complexLess(x, y complex128) bool {
+	return real(x) < real(y) || (real(x) == real(y) && imag(x) < imag(y))
+}
+
+// complexPredicate turns a test on two complex numbers into a kernel returning 0 or 1.
+func complexPredicate(test func(x, y complex128) bool) complexKernel {
+	return func(x, y complex128) complex128 {
+		if test(x, y) {
+			return 1
+		}
+		return 0
+	}
+}
+
+// complexMaximum and complexMinimum propagate a NaN in either operand, like their
+// float64 counterparts.
+func complexMaximum(x, y complex128) complex128 {
+	if cmplx.IsNaN(x) || (!cmplx.IsNaN(y) && !complexLess(x, y)) {
+		return x
+	}
+	return y
+}
+
+func complexMinimum(x, y complex128) complex128 {
+	if cmplx.IsNaN(x) || (!cmplx.IsNaN(y) && !complexLess(y, x)) {
+		return x
+	}
+	return y
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: complexLoop – The complex counterpart of Ufunc.loop                        ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Reads every operand as complex128, applies u.complex and stores the              ║
+// ║   result into `out` through the storage interface, rounded to                      ║
+// ║   complex64 when that is the compute dtype.                                        ║
+// ║                                                                                    ║
+// ║   Returns: error                                                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (u *Ufunc) complexLoop(out *NDArray, inputs []*NDArray, where *NDArray, compute DType) error {
+
+	operands, strides, offsets, err := broadcastOperands(out, inputs, where)
+	if err != nil {
+		return err
+	}
+
+	x := operands[1]
+	var y, mask *NDArray
+	if u.nin == 2 {
+		y = operands[2]
+	}
+	if where != nil {
+		mask = operands[len(operands)-1]
+	}
+	single := compute == Complex64
+
+	walk(out.shape, strides, offsets, func(offs, inner []int, n int) {
+		pos := append([]int(nil), offs...)
+		for i := 0; i < n; i++ {
+			if mask == nil || mask.data.float(pos[len(pos)-1]) != 0 {
+				var b complex128
+				if y != nil {
+					b = y.data.complex(pos[2])
+				}
+				v := u.complex(x.data.complex(pos[1]), b)
+				if single {
+					v = complex128(complex64(v))
+				}
+				out.data.setComplex(pos[0], v)
+			}
+			for k := range pos {
+				pos[k] += inner[k]
+			}
+		}
+	})
+	return nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: foldComplex – Complex counterpart of foldAxes                              ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Folds `a` in complex128 and returns the folds as an array of dtype               ║
+// ║   `result`. Sums fold the real and imaginary parts separately through              ║
+// ║   foldAxes, so they get the same Summation algorithms as real sums.                ║
+// ║                                                                                    ║
+// ║   Returns: *NDArray – shape of `a` with reduced axes set to 1                      ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func foldComplex(a *NDArray, reduced []bool, where *NDArray, u *Ufunc, summation Summation, initial float64, hasInitial bool, result DType) (*NDArray, error) {
+
+	kept := keptShape(a.shape, reduced)
+	res := allocOf(kept, result)
+
+	if u.additive {
+		re, err := u.floatInput(a.Real())
+		if err != nil {
+			return nil, err
+		}
+		im, err := u.floatInput(a.Imag())
+		if err != nil {
+			return nil, err
+		}
+		reSum := foldAxes(re, reduced, where, u, summation, initial, hasInitial).floats()
+		imSum := foldAxes(im, reduced, where, u, summation, 0, hasInitial).floats()
+		for i := range reSum {
+			res.data.setComplex(i, complex(reSum[i], imSum[i]))
+		}
+		return res, nil
+	}
+
+	acc := make([]complex128, res.Size())
+	seen := make([]bool, len(acc))
+	if hasInitial {
+		for i := range acc {
+			acc[i] = complex(initial, 0)
+			seen[i] = true
+		}
+	}
+
+	shape, operands, offsets := foldPlan(a, kept, reduced, where)
+	walk(shape, operands, offsets, func(offs, inner []int, n int) {
+		r, x := offs[0], offs[1]
+		m := 0
+		if where != nil {
+			m = offs[2]
+		}
+		for i := 0; i < n; i++ {
+			if where == nil || where.data.float(m) != 0 {
+				v := a.data.complex(x)
+				if seen[r] {
+					v = u.complex(acc[r], v)
+				}
+				acc[r], seen[r] = v, true
+			}
+			r += inner[0]
+			x += inner[1]
+			if where != nil {
+				m += inner[2]
+			}
+		}
+	})
+
+	for i, v := range acc {
+		res.data.setComplex(i, v)
+	}
+	return res, nil
+}
+
+// formatComplex prints a complex element the way String prints floats, as
+// re+imi with the shortest representation of each part at the given bit size.
+func formatComplex(v complex128, bits int) string {
+	re := strconv.FormatFloat(real(v), 'g', -1, bits)
+	im := strconv.FormatFloat(imag(v), 'g', -1, bits)
+	if !strings.HasPrefix(im, "-") && !strings.HasPrefix(im, "+") {
+		im = "+" + im
+	}
+	return re + im + "i"
+}
+
+// formatComplexes prints the elements of a complex buffer like %v prints a slice.
+func formatComplexes(buf storage) string {
+	bits := 64
+	if buf.dtype() == Complex64 {
+		bits = 32
+	}
+
+	parts := make([]string, buf.len())
+	for i := range parts {
+		parts[i] = formatComplex(buf.complex(i), bits)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
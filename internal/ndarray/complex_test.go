@@ -0,0 +1,204 @@
+package ndarray_test
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestRealImagViews(t *testing.T) {
+	z := typed(t, 1+2i, 3-4i, 5+6i, 7-8i)
+	z, _ = z.Reshape(2, 2)
+
+	re, im := z.Real(), z.Imag()
+	if got, want := re.String(), "NDArray(shape=[2 2], data=[1 3 5 7])"; got != want || re.DType() != ndarray.Float64 {
+		t.Errorf("real: got %s (%s), want %s", got, re.DType(), want)
+	}
+	if got, want := im.String(), "NDArray(shape=[2 2], data=[2 -4 6 -8])"; got != want {
+		t.Errorf("imag: got %s, want %s", got, want)
+	}
+	if got, want := z.T().Imag().String(), "NDArray(shape=[2 2], data=[2 6 -4 -8])"; got != want {
+		t.Errorf("imag of transpose: got %s, want %s", got, want)
+	}
+
+	// The parts share memory with z
+	if _, err := ndarray.Negative(im, ndarray.WithOut(im)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := re.Set(0, 0, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := z.String(), "NDArray(shape=[2 2], data=[0-2i 3+4i 5-6i 7+8i])"; got != want {
+		t.Errorf("after writing through the views: got %s, want %s", got, want)
+	}
+
+	single := typed[complex64](t, 0.1+0.2i)
+	if re := single.Real(); re.DType() != ndarray.Float32 || re.String() != "NDArray(shape=[1], data=[0.1])" {
+		t.Errorf("real of complex64: got %s (%s)", re, re.DType())
+	}
+
+	// Real arrays: Real is a view, Imag read-only zeros
+	x := typed(t, 1.5, -2.0)
+	if err := x.Real().Set(7, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := x.String(), "NDArray(shape=[2], data=[1.5 7])"; got != want {
+		t.Errorf("expected Real of a real array to be a view: got %s, want %s", got, want)
+	}
+	if zi := x.Imag(); zi.String() != "NDArray(shape=[2], data=[0 0])" || zi.Flags().Writeable {
+		t.Errorf("imag of real array: got %s, writeable %v", zi, zi.Flags().Writeable)
+	}
+}
+
+func TestComplexArithmetic(t *testing.T) {
+	tests := []struct {
+		name  string
+		op    func(a, b *ndarray.NDArray, opts ...ndarray.Option) (*ndarray.NDArray, error)
+		a, b  *ndarray.NDArray
+		dtype ndarray.DType
+		want  string
+	}{
+		{"add", ndarray.Add, typed(t, 1+2i), typed(t, 3-4i), ndarray.Complex128, "[4-2i]"},
+		{"mul", ndarray.Mul, typed(t, 1+2i), typed(t, 3-4i), ndarray.Complex128, "[11+2i]"},
+		{"div", ndarray.Div, typed(t, 11+2i), typed(t, 3-4i), ndarray.Complex128, "[1+2i]"},
+		{"power", ndarray.Pow, typed(t, 1i, 2+0i), typed(t, 2+0i, 0i), ndarray.Complex128, "[-1+0i 1+0i]"},
+		{"complex + float64", ndarray.Add, typed(t, 1i), typed(t, 2.5), ndarray.Complex128, "[2.5+1i]"},
+		{"complex64 + float32", ndarray.Add, typed[complex64](t, 1i), typed[float32](t, 0.1), ndarray.Complex64, "[0.1+1i]"},
+		{"complex64 + float64", ndarray.Add, typed[complex64](t, 1i), typed(t, 0.5), ndarray.Complex128, "[0.5+1i]"},
+		{"complex64 + int16", ndarray.Add, typed[complex64](t, 1i), typed[int16](t, 2), ndarray.Complex64, "[2+1i]"},
+		{"complex64 + int32", ndarray.Add, typed[complex64](t, 1i), typed[int32](t, 2), ndarray.Complex128, "[2+1i]"},
+		{"equal", ndarray.Equal, typed(t, 1+2i, 1+2i), typed(t, 1+2i, 1-2i), ndarray.Bool, "[true false]"},
+		{"less is lexicographic", ndarray.Less, typed(t, 1+5i, 1+1i, 2+0i), typed(t, 2+0i, 1+2i, 1+9i), ndarray.Bool, "[true true false]"},
+		{"maximum", ndarray.Maximum, typed(t, 1+5i, 1+1i), typed(t, 2+0i, 1+2i), ndarray.Complex128, "[2+0i 1+2i]"},
+		{"maximum keeps a NaN of either side", ndarray.Maximum, typed(t, 1+0i, complex(math.NaN(), 0)), typed(t, complex(math.NaN(), 0), 1+0i), ndarray.Complex128, "[NaN+0i NaN+0i]"},
+		{"minimum keeps a NaN of either side", ndarray.Minimum, typed(t, 1+0i, complex(math.NaN(), 0)), typed(t, complex(math.NaN(), 0), 1+0i), ndarray.Complex128, "[NaN+0i NaN+0i]"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.op(tt.a, tt.b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if want := "NDArray(shape=" + fmt.Sprint(got.Shape()) + ", data=" + tt.want + ")"; got.String() != want || got.DType() != tt.dtype {
+			t.Errorf("%s: got %s (%s), want %s (%s)", tt.name, got, got.DType(), want, tt.dtype)
+		}
+	}
+
+	z := typed(t, 1+2i)
+	if _, err := ndarray.Mod(z, z); err == nil {
+		t.Errorf("expected error for complex remainder")
+	}
+	custom := ndarray.NewUnaryUfunc("twice", func(x float64) float64 { return 2 * x })
+	if _, err := custom.Call([]*ndarray.NDArray{z}); err == nil {
+		t.Errorf("expected error for complex input to a real-only custom ufunc")
+	}
+}
+
+func TestComplexMath(t *testing.T) {
+	z := typed(t, 3+4i, -1+0i, 1i)
+
+	abs, err := ndarray.Abs(z)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := abs.String(), "NDArray(shape=[3], data=[5 1 1])"; got != want || abs.DType() != ndarray.Float64 {
+		t.Errorf("abs: got %s (%s), want %s", got, abs.DType(), want)
+	}
+	if a, _ := ndarray.Abs(typed[complex64](t, 3+4i)); a.DType() != ndarray.Float32 {
+		t.Errorf("abs of complex64: got %s, want float32", a.DType())
+	}
+
+	conj, _ := ndarray.Conj(z)
+	if got, want := conj.String(), "NDArray(shape=[3], data=[3-4i -1-0i 0-1i])"; got != want {
+		t.Errorf("conj: got %s, want %s", got, want)
+	}
+	if c, _ := ndarray.Conj(typed[int8](t, -3)); c.DType() != ndarray.Int8 || c.String() != "NDArray(shape=[1], data=[-3])" {
+		t.Errorf("conj of int8: got %s (%s)", c, c.DType())
+	}
+
+	angle, _ := ndarray.Angle(z)
+	want := []float64{math.Atan2(4, 3), math.Pi, math.Pi / 2}
+	got, _ := ndarray.ToSliceOf[float64](angle)
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-15 {
+			t.Errorf("angle[%d]: got %v, want %v", i, got[i], want[i])
+		}
+	}
+	if a, _ := ndarray.Angle(typed(t, -2.0, 3)); a.String() != "NDArray(shape=[2], data=[3.141592653589793 0])" {
+		t.Errorf("angle of reals: got %s", a)
+	}
+
+	root, _ := ndarray.Sqrt(typed(t, -1+0i, -4+0i))
+	if got, want := root.String(), "NDArray(shape=[2], data=[0+1i 0+2i])"; got != want {
+		t.Errorf("sqrt: got %s, want %s", got, want)
+	}
+
+	e, _ := ndarray.Exp(typed(t, complex(0, math.Pi)))
+	if v, _ := e.Value(0); cmplx.Abs(v.(complex128)-(-1)) > 1e-15 {
+		t.Errorf("exp(iπ): got %v, want -1", v)
+	}
+}
+
+func TestComplexReductions(t *testing.T) {
+	z := typed(t, 1+2i, 3-4i, 5+0i)
+
+	sum, err := z.Sum()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := sum.String(), "NDArray(shape=[], data=[9-2i])"; got != want || sum.DType() != ndarray.Complex128 {
+		t.Errorf("sum: got %s (%s), want %s", got, sum.DType(), want)
+	}
+	if p, _ := z.Prod(); p.String() != "NDArray(shape=[], data=[55+10i])" {
+		t.Errorf("prod: got %s", p)
+	}
+	if m, _ := z.Max(); m.String() != "NDArray(shape=[], data=[5+0i])" {
+		t.Errorf("max: got %s", m)
+	}
+	nan := typed(t, 1+2i, 3-1i, complex(math.NaN(), 0))
+	if m, _ := nan.Max(); m.String() != "NDArray(shape=[], data=[NaN+0i])" {
+		t.Errorf("max with a trailing NaN: got %s", m)
+	}
+	if m, _ := nan.Min(); m.String() != "NDArray(shape=[], data=[NaN+0i])" {
+		t.Errorf("min with a trailing NaN: got %s", m)
+	}
+	if m, _ := z.Mean(); m.String() != "NDArray(shape=[], data=[3-0.6666666666666666i])" {
+		t.Errorf("mean: got %s", m)
+	}
+
+	v, err := typed(t, 1i, -1i).Var()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.String() != "NDArray(shape=[], data=[1])" || v.DType() != ndarray.Float64 {
+		t.Errorf("var: got %s (%s), want 1 (float64)", v, v.DType())
+	}
+
+	acc, _ := ndarray.AddUfunc.Accumulate(z, 0)
+	if got, want := acc.String(), "NDArray(shape=[3], data=[1+2i 4-2i 9-2i])"; got != want {
+		t.Errorf("accumulate: got %s, want %s", got, want)
+	}
+	if a, _ := z.Any(); a.String() != "NDArray(shape=[], data=[true])" {
+		t.Errorf("any: got %s", a)
+	}
+}
+
+func TestComplexString(t *testing.T) {
+	tests := []struct {
+		a    *ndarray.NDArray
+		want string
+	}{
+		{typed(t, 1+2i, -0.5-1i), "NDArray(shape=[2], data=[1+2i -0.5-1i])"},
+		{typed(t, complex(math.Inf(1), math.NaN())), "NDArray(shape=[1], data=[+Inf+NaNi])"},
+		{typed[complex64](t, 0.1+0.2i), "NDArray(shape=[1], data=[0.1+0.2i])"},
+	}
+	for _, tt := range tests {
+		if got := tt.a.String(); got != tt.want {
+			t.Errorf("got %s, want %s", got, tt.want)
+		}
+	}
+}
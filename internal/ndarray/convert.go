@@ -0,0 +1,333 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║      ██████╗ ██████╗ ███╗   ██╗██╗   ██╗███████╗██████╗ ████████╗                  ║
+// ║     ██╔════╝██╔═══██╗████╗  ██║██║   ██║██╔════╝██╔══██╗╚══██╔══╝                  ║
+// ║     ██║     ██║   ██║██╔██╗ ██║██║   ██║█████╗  ██████╔╝   ██║                     ║
+// ║     ██║     ██║   ██║██║╚██╗██║╚██╗ ██╔╝██╔══╝  ██╔══██╗   ██║                     ║
+// ║     ╚██████╗╚██████╔╝██║ ╚████║ ╚████╔╝ ███████╗██║  ██║   ██║                     ║
+// ║      ╚═════╝ ╚═════╝ ╚═╝  ╚═══╝  ╚═══╝  ╚══════╝╚═╝  ╚═╝   ╚═╝                     ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Conversions between NDArray and plain Go values: flat                             ║
+// ║  slices, nested slices/arrays and back.                                            ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/convert.go               ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: FromSlice – Build an NDArray from a flat []float64                         ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Interprets `data` in row-major (C) order with the given shape.                   ║
+// ║                                                                                    ║
+// ║   - The data is copied; see FromSliceNoCopy to share it instead                    ║
+// ║   - Without a shape the result is 1-D with len(data) elements                      ║
+// ║   - One dimension may be -1 and is inferred, as in Reshape                         ║
+// ║   - The shape must account for exactly len(data) elements                          ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   FromSlice([]float64{1, 2, 3, 4, 5, 6}, 2, -1) → shape [2, 3]                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func FromSlice(data []float64, shape ...int) (*NDArray, error) {
+	return FromSliceNoCopy(append([]float64(nil), data...), shape...)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: FromSliceNoCopy – Wrap a []float64 without copying it                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Same as FromSlice, but the array uses `data` as its buffer: writes               ║
+// ║   through either side are visible in the other.                                    ║
+// ║                                                                                    ║
+// ║   - The array owns the buffer from now on (OwnData is set); the caller             ║
+// ║     should not resize it                                                           ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   buf := []float64{1, 2, 3}                                                        ║
+// ║   a, _ := FromSliceNoCopy(buf)                                                     ║
+// ║   buf[0] = 9                   → a.Get(0) == 9                                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func FromSliceNoCopy(data []float64, shape ...int) (*NDArray, error) {
+
+	if len(shape) == 0 {
+		shape = []int{len(data)}
+		if len(data) == 0 {
+			return newOwned(data, shape, cStrides(shape)), nil
+		}
+	}
+
+	resolved, err := resolveShape(len(data), shape)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOwned(data[:len(data):len(data)], resolved, cStrides(resolved)), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: FromSliceOf – Build an NDArray from a flat slice of any element type       ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Generic counterpart of FromSlice: the dtype follows the Go type                  ║
+// ║   ([]uint8 → Uint8, []int64 → Int64, []complex128 → Complex128, ...).              ║
+// ║                                                                                    ║
+// ║   - The data is copied                                                             ║
+// ║   - Shape rules are those of FromSlice                                             ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   img, _ := FromSliceOf(pixels, 480, 640, 3)   → dtype Uint8                       ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func FromSliceOf[T Element](data []T, shape ...int) (*NDArray, error) {
+
+	if len(shape) == 0 {
+		shape = []int{len(data)}
+	}
+
+	resolved, err := resolveShape(len(data), shape)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := storageOf(append([]T(nil), data...))
+	return newStorage(buf, resolved, cStrides(resolved)), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: ToSliceOf – Copy the elements into a flat slice of their own type          ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Generic counterpart of ToSlice, in row-major (C) order.                          ║
+// ║                                                                                    ║
+// ║   - T must match the array's dtype; use AsType first to convert                    ║
+// ║                                                                                    ║
+// ║   Returns: ([]T, error)                                                            ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   labels, _ := ToSliceOf[int32](a)   → error unless a.DType() == Int32             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func ToSliceOf[T Element](a *NDArray) ([]T, error) {
+
+	out, ok := gather(a).slice().([]T)
+	if !ok {
+		return nil, fmt.Errorf("cannot read a %s array as []%T", a.dtype, *new(T))
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: FromNested – Build an NDArray from nested Go slices or arrays              ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.array(obj)` for Go values, using reflection.                   ║
+// ║                                                                                    ║
+// ║   - Accepts any nesting of slices, arrays and interfaces holding them              ║
+// ║     ([][]float64, [3][2]int, []any{...}, ...)                                      ║
+// ║   - Leaves may be any integer, unsigned, float or bool kind                        ║
+// ║   - The result is a Bool array when every leaf is a bool, else Float64             ║
+// ║   - The shape is taken from the first element at each level; every                 ║
+// ║     sibling must match it, otherwise the input is ragged and rejected              ║
+// ║   - A bare number gives a 0-d array                                                ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   FromNested([][]int{{1, 2, 3}, {4, 5, 6}}) → shape [2, 3]                         ║
+// ║   FromNested([][]int{{1, 2}, {3}})          → error (inhomogeneous shape)          ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func FromNested(value any) (*NDArray, error) {
+
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return nil, fmt.Errorf("cannot build an array from nil")
+	}
+
+	// Follow the first element down to find the candidate shape
+	shape := []int{}
+	for cur := elem(v); isSequence(cur); {
+		shape = append(shape, cur.Len())
+		if len(shape) > 32 {
+			return nil, fmt.Errorf("shape has too many dimensions (max 32)")
+		}
+		if cur.Len() == 0 {
+			break
+		}
+		cur = elem(cur.Index(0))
+	}
+
+	data := make([]float64, 0, shapeSize(shape))
+	bools := 0
+	if err := flattenNested(elem(v), shape, 0, &data, &bools); err != nil {
+		return nil, err
+	}
+
+	out := newOwned(data, shape, cStrides(shape))
+	if len(data) > 0 && bools == len(data) {
+		out = out.withDType(Bool)
+	}
+	return out, nil
+}
+
+// flattenNested appends the leaves under v in C order, checking they match shape[depth:],
+// and counts the leaves that are Go bools.
+func flattenNested(v reflect.Value, shape []int, depth int, data *[]float64, bools *int) error {
+
+	if depth == len(shape) {
+		if isSequence(v) {
+			return inhomogeneous(shape, depth)
+		}
+		x, err := leafValue(v)
+		if err != nil {
+			return err
+		}
+		*data = append(*data, x)
+		if v.Kind() == reflect.Bool {
+			*bools++
+		}
+		return nil
+	}
+
+	if !isSequence(v) || v.Len() != shape[depth] {
+		return inhomogeneous(shape, depth)
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		if err := flattenNested(elem(v.Index(i)), shape, depth+1, data, bools); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inhomogeneous reports a ragged input, mirroring NumPy's wording.
+func inhomogeneous(shape []int, depth int) error {
+	return fmt.Errorf("setting an array element with a sequence: the requested array has an "+
+		"inhomogeneous shape after %d dimensions (detected shape %v + inhomogeneous part)",
+		depth, shape[:depth])
+}
+
+// elem unwraps interfaces and pointers until it reaches a concrete value.
+func elem(v reflect.Value) reflect.Value {
+	for (v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer) && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// isSequence reports whether v is a slice or array.
+func isSequence(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+// leafValue converts a numeric or bool reflect.Value to float64.
+func leafValue(v reflect.Value) (float64, error) {
+
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.Invalid:
+		return 0, fmt.Errorf("cannot convert nil to float64")
+	default:
+		return 0, fmt.Errorf("cannot convert value of type %s to float64", v.Type())
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: ToSlice – Copy the elements into a flat []float64                          ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Row-major (C) order, whatever the array's strides are.                           ║
+// ║                                                                                    ║
+// ║   - Always a fresh slice, never the array's own buffer                             ║
+// ║                                                                                    ║
+// ║   Returns: []float64                                                               ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a (shape [2, 2]) = [[1 2] [3 4]]                                                 ║
+// ║   a.ToSlice() → [1 2 3 4]                                                          ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) ToSlice() []float64 {
+	return gatherData(a)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: ToNested – Copy the elements into nested Go slices                         ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a.tolist()`, with typed slices instead of lists.                  ║
+// ║                                                                                    ║
+// ║   - A 1-D array gives []float64, 2-D gives [][]float64, and so on                  ║
+// ║   - A 0-d array gives a plain float64                                              ║
+// ║   - Use a type assertion to get the concrete type back                             ║
+// ║                                                                                    ║
+// ║   Returns: any                                                                     ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   m := a.ToNested().([][]float64)                                                  ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) ToNested() any {
+
+	data := gatherData(a)
+	if len(a.shape) == 0 {
+		return data[0]
+	}
+
+	return buildNested(data, a.shape).Interface()
+}
+
+// buildNested turns C-ordered data into a nested slice value of the given shape.
+func buildNested(data []float64, shape []int) reflect.Value {
+
+	if len(shape) == 1 {
+		return reflect.ValueOf(data[:shape[0]:shape[0]])
+	}
+
+	typ := reflect.TypeOf([]float64(nil))
+	for range shape[1:] {
+		typ = reflect.SliceOf(typ)
+	}
+
+	out := reflect.MakeSlice(typ, shape[0], shape[0])
+	step := shapeSize(shape[1:])
+	for i := 0; i < shape[0]; i++ {
+		out.Index(i).Set(buildNested(data[i*step:], shape[1:]))
+	}
+	return out
+}
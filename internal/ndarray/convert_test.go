@@ -0,0 +1,132 @@
+package ndarray_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestFromSlice(t *testing.T) {
+	data := []float64{1, 2, 3, 4, 5, 6}
+
+	a, err := ndarray.FromSlice(data, 2, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := a.String(); got != "NDArray(shape=[2 3], data=[1 2 3 4 5 6])" {
+		t.Errorf("unexpected array: %s", got)
+	}
+
+	// FromSlice copies, so later writes to data are not seen
+	data[0] = 100
+	if v, _ := a.Get(0, 0); v != 1 {
+		t.Errorf("expected copied data, got %v", v)
+	}
+
+	if _, err := ndarray.FromSlice(data, 4); err == nil {
+		t.Error("expected error for mismatched shape, got nil")
+	}
+
+	empty, err := ndarray.FromSlice(nil)
+	if err != nil {
+		t.Fatalf("unexpected error for empty slice: %v", err)
+	}
+	if got := empty.Shape(); len(got) != 1 || got[0] != 0 {
+		t.Errorf("expected shape [0], got %v", got)
+	}
+}
+
+func TestFromSliceNoCopy(t *testing.T) {
+	buf := []float64{1, 2, 3, 4}
+
+	a, err := ndarray.FromSliceNoCopy(buf, 2, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf[3] = 40
+	if v, _ := a.Get(1, 1); v != 40 {
+		t.Errorf("expected shared buffer, got %v", v)
+	}
+
+	_ = a.Set(10, 0, 0)
+	if buf[0] != 10 {
+		t.Errorf("expected write through to the slice, got %v", buf[0])
+	}
+}
+
+func TestFromNested(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"floats", [][]float64{{1, 2, 3}, {4, 5, 6}}, "NDArray(shape=[2 3], data=[1 2 3 4 5 6])"},
+		{"ints", []int{1, -2}, "NDArray(shape=[2], data=[1 -2])"},
+		{"arrays", [2][1][2]uint8{{{1, 2}}, {{3, 4}}}, "NDArray(shape=[2 1 2], data=[1 2 3 4])"},
+		{"interfaces", []any{[]float32{1, 2}, [2]int{3, 4}}, "NDArray(shape=[2 2], data=[1 2 3 4])"},
+		{"bools", []bool{true, false}, "NDArray(shape=[2], data=[true false])"},
+		{"mixed bools", []any{true, 2}, "NDArray(shape=[2], data=[1 2])"},
+		{"scalar", 7, "NDArray(shape=[], data=[7])"},
+		{"empty", [][]float64{}, "NDArray(shape=[0], data=[])"},
+	}
+
+	for _, tt := range tests {
+		a, err := ndarray.FromNested(tt.value)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got := a.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFromNestedErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"ragged rows", [][]float64{{1, 2}, {3}}},
+		{"mixed depth", []any{1.0, []float64{2}}},
+		{"sequence where scalar expected", []any{[]float64{1}, 2.0}},
+		{"strings", []string{"a"}},
+		{"nil", nil},
+	}
+
+	for _, tt := range tests {
+		if _, err := ndarray.FromNested(tt.value); err == nil {
+			t.Errorf("%s: expected error, got nil", tt.name)
+		}
+	}
+}
+
+func TestToSliceAndNested(t *testing.T) {
+	a := seq(t, 2, 3)
+
+	// Column 1 is a strided view; ToSlice still returns it in order
+	col, _ := a.Slice(ndarray.All, ndarray.At(1))
+	if got := col.ToSlice(); !reflect.DeepEqual(got, []float64{1, 4}) {
+		t.Errorf("unexpected ToSlice of view: %v", got)
+	}
+
+	nested, ok := a.ToNested().([][]float64)
+	if !ok {
+		t.Fatalf("expected [][]float64, got %T", a.ToNested())
+	}
+	if !reflect.DeepEqual(nested, [][]float64{{0, 1, 2}, {3, 4, 5}}) {
+		t.Errorf("unexpected ToNested: %v", nested)
+	}
+
+	if got := ndarray.Scalar(3).ToNested(); got != 3.0 {
+		t.Errorf("expected float64 for 0-d array, got %v", got)
+	}
+
+	// Round trip
+	back, err := ndarray.FromNested(nested)
+	if err != nil || back.String() != a.String() {
+		t.Errorf("round trip failed: %v, %v", back, err)
+	}
+}
@@ -0,0 +1,539 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║      ██████╗██████╗ ███████╗ █████╗ ████████╗███████╗                              ║
+// ║     ██╔════╝██╔══██╗██╔════╝██╔══██╗╚══██╔══╝██╔════╝                              ║
+// ║     ██║     ██████╔╝█████╗  ███████║   ██║   █████╗                                ║
+// ║     ██║     ██╔══██╗██╔══╝  ██╔══██║   ██║   ██╔══╝                                ║
+// ║     ╚██████╗██║  ██║███████╗██║  ██║   ██║   ███████╗                              ║
+// ║      ╚═════╝╚═╝  ╚═╝╚══════╝╚═╝  ╚═╝   ╚═╝   ╚══════╝                              ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Array creation routines for NDArray: constants, ranges,                           ║
+// ║  spaced sequences, identity and triangular matrices, grids.                        ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/create.go                ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"fmt"
+	"math"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Empty – Create an array without meaningful initial values                  ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Counterpart of `np.empty`. Go always zeroes new memory, so the result            ║
+// ║   is the same as Zeros; callers should still not rely on its contents.             ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   buf, _ := Empty(1024, 3)                                                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Empty(shape ...int) (*NDArray, error) {
+	return New(shape...)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: ZerosLike, OnesLike, FullLike, EmptyLike – Arrays shaped like another      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Allocate a new C-ordered array with the shape of `a`.                            ║
+// ║                                                                                    ║
+// ║   - Only the shape is taken from `a`, never its data or strides                    ║
+// ║   - Works for any shape `a` can have, including 0-d and empty arrays               ║
+// ║                                                                                    ║
+// ║   Returns: *NDArray                                                                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a.Shape()               → [2, 3]                                                 ║
+// ║   FullLike(a, -1).Shape() → [2, 3], every element -1                               ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func ZerosLike(a *NDArray) *NDArray {
+	return allocOf(append([]int(nil), a.shape...), a.dtype)
+}
+
+func OnesLike(a *NDArray) *NDArray {
+	return FullLike(a, 1.0)
+}
+
+func FullLike(a *NDArray, value float64) *NDArray {
+	out := ZerosLike(a)
+	for i := 0; i < out.data.len(); i++ {
+		out.data.setFloat(i, value)
+	}
+	return out
+}
+
+func EmptyLike(a *NDArray) *NDArray {
+	return ZerosLike(a)
+}
+
+// maxArangeLength is the longest Arange result: the most float64 values an int can
+// count and Go can allocate in one slice.
+const maxArangeLength = min(math.MaxInt, 1<<45)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Arange – Evenly spaced values within a half-open interval                  ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.arange(start, stop, step)`: values start + i*step              ║
+// ║   for every i with the value still before `stop`.                                  ║
+// ║                                                                                    ║
+// ║   - Length is ceil((stop - start) / step), or 0 if that is negative                ║
+// ║   - step may be negative but not zero                                              ║
+// ║   - Non-integer steps are subject to rounding; prefer Linspace then                ║
+// ║   - Lengths too large to allocate are an error                                     ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – 1-D array                                           ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Arange(0, 5, 1)    → [0 1 2 3 4]                                                 ║
+// ║   Arange(1, 0, -0.25) → [1 0.75 0.5 0.25]                                          ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Arange(start, stop, step float64) (*NDArray, error) {
+
+	if step == 0 {
+		return nil, fmt.Errorf("arange: step must not be zero")
+	}
+
+	length := math.Ceil((stop - start) / step)
+	if math.IsNaN(length) || math.IsInf(length, 0) {
+		return nil, fmt.Errorf("arange: cannot compute length")
+	}
+	if length < 0 {
+		length = 0
+	}
+	if length > maxArangeLength {
+		return nil, fmt.Errorf("arange: maximum allowed size exceeded")
+	}
+
+	out := alloc([]int{int(length)})
+	data := out.floats()
+	for i := range data {
+		data[i] = start + float64(i)*step
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Linspace – `num` evenly spaced values over an interval                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.linspace(start, stop, num, endpoint, retstep)`.                ║
+// ║                                                                                    ║
+// ║   - WithEndpoint(false) excludes `stop` (interval becomes [start, stop))           ║
+// ║   - WithRetStep(&step) stores the spacing in `step`                                ║
+// ║   - With the endpoint, the last value is exactly `stop`                            ║
+// ║   - num = 0 gives an empty array, num < 0 is an error                              ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Linspace(2, 3, 5)                      → [2 2.25 2.5 2.75 3]                     ║
+// ║   Linspace(2, 3, 5, WithEndpoint(false)) → [2 2.2 2.4 2.6 2.8]                     ║
+// ║                                                                                    ║
+// ║   var step float64                                                                 ║
+// ║   Linspace(0, 1, 11, WithRetStep(&step)) → step = 0.1                              ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Linspace(start, stop float64, num int, opts ...Option) (*NDArray, error) {
+
+	if num < 0 {
+		return nil, fmt.Errorf("number of samples, %d, must be non-negative", num)
+	}
+
+	o := collectOptions(opts)
+	div := num
+	if !o.noEndpoint {
+		div = num - 1
+	}
+
+	step := math.NaN()
+	if div > 0 {
+		step = (stop - start) / float64(div)
+	}
+
+	out := alloc([]int{num})
+	data := out.floats()
+	for i := range data {
+		if div > 0 {
+			data[i] = start + float64(i)*step
+		} else {
+			data[i] = start
+		}
+	}
+	if !o.noEndpoint && num > 1 {
+		data[num-1] = stop
+	}
+
+	if o.retStep != nil {
+		*o.retStep = step
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Logspace – Values evenly spaced on a log scale                             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.logspace(start, stop, num, endpoint, base)`:                   ║
+// ║   base ** Linspace(start, stop, num).                                              ║
+// ║                                                                                    ║
+// ║   - Honours WithEndpoint                                                           ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Logspace(0, 3, 4, 10) → [1 10 100 1000]                                          ║
+// ║   Logspace(0, 3, 4, 2)  → [1 2 4 8]                                                ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Logspace(start, stop float64, num int, base float64, opts ...Option) (*NDArray, error) {
+
+	exponents, err := Linspace(start, stop, num, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	data := exponents.floats()
+	for i, e := range data {
+		data[i] = math.Pow(base, e)
+	}
+	return exponents, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Geomspace – Geometric progression between two values                       ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.geomspace(start, stop, num, endpoint)`: each value is          ║
+// ║   a constant multiple of the previous one.                                         ║
+// ║                                                                                    ║
+// ║   - Both ends must be non-zero and have the same sign                              ║
+// ║   - The first value (and the last, with the endpoint) are exact                    ║
+// ║   - Honours WithEndpoint                                                           ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Geomspace(1, 1000, 4)   → [1 10 100 1000]                                        ║
+// ║   Geomspace(-1, -1000, 4) → [-1 -10 -100 -1000]                                    ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Geomspace(start, stop float64, num int, opts ...Option) (*NDArray, error) {
+
+	if start == 0 || stop == 0 {
+		return nil, fmt.Errorf("geometric sequence cannot include zero")
+	}
+	if (start < 0) != (stop < 0) {
+		return nil, fmt.Errorf("geometric sequence cannot mix positive and negative values")
+	}
+
+	sign := 1.0
+	if start < 0 {
+		sign = -1.0
+	}
+
+	out, err := Logspace(math.Log10(start*sign), math.Log10(stop*sign), num, 10, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	data := out.floats()
+	for i := range data {
+		data[i] *= sign
+	}
+
+	o := collectOptions(opts)
+	if num > 0 {
+		data[0] = start
+	}
+	if num > 1 && !o.noEndpoint {
+		data[num-1] = stop
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Eye, Identity – Ones on a diagonal, zeros elsewhere                        ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Eye(n, m, k) is `np.eye(N, M, k)`: an n×m matrix with ones on the k-th           ║
+// ║   diagonal (k > 0 above the main one, k < 0 below).                                ║
+// ║   Identity(n) is the n×n identity matrix.                                          ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Eye(2, 3, 1) → [[0 1 0]                                                          ║
+// ║                   [0 0 1]]                                                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Eye(n, m, k int) (*NDArray, error) {
+
+	out, err := New(n, m)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < n; i++ {
+		if j := i + k; j >= 0 && j < m {
+			out.floats()[i*m+j] = 1
+		}
+	}
+	return out, nil
+}
+
+func Identity(n int) (*NDArray, error) {
+	return Eye(n, n, 0)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Diag – Extract a diagonal or build a diagonal matrix                       ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.diag(v, k)`:                                                   ║
+// ║                                                                                    ║
+// ║   - 1-D input: a new square matrix with `v` on the k-th diagonal                   ║
+// ║   - 2-D input: the k-th diagonal as a read-only strided view                       ║
+// ║     (stride = row stride + column stride, no copy)                                 ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Diag([1 2], 0)          → [[1 0] [0 2]]                                          ║
+// ║   Diag([[0 1 2] [3 4 5]], 1) → [1 5]                                               ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Diag(v *NDArray, k int) (*NDArray, error) {
+
+	switch len(v.shape) {
+	case 1:
+		n := v.shape[0] + abs(k)
+		out := allocOf([]int{n, n}, v.dtype)
+		for i := 0; i < v.shape[0]; i++ {
+			row, col := i, i+k
+			if k < 0 {
+				row, col = i-k, i
+			}
+			out.data.copyFrom(row*n+col, v.data, v.offset+i*v.strides[0])
+		}
+		return out, nil
+
+	case 2:
+		rows, cols := v.shape[0], v.shape[1]
+		offset := v.offset
+		length := 0
+		if k >= 0 {
+			offset += k * v.strides[1]
+			length = min(rows, cols-k)
+		} else {
+			offset -= k * v.strides[0]
+			length = min(rows+k, cols)
+		}
+		if length < 0 {
+			length = 0
+		}
+
+		d := v.view([]int{length}, []int{v.strides[0] + v.strides[1]}, offset)
+		d.flags.Writeable = false
+		return d, nil
+
+	default:
+		return nil, fmt.Errorf("input must be 1- or 2-d, got %d dimensions", len(v.shape))
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Diagflat – Diagonal matrix from the flattened input                        ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.diagflat(v, k)`: like Diag on a 1-D array, but any             ║
+// ║   input is first flattened in row-major order.                                     ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Diagflat([[1 2] [3 4]], 0) → 4×4 matrix with 1 2 3 4 on the diagonal             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Diagflat(v *NDArray, k int) (*NDArray, error) {
+
+	flat, err := v.Reshape(-1)
+	if err != nil {
+		return nil, err
+	}
+	return Diag(flat, k)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Tri – Lower-triangular matrix of ones                                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.tri(N, M, k)`: ones at and below the k-th diagonal.            ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Tri(3, 3, 0) → [[1 0 0]                                                          ║
+// ║                   [1 1 0]                                                          ║
+// ║                   [1 1 1]]                                                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Tri(n, m, k int) (*NDArray, error) {
+
+	out, err := New(n, m)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < m && j <= i+k; j++ {
+			out.floats()[i*m+j] = 1
+		}
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Tril, Triu – Lower / upper triangle of a matrix (or stack)                 ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.tril(a, k)` / `np.triu(a, k)`.                                 ║
+// ║                                                                                    ║
+// ║   - Tril keeps elements at and below the k-th diagonal                             ║
+// ║   - Triu keeps elements at and above the k-th diagonal                             ║
+// ║   - Everything else is zeroed in a new array                                       ║
+// ║   - Inputs with more than 2 dims are treated as stacks of matrices                 ║
+// ║     (the last two axes), as in NumPy                                               ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Triu([[1 2 3] [4 5 6] [7 8 9]], 1) → [[0 2 3] [0 0 6] [0 0 0]]                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Tril(a *NDArray, k int) (*NDArray, error) {
+	return triangle(a, func(i, j int) bool { return j <= i+k })
+}
+
+func Triu(a *NDArray, k int) (*NDArray, error) {
+	return triangle(a, func(i, j int) bool { return j >= i+k })
+}
+
+// triangle copies `a`, zeroing positions (i, j) of the last two axes where keep is false.
+func triangle(a *NDArray, keep func(i, j int) bool) (*NDArray, error) {
+
+	if len(a.shape) < 2 {
+		return nil, fmt.Errorf("input must be at least 2-d, got %d dimensions", len(a.shape))
+	}
+
+	out := a.Copy()
+	rows, cols := a.shape[len(a.shape)-2], a.shape[len(a.shape)-1]
+	for start := 0; start < out.data.len(); start += rows * cols {
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				if !keep(i, j) {
+					out.data.setFloat(start+i*cols+j, 0)
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: Indexing – Output layout of Meshgrid                                       ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Mirrors the `indexing=` argument of `np.meshgrid`:                               ║
+// ║                                                                                    ║
+// ║     - IndexingXY : Cartesian, the first two output axes are swapped                ║
+// ║                    (x varies along columns) — NumPy's default                      ║
+// ║     - IndexingIJ : matrix indexing, output axis i follows input i                  ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type Indexing int
+
+const (
+	IndexingXY Indexing = iota
+	IndexingIJ
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Meshgrid – Coordinate matrices from coordinate vectors                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.meshgrid(*xi, indexing=...)`.                                  ║
+// ║                                                                                    ║
+// ║   - Inputs are flattened to 1-D                                                    ║
+// ║   - Output i repeats input i along every other axis                                ║
+// ║   - Each output is an independent copy                                             ║
+// ║                                                                                    ║
+// ║   Returns: ([]*NDArray, error)                                                     ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   x = [1 2 3], y = [10 20]                                                         ║
+// ║   Meshgrid(IndexingXY, x, y) → X = [[1 2 3] [1 2 3]]        (shape [2, 3])         ║
+// ║                                Y = [[10 10 10] [20 20 20]]                         ║
+// ║   Meshgrid(IndexingIJ, x, y) → X shape [3, 2], X[i][j] = x[i]                      ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Meshgrid(indexing Indexing, xs ...*NDArray) ([]*NDArray, error) {
+
+	if len(xs) > 32 {
+		return nil, fmt.Errorf("shape has too many dimensions (max 32)")
+	}
+
+	flats := make([]*NDArray, len(xs))
+	shape := make([]int, len(xs))
+	for i, x := range xs {
+		flat, err := x.Reshape(-1)
+		if err != nil {
+			return nil, err
+		}
+		flats[i] = flat
+		shape[i] = flat.shape[0]
+	}
+
+	// Cartesian indexing swaps the roles of the first two axes
+	axisOf := make([]int, len(xs))
+	for i := range axisOf {
+		axisOf[i] = i
+	}
+	if indexing == IndexingXY && len(xs) >= 2 {
+		shape[0], shape[1] = shape[1], shape[0]
+		axisOf[0], axisOf[1] = 1, 0
+	}
+
+	out := make([]*NDArray, len(xs))
+	for i, flat := range flats {
+		strides := make([]int, len(shape))
+		strides[axisOf[i]] = flat.strides[0]
+		grid := flat.view(append([]int(nil), shape...), strides, flat.offset)
+		out[i] = grid.Copy()
+	}
+	return out, nil
+}
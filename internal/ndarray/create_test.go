@@ -0,0 +1,237 @@
+package ndarray_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestOnesAndFull(t *testing.T) {
+	ones, err := ndarray.Ones(2, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ones.String(); got != "NDArray(shape=[2 2], data=[1 1 1 1])" {
+		t.Errorf("unexpected Ones: %s", got)
+	}
+
+	full, err := ndarray.Full(3.5, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := full.String(); got != "NDArray(shape=[3], data=[3.5 3.5 3.5])" {
+		t.Errorf("unexpected Full: %s", got)
+	}
+
+	if _, err := ndarray.Ones(0, 2); err == nil {
+		t.Error("expected error for invalid shape, got nil")
+	}
+}
+
+func TestLikeConstructors(t *testing.T) {
+	a := seq(t, 2, 3)
+	col, _ := a.Slice(ndarray.All, ndarray.At(1))
+
+	if got := ndarray.ZerosLike(a).String(); got != "NDArray(shape=[2 3], data=[0 0 0 0 0 0])" {
+		t.Errorf("unexpected ZerosLike: %s", got)
+	}
+	if got := ndarray.OnesLike(col).String(); got != "NDArray(shape=[2], data=[1 1])" {
+		t.Errorf("unexpected OnesLike: %s", got)
+	}
+	if got := ndarray.FullLike(ndarray.Scalar(1), 7).String(); got != "NDArray(shape=[], data=[7])" {
+		t.Errorf("unexpected FullLike: %s", got)
+	}
+}
+
+func TestArange(t *testing.T) {
+	tests := []struct {
+		start, stop, step float64
+		want              string
+	}{
+		{0, 5, 1, "NDArray(shape=[5], data=[0 1 2 3 4])"},
+		{1, 0, -0.25, "NDArray(shape=[4], data=[1 0.75 0.5 0.25])"},
+		{0, 1, 0.3, "NDArray(shape=[4], data=[0 0.3 0.6 0.8999999999999999])"},
+		{5, 0, 1, "NDArray(shape=[0], data=[])"},
+	}
+
+	for _, tt := range tests {
+		a, err := ndarray.Arange(tt.start, tt.stop, tt.step)
+		if err != nil {
+			t.Fatalf("Arange(%v, %v, %v): unexpected error: %v", tt.start, tt.stop, tt.step, err)
+		}
+		if got := a.String(); got != tt.want {
+			t.Errorf("Arange(%v, %v, %v) = %s, want %s", tt.start, tt.stop, tt.step, got, tt.want)
+		}
+	}
+
+	if _, err := ndarray.Arange(0, 1, 0); err == nil {
+		t.Error("expected error for zero step, got nil")
+	}
+	for _, stop := range []float64{1e18, 1e300} {
+		if _, err := ndarray.Arange(0, stop, 1); err == nil {
+			t.Errorf("expected error for %g values, got nil", stop)
+		}
+	}
+}
+
+func TestLinspace(t *testing.T) {
+	var step float64
+	a, err := ndarray.Linspace(2, 3, 5, ndarray.WithRetStep(&step))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := a.String(); got != "NDArray(shape=[5], data=[2 2.25 2.5 2.75 3])" {
+		t.Errorf("unexpected Linspace: %s", got)
+	}
+	if step != 0.25 {
+		t.Errorf("expected step 0.25, got %v", step)
+	}
+
+	b, _ := ndarray.Linspace(2, 3, 5, ndarray.WithEndpoint(false), ndarray.WithRetStep(&step))
+	if got := b.String(); got != "NDArray(shape=[5], data=[2 2.2 2.4 2.6 2.8])" {
+		t.Errorf("unexpected Linspace without endpoint: %s", got)
+	}
+	if step != 0.2 {
+		t.Errorf("expected step 0.2, got %v", step)
+	}
+
+	// The endpoint is exact even when i*step would round past it
+	c, _ := ndarray.Linspace(0, 0.3, 4)
+	if last, _ := c.Get(3); last != 0.3 {
+		t.Errorf("expected exact endpoint 0.3, got %v", last)
+	}
+
+	ndarray.Linspace(1, 2, 1, ndarray.WithRetStep(&step))
+	if !math.IsNaN(step) {
+		t.Errorf("expected NaN step for a single sample, got %v", step)
+	}
+
+	if _, err := ndarray.Linspace(0, 1, -1); err == nil {
+		t.Error("expected error for negative num, got nil")
+	}
+}
+
+func TestLogspaceAndGeomspace(t *testing.T) {
+	a, _ := ndarray.Logspace(0, 3, 4, 2)
+	if got := a.String(); got != "NDArray(shape=[4], data=[1 2 4 8])" {
+		t.Errorf("unexpected Logspace: %s", got)
+	}
+
+	g, err := ndarray.Geomspace(-1, -1000, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []float64{-1, -10, -100, -1000}
+	for i, w := range want {
+		v, _ := g.Get(i)
+		if math.Abs(v-w) > 1e-12*math.Abs(w) {
+			t.Errorf("Geomspace[%d] = %v, want %v", i, v, w)
+		}
+	}
+
+	if _, err := ndarray.Geomspace(0, 10, 3); err == nil {
+		t.Error("expected error for zero start, got nil")
+	}
+	if _, err := ndarray.Geomspace(-1, 10, 3); err == nil {
+		t.Error("expected error for mixed signs, got nil")
+	}
+}
+
+func TestEyeAndTri(t *testing.T) {
+	e, err := ndarray.Eye(2, 3, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := e.String(); got != "NDArray(shape=[2 3], data=[0 1 0 0 0 1])" {
+		t.Errorf("unexpected Eye: %s", got)
+	}
+
+	id, _ := ndarray.Identity(2)
+	if got := id.String(); got != "NDArray(shape=[2 2], data=[1 0 0 1])" {
+		t.Errorf("unexpected Identity: %s", got)
+	}
+
+	tri, _ := ndarray.Tri(3, 3, -1)
+	if got := tri.String(); got != "NDArray(shape=[3 3], data=[0 0 0 1 0 0 1 1 0])" {
+		t.Errorf("unexpected Tri: %s", got)
+	}
+}
+
+func TestDiag(t *testing.T) {
+	a := seq(t, 2, 3)
+
+	d, err := ndarray.Diag(a, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := d.String(); got != "NDArray(shape=[2], data=[1 5])" {
+		t.Errorf("unexpected diagonal: %s", got)
+	}
+	if d.Base() != a.Base() || d.Flags().Writeable {
+		t.Error("expected a read-only view of the input")
+	}
+
+	below, _ := ndarray.Diag(a, -1)
+	if got := below.String(); got != "NDArray(shape=[1], data=[3])" {
+		t.Errorf("unexpected diagonal below: %s", got)
+	}
+
+	m, _ := ndarray.Diag(seq(t, 2), -1)
+	if got := m.String(); got != "NDArray(shape=[3 3], data=[0 0 0 0 0 0 0 1 0])" {
+		t.Errorf("unexpected diagonal matrix: %s", got)
+	}
+
+	f, _ := ndarray.Diagflat(seq(t, 2, 1), 0)
+	if got := f.String(); got != "NDArray(shape=[2 2], data=[0 0 0 1])" {
+		t.Errorf("unexpected Diagflat: %s", got)
+	}
+}
+
+func TestTrilTriu(t *testing.T) {
+	a := seq(t, 3, 3)
+
+	lower, _ := ndarray.Tril(a, 0)
+	if got := lower.String(); got != "NDArray(shape=[3 3], data=[0 0 0 3 4 0 6 7 8])" {
+		t.Errorf("unexpected Tril: %s", got)
+	}
+
+	upper, _ := ndarray.Triu(a, 1)
+	if got := upper.String(); got != "NDArray(shape=[3 3], data=[0 1 2 0 0 5 0 0 0])" {
+		t.Errorf("unexpected Triu: %s", got)
+	}
+
+	// Stacks of matrices use the last two axes
+	stack, _ := ndarray.Triu(seq(t, 2, 2, 2), 0)
+	if got := stack.String(); got != "NDArray(shape=[2 2 2], data=[0 1 0 3 4 5 0 7])" {
+		t.Errorf("unexpected stacked Triu: %s", got)
+	}
+
+	if _, err := ndarray.Tril(seq(t, 3), 0); err == nil {
+		t.Error("expected error for 1-d input, got nil")
+	}
+}
+
+func TestMeshgrid(t *testing.T) {
+	x := seq(t, 3)
+	y, _ := ndarray.Arange(10, 30, 10)
+
+	xy, err := ndarray.Meshgrid(ndarray.IndexingXY, x, y)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := xy[0].String(); got != "NDArray(shape=[2 3], data=[0 1 2 0 1 2])" {
+		t.Errorf("unexpected X (xy): %s", got)
+	}
+	if got := xy[1].String(); got != "NDArray(shape=[2 3], data=[10 10 10 20 20 20])" {
+		t.Errorf("unexpected Y (xy): %s", got)
+	}
+
+	ij, _ := ndarray.Meshgrid(ndarray.IndexingIJ, x, y)
+	if got := ij[0].String(); got != "NDArray(shape=[3 2], data=[0 0 1 1 2 2])" {
+		t.Errorf("unexpected X (ij): %s", got)
+	}
+	if got := ij[1].String(); got != "NDArray(shape=[3 2], data=[10 20 10 20 10 20])" {
+		t.Errorf("unexpected Y (ij): %s", got)
+	}
+}
@@ -0,0 +1,931 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║     ██████╗  █████╗ ████████╗███████╗████████╗██╗███╗   ███╗███████╗               ║
+// ║     ██╔══██╗██╔══██╗╚══██╔══╝██╔════╝╚══██╔══╝██║████╗ ████║██╔════╝               ║
+// ║     ██║  ██║███████║   ██║   █████╗     ██║   ██║██╔████╔██║█████╗                 ║
+// ║     ██║  ██║██╔══██║   ██║   ██╔══╝     ██║   ██║██║╚██╔╝██║██╔══╝                 ║
+// ║     ██████╔╝██║  ██║   ██║   ███████╗   ██║   ██║██║ ╚═╝ ██║███████╗               ║
+// ║     ╚═════╝ ╚═╝  ╚═╝   ╚═╝   ╚══════╝   ╚═╝   ╚═╝╚═╝     ╚═╝╚══════╝               ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Dates and durations: datetime64 / timedelta64 dtypes in seven                     ║
+// ║  units, NaT, Go time conversions and their ufunc lane.                             ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/datetime.go              ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: TimeUnit – Resolution of datetime64 and timedelta64 elements               ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Mirrors the `[unit]` of NumPy's datetime dtypes. Elements are int64              ║
+// ║   counts of the unit (ticks) since 1970-01-01T00:00 UTC for datetimes:             ║
+// ║                                                                                    ║
+// ║     - Years, Months     : calendar units ("Y", "M"), of varying length             ║
+// ║     - Days              : "D", 86400 seconds (no leap seconds, as NumPy)           ║
+// ║     - Seconds … Nanoseconds : "s", "ms", "us", "ns"                                ║
+// ║                                                                                    ║
+// ║   Units are ordered from the coarsest to the finest; operands of                   ║
+// ║   different units meet in the finer one.                                           ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Datetime64(Days)           → datetime64[D]                                       ║
+// ║   Timedelta64(Milliseconds)  → timedelta64[ms]                                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type TimeUnit int
+
+const (
+	Years TimeUnit = iota
+	Months
+	Days
+	Seconds
+	Milliseconds
+	Microseconds
+	Nanoseconds
+)
+
+// unitNames holds NumPy's code of each unit.
+var unitNames = [...]string{"Y", "M", "D", "s", "ms", "us", "ns"}
+
+// String returns NumPy's code for the unit.
+func (u TimeUnit) String() string {
+	if u >= Years && u <= Nanoseconds {
+		return unitNames[u]
+	}
+	return fmt.Sprintf("TimeUnit(%d)", int(u))
+}
+
+// linear reports whether the unit has a fixed length, i.e. days or finer.
+func (u TimeUnit) linear() bool { return u >= Days }
+
+// unitNanos is the length of each linear unit in nanoseconds.
+var unitNanos = [...]int64{Days: 86400e9, Seconds: 1e9, Milliseconds: 1e6, Microseconds: 1e3, Nanoseconds: 1}
+
+// averageSeconds is the length NumPy gives the calendar units when timedeltas are
+// converted to linear ones: the Gregorian year of 365.2425 days, and its twelfth.
+var averageSeconds = [...]int64{Years: 31556952, Months: 2629746}
+
+// NaT ("not a time") is the tick value of missing datetimes and timedeltas. It
+// propagates through arithmetic like NaN, and compares unequal to everything.
+const NaT int64 = math.MinInt64
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Datetime64, Timedelta64 – The time dtypes of a unit                        ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.dtype('datetime64[unit]')` and                                 ║
+// ║   `np.dtype('timedelta64[unit]')`. Both store 8-byte ticks, kinds 'M'              ║
+// ║   and 'm'. Casting between them follows NumPy:                                     ║
+// ║                                                                                    ║
+// ║   - Safe    : to a finer unit (datetime64[D] → datetime64[s]), and                 ║
+// ║               bool / integers (but uint64) → timedelta64                           ║
+// ║   - Same kind : any unit change, except timedeltas between the                     ║
+// ║               calendar units (Y, M) and the linear ones                            ║
+// ║   - Unsafe  : also from and to integers, floats and bool, and                      ║
+// ║               timedeltas in Y / M to linear units with average lengths             ║
+// ║   - Never   : datetime64 ↔ timedelta64, nor from or to complex types               ║
+// ║                                                                                    ║
+// ║   Returns: DType                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   d := Datetime64(Days)                                                            ║
+// ║   d.String(), d.ItemSize()                   → "datetime64[D]", 8                  ║
+// ║   CanCast(d, Datetime64(Seconds), CastingSafe) → true                              ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Datetime64(unit TimeUnit) DType  { return datetimeBase + DType(unit) }
+func Timedelta64(unit TimeUnit) DType { return timedeltaBase + DType(unit) }
+
+// The time dtypes are numbered from these, one per unit, after the plain dtypes.
+const (
+	datetimeBase  DType = 32
+	timedeltaBase DType = 48
+)
+
+func init() {
+	for u := Years; u <= Nanoseconds; u++ {
+		dtypeInfo[Datetime64(u)] = typeInfo{"datetime64[" + u.String() + "]", 'M', 8}
+		dtypeInfo[Timedelta64(u)] = typeInfo{"timedelta64[" + u.String() + "]", 'm', 8}
+	}
+}
+
+// isTime reports whether d is a datetime64 or timedelta64 type.
+func (d DType) isTime() bool {
+	k := d.kind()
+	return k == 'M' || k == 'm'
+}
+
+// unit returns the unit of a datetime64 or timedelta64 type.
+func (d DType) unit() TimeUnit {
+	if d.kind() == 'M' {
+		return TimeUnit(d - datetimeBase)
+	}
+	return TimeUnit(d - timedeltaBase)
+}
+
+// validUnit rejects units outside the seven supported ones.
+func validUnit(unit TimeUnit) error {
+	if unit < Years || unit > Nanoseconds {
+		return fmt.Errorf("invalid datetime unit %s", unit)
+	}
+	return nil
+}
+
+// timeCast is CanCast when either side is a datetime64 or timedelta64 type.
+func timeCast(from, to DType, casting Casting) bool {
+
+	fk, tk := from.kind(), to.kind()
+	switch {
+	case from == to:
+		return true
+	case fk == 'c' || tk == 'c' || (from.isTime() && to.isTime() && fk != tk):
+		return false
+	case !from.isTime():
+		if casting == CastingUnsafe {
+			return true
+		}
+		return tk == 'm' && (fk == 'b' || fk == 'i' || (fk == 'u' && from != Uint64))
+	case !to.isTime():
+		return casting == CastingUnsafe
+	}
+
+	// Same kind, different units
+	fu, tu := from.unit(), to.unit()
+	comparable := fk == 'M' || fu.linear() == tu.linear()
+	switch casting {
+	case CastingUnsafe:
+		return true
+	case CastingSameKind:
+		return comparable
+	default:
+		return comparable && tu >= fu
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: timeBuf – Storage of datetime64 and timedelta64 elements                   ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   int64 ticks of the dtype's unit, NaT included. Unlike the other                  ║
+// ║   buffers it carries its dtype, since the unit is not part of the Go               ║
+// ║   type.                                                                            ║
+// ║                                                                                    ║
+// ║   - As integers (see storage.go) the ticks move exactly, so AsType                 ║
+// ║     to Int64 and back is lossless                                                  ║
+// ║   - As floats NaT reads as NaN, and NaN is stored as NaT                           ║
+// ║   - Between time buffers of one kind, copyFrom converts the unit                   ║
+// ║   - value gives a time.Time (UTC) or a time.Duration                               ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type timeBuf struct {
+	ticks []int64
+	d     DType
+}
+
+func (b timeBuf) len() int                       { return len(b.ticks) }
+func (b timeBuf) dtype() DType                   { return b.d }
+func (b timeBuf) complex(i int) complex128       { return complex(b.float(i), 0) }
+func (b timeBuf) setComplex(i int, v complex128) { b.setFloat(i, real(v)) }
+func (b timeBuf) int(i int) int64                { return b.ticks[i] }
+func (b timeBuf) setInt(i int, v int64)          { b.ticks[i] = v }
+func (b timeBuf) fresh(n int) storage            { return timeBuf{make([]int64, n), b.d} }
+func (b timeBuf) slice() any                     { return b.ticks }
+
+func (b timeBuf) float(i int) float64 {
+	if b.ticks[i] == NaT {
+		return math.NaN()
+	}
+	return float64(b.ticks[i])
+}
+
+func (b timeBuf) setFloat(i int, v float64) {
+	if math.IsNaN(v) {
+		b.ticks[i] = NaT
+		return
+	}
+	b.ticks[i] = truncate(v)
+}
+
+func (b timeBuf) value(i int) any {
+	if b.d.kind() == 'M' {
+		return ticksToTime(b.ticks[i], b.d.unit())
+	}
+	ns, _ := convertTicks(b.ticks[i], b.d.unit(), Nanoseconds, false)
+	return time.Duration(ns)
+}
+
+func (b timeBuf) copyFrom(i int, src storage, j int) {
+	if s, ok := src.(timeBuf); ok && s.d.kind() == b.d.kind() {
+		// Ticks that overflow the unit become NaT; AsType and ufuncs report them
+		b.ticks[i], _ = convertTicks(s.ticks[j], s.d.unit(), b.d.unit(), b.d.kind() == 'M')
+		return
+	}
+	convertElem(b, i, src, j)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: convertTicks – Express ticks of one unit in another                        ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   - Towards a finer unit the ticks are multiplied, towards a coarser               ║
+// ║     one they are floored (datetimes before 1970 stay in their day)                 ║
+// ║   - Datetimes (calendar = true) cross between months / years and                   ║
+// ║     days through the Gregorian calendar                                            ║
+// ║   - Timedeltas cross with the average year and month (unsafe casts)                ║
+// ║   - NaT stays NaT; ticks too many for the new unit are an error                    ║
+// ║     where NumPy would wrap them                                                    ║
+// ║                                                                                    ║
+// ║   Returns: (int64, error) – NaT and an error on overflow                           ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   convertTicks(1, Days, Seconds, true)       → 86400                               ║
+// ║   convertTicks(-1, Seconds, Days, true)      → -1     (1969-12-31)                 ║
+// ║   convertTicks(1, Months, Days, true)        → 31     (1970-02-01)                 ║
+// ║   convertTicks(1, Months, Seconds, false)    → 2629746                             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func convertTicks(v int64, from, to TimeUnit, calendar bool) (int64, error) {
+
+	if v == NaT || from == to {
+		return v, nil
+	}
+
+	var r int64
+	ok := true
+	switch {
+	case from.linear() && to.linear():
+		r, ok = rescale(v, unitNanos[from], unitNanos[to])
+	case !from.linear() && !to.linear():
+		r, ok = rescale(v, averageSeconds[from], averageSeconds[to])
+	case calendar && !from.linear():
+		r, ok = rescale(monthsToDays(v, from), unitNanos[Days], unitNanos[to])
+	case calendar:
+		r, _ = rescale(v, unitNanos[from], unitNanos[Days])
+		r = daysToMonths(r, to)
+	case !from.linear():
+		if r, ok = rescale(v, averageSeconds[from], 1); ok {
+			r, ok = rescale(r, unitNanos[Seconds], unitNanos[to])
+		}
+	default:
+		r, _ = rescale(v, unitNanos[from], unitNanos[Seconds])
+		r = floorTicks(r, averageSeconds[to])
+	}
+	if !ok {
+		return NaT, fmt.Errorf("overflow converting %d ticks of unit %s to unit %s", v, from, to)
+	}
+	return r, nil
+}
+
+// rescale converts a count of units `from` long into units `to` long (in any
+// common measure), flooring when the target is coarser. Counts too large for the
+// finer unit give false.
+func rescale(v, from, to int64) (int64, bool) {
+	if from < to {
+		return floorTicks(v, to/from), true
+	}
+
+	f := from / to
+	if v > math.MaxInt64/f || v <= math.MinInt64/f {
+		return NaT, false
+	}
+	return v * f, true
+}
+
+// convertTimes is AsType between time dtypes of one kind, refusing ticks that
+// overflow the new unit.
+func convertTimes(a *NDArray, d DType) (*NDArray, error) {
+
+	src := gather(a).(timeBuf)
+	ticks := make([]int64, len(src.ticks))
+	for i, v := range src.ticks {
+		t, err := convertTicks(v, a.dtype.unit(), d.unit(), d.kind() == 'M')
+		if err != nil {
+			return nil, err
+		}
+		ticks[i] = t
+	}
+	shape := append([]int(nil), a.shape...)
+	return newStorage(timeBuf{ticks, d}, shape, cStrides(shape)), nil
+}
+
+// floorTicks is v / n rounded toward negative infinity, for n > 0.
+func floorTicks(v, n int64) int64 {
+	q := v / n
+	if v%n < 0 {
+		q--
+	}
+	return q
+}
+
+// monthsToDays returns the day on which month (or year) v since 1970 starts.
+func monthsToDays(v int64, unit TimeUnit) int64 {
+	if unit == Years {
+		v *= 12
+	}
+	year := floorTicks(v, 12)
+	start := time.Date(1970+int(year), time.Month(v-12*year+1), 1, 0, 0, 0, 0, time.UTC)
+	return start.Unix() / 86400
+}
+
+// daysToMonths returns the month (or year) since 1970 that day v falls in.
+func daysToMonths(v int64, unit TimeUnit) int64 {
+	t := time.Unix(v*86400, 0).UTC()
+	months := int64(t.Year()-1970)*12 + int64(t.Month()) - 1
+	if unit == Years {
+		return floorTicks(months, 12)
+	}
+	return months
+}
+
+// ticksToTime returns the UTC instant a datetime tick stands for; NaT gives the
+// zero time.Time.
+func ticksToTime(v int64, unit TimeUnit) time.Time {
+
+	switch {
+	case v == NaT:
+		return time.Time{}
+	case !unit.linear():
+		return time.Unix(monthsToDays(v, unit)*86400, 0).UTC()
+	case unit == Days:
+		return time.Unix(v*86400, 0).UTC()
+	}
+
+	perSecond := int64(1e9) / unitNanos[unit]
+	sec := floorTicks(v, perSecond)
+	return time.Unix(sec, (v-sec*perSecond)*unitNanos[unit]).UTC()
+}
+
+// timeToTicks is the inverse of ticksToTime, flooring t to the unit; the zero
+// time.Time gives NaT. Instants too far from 1970 to count in the unit, such as
+// dates outside about 1678–2262 in nanoseconds, are an error.
+func timeToTicks(t time.Time, unit TimeUnit) (int64, error) {
+
+	if t.IsZero() {
+		return NaT, nil
+	}
+
+	sec := t.Unix()
+	switch {
+	case !unit.linear():
+		return daysToMonths(floorTicks(sec, 86400), unit), nil
+	case unit == Days:
+		return floorTicks(sec, 86400), nil
+	}
+
+	scale := int64(1e9) / unitNanos[unit]
+	if sec > math.MaxInt64/scale-1 || sec <= math.MinInt64/scale {
+		return NaT, fmt.Errorf("%s is out of bounds for datetime64[%s]", t.UTC().Format(time.RFC3339), unit)
+	}
+	return sec*scale + int64(t.Nanosecond())/unitNanos[unit], nil
+}
+
+// timeScalar holds t in a one-element datetime64 buffer of the finest unit that can
+// count it: nanoseconds for dates within about 1678–2262, coarser ones beyond.
+func timeScalar(t time.Time) timeBuf {
+	unit := Nanoseconds
+	v, err := timeToTicks(t, unit)
+	for err != nil {
+		unit--
+		v, err = timeToTicks(t, unit)
+	}
+	return timeBuf{[]int64{v}, Datetime64(unit)}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: FromTimes, FromDurations – Build time arrays from Go values                ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.array(values, dtype='datetime64[unit]')` and                   ║
+// ║   `np.array(values, dtype='timedelta64[unit]')`.                                   ║
+// ║                                                                                    ║
+// ║   - Instants are floored to the unit, in UTC whatever their location               ║
+// ║   - The zero time.Time and time.Duration(NaT) become NaT                           ║
+// ║   - Shape rules are those of FromSlice; the data is copied                         ║
+// ║   - Single values can also be made with ScalarOf, which stores a                   ║
+// ║     time.Time as datetime64 in the finest unit that can count it                   ║
+// ║     (ns within about 1678–2262) and a time.Duration as timedelta64[ns]             ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – error on an unknown unit, a bad shape,              ║
+// ║            or an instant too far from 1970 to count in the unit                    ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   t0 := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)                              ║
+// ║   FromTimes([]time.Time{t0, {}}, Days)     → [2024-03-01 NaT]                      ║
+// ║   FromDurations([]time.Duration{1500 * time.Millisecond}, Seconds) → [1]           ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func FromTimes(times []time.Time, unit TimeUnit, shape ...int) (*NDArray, error) {
+
+	if err := validUnit(unit); err != nil {
+		return nil, err
+	}
+
+	ticks := make([]int64, len(times))
+	for i, t := range times {
+		v, err := timeToTicks(t, unit)
+		if err != nil {
+			return nil, err
+		}
+		ticks[i] = v
+	}
+	return fromTicks(ticks, Datetime64(unit), shape)
+}
+
+func FromDurations(durations []time.Duration, unit TimeUnit, shape ...int) (*NDArray, error) {
+
+	if err := validUnit(unit); err != nil {
+		return nil, err
+	}
+
+	ticks := make([]int64, len(durations))
+	for i, d := range durations {
+		// Nanoseconds are the finest unit, so this cannot overflow
+		ticks[i], _ = convertTicks(int64(d), Nanoseconds, unit, false)
+	}
+	return fromTicks(ticks, Timedelta64(unit), shape)
+}
+
+// fromTicks wraps ticks of dtype d in an array of the given shape.
+func fromTicks(ticks []int64, d DType, shape []int) (*NDArray, error) {
+
+	if len(shape) == 0 {
+		shape = []int{len(ticks)}
+	}
+
+	resolved, err := resolveShape(len(ticks), shape)
+	if err != nil {
+		return nil, err
+	}
+	return newStorage(timeBuf{ticks, d}, resolved, cStrides(resolved)), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Times, Durations – Copy time elements out as Go values                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Flat slices in row-major (C) order, like ToSliceOf.                              ║
+// ║                                                                                    ║
+// ║   - Times needs a datetime64 array; instants are in UTC and NaT                    ║
+// ║     gives the zero time.Time                                                       ║
+// ║   - Durations needs a timedelta64 array; NaT gives                                 ║
+// ║     time.Duration(NaT), and Y / M ticks use the average lengths                    ║
+// ║   - Values beyond time.Duration's ±292 years are an error                          ║
+// ║                                                                                    ║
+// ║   Returns: ([]time.Time, error) / ([]time.Duration, error)                         ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   d, _ := Sub(stops, starts)   → timedelta64[s]                                    ║
+// ║   ds, _ := d.Durations()      → [1h30m0s 45s]                                      ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Times() ([]time.Time, error) {
+
+	if a.dtype.kind() != 'M' {
+		return nil, fmt.Errorf("cannot read a %s array as []time.Time", a.dtype)
+	}
+
+	buf := gather(a).(timeBuf)
+	out := make([]time.Time, len(buf.ticks))
+	for i, v := range buf.ticks {
+		out[i] = ticksToTime(v, a.dtype.unit())
+	}
+	return out, nil
+}
+
+func (a *NDArray) Durations() ([]time.Duration, error) {
+
+	if a.dtype.kind() != 'm' {
+		return nil, fmt.Errorf("cannot read a %s array as []time.Duration", a.dtype)
+	}
+
+	buf := gather(a).(timeBuf)
+	out := make([]time.Duration, len(buf.ticks))
+	for i, v := range buf.ticks {
+		d, err := tickDuration(v, a.dtype.unit())
+		if err != nil {
+			return nil, err
+		}
+		out[i] = d
+	}
+	return out, nil
+}
+
+// tickDuration is v ticks of unit as a time.Duration, failing beyond its ±292 years.
+func tickDuration(v int64, unit TimeUnit) (time.Duration, error) {
+	ns, err := convertTicks(v, unit, Nanoseconds, false)
+	if err != nil {
+		return 0, fmt.Errorf("timedelta of %d %s is out of range for time.Duration", v, unit)
+	}
+	return time.Duration(ns), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: ArangeDatetime – Evenly spaced dates within a half-open interval           ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.arange(start, stop, step, dtype='datetime64[unit]')`.          ║
+// ║                                                                                    ║
+// ║   - start and stop are floored to the unit, step counts units, so                  ║
+// ║     monthly and yearly ranges follow the calendar                                  ║
+// ║   - Length is ceil((stop - start) / step), or 0 if that is negative                ║
+// ║   - step may be negative but not zero; NaT bounds are rejected                     ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – 1-D datetime64[unit] array                          ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)                               ║
+// ║   ArangeDatetime(jan, jan.AddDate(0, 4, 0), 1, Months)                             ║
+// ║   → [2024-01 2024-02 2024-03 2024-04]                                              ║
+// ║   ArangeDatetime(jan, jan.AddDate(0, 0, 7), 3, Days)                               ║
+// ║   → [2024-01-01 2024-01-04]   (the 7th itself is excluded)                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func ArangeDatetime(start, stop time.Time, step int64, unit TimeUnit) (*NDArray, error) {
+
+	if err := validUnit(unit); err != nil {
+		return nil, err
+	}
+	if step == 0 {
+		return nil, fmt.Errorf("arange: step must not be zero")
+	}
+	if start.IsZero() || stop.IsZero() {
+		return nil, fmt.Errorf("arange: cannot use NaT as an interval bound")
+	}
+
+	first, err := timeToTicks(start, unit)
+	if err != nil {
+		return nil, err
+	}
+	last, err := timeToTicks(stop, unit)
+	if err != nil {
+		return nil, err
+	}
+	span, n := last-first, int64(0)
+	switch {
+	case step > 0 && span > 0:
+		n = (span + step - 1) / step
+	case step < 0 && span < 0:
+		n = (span + step + 1) / step
+	}
+
+	ticks := make([]int64, n)
+	for i := range ticks {
+		ticks[i] = first + int64(i)*step
+	}
+	return newStorage(timeBuf{ticks, Datetime64(unit)}, []int{len(ticks)}, []int{1}), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: IsNaT – Which elements are NaT                                             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.isnat(a)`.                                                     ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – Bool array of a's shape; error unless a             ║
+// ║            is datetime64 or timedelta64                                            ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = [2024-01-01 NaT]                                                             ║
+// ║   IsNaT(a) → [false true]                                                          ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func IsNaT(a *NDArray) (*NDArray, error) {
+
+	if !a.dtype.isTime() {
+		return nil, fmt.Errorf("ufunc 'isnat' is only defined for datetime64 and timedelta64, got %s", a.dtype)
+	}
+
+	out := allocOf(append([]int(nil), a.shape...), Bool)
+	for i, v := range gather(a).(timeBuf).ticks {
+		if v == NaT {
+			out.data.setFloat(i, 1)
+		}
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: timeSignatures – How a ufunc treats time operands                          ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Maps the kinds of a call's operands to the kind of its result, as                ║
+// ║   NumPy's datetime type resolvers do. Operand codes are 'M'                        ║
+// ║   (datetime64), 'm' (timedelta64), 'n' (bool or integer, counted in                ║
+// ║   the call's unit) and 'f' (float); results are 'M', 'm', 'f'                      ║
+// ║   (Float64) or 'b' (Bool). Calls not listed are errors.                            ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   timeSub["MM"] → 'm'   (datetime - datetime = timedelta)                          ║
+// ║   timeDiv["mm"] → 'f'   (timedelta / timedelta = float64)                          ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type timeSignatures map[string]byte
+
+var (
+	timeAdd     = timeSignatures{"Mm": 'M', "mM": 'M', "Mn": 'M', "nM": 'M', "mm": 'm', "mn": 'm', "nm": 'm'}
+	timeSub     = timeSignatures{"MM": 'm', "Mm": 'M', "Mn": 'M', "mm": 'm', "mn": 'm', "nm": 'm'}
+	timeMul     = timeSignatures{"mn": 'm', "nm": 'm', "mf": 'm', "fm": 'm'}
+	timeDiv     = timeSignatures{"mm": 'f', "mn": 'm', "mf": 'm'}
+	timeSigned  = timeSignatures{"m": 'm'}
+	timeExtreme = timeSignatures{"MM": 'M', "mm": 'm'}
+	timeCompare = timeSignatures{"MM": 'b', "mm": 'b', "mn": 'b', "nm": 'b'}
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: resolveTime – resolve for calls with time operands                         ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Looks the operand kinds up in u.time. The call runs in the finest                ║
+// ║   unit among the time operands; a timedelta in years or months cannot              ║
+// ║   meet a linear unit (NumPy has no common divisor for them).                       ║
+// ║                                                                                    ║
+// ║   Returns: (compute, result DType, error) – compute is the timedelta64             ║
+// ║            of the call's unit, which routes Call to timeLoop                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   SubUfunc.resolveTime(Datetime64(Days), Datetime64(Seconds))                      ║
+// ║   → timedelta64[s], timedelta64[s]                                                 ║
+// ║   AddUfunc.resolveTime(Datetime64(Days), Datetime64(Days)) → error                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (u *Ufunc) resolveTime(types ...DType) (DType, DType, error) {
+
+	sig := make([]byte, len(types))
+	names := make([]string, len(types))
+	unit := Years
+	for k, d := range types {
+		names[k] = d.String()
+		switch kind := d.kind(); kind {
+		case 'M', 'm':
+			sig[k] = kind
+			unit = max(unit, d.unit())
+		case 'f', 'c':
+			sig[k] = kind
+		default:
+			sig[k] = 'n'
+		}
+	}
+
+	res, ok := u.time[string(sig)]
+	if !ok {
+		return 0, 0, fmt.Errorf("ufunc '%s' cannot use operands with types %s", u.name, strings.Join(names, " and "))
+	}
+	for _, d := range types {
+		if d.kind() == 'm' && d.unit().linear() != unit.linear() {
+			return 0, 0, fmt.Errorf("cannot get a common metadata divisor for datetime units %s and %s", d.unit(), unit)
+		}
+	}
+
+	switch res {
+	case 'M':
+		return Timedelta64(unit), Datetime64(unit), nil
+	case 'm':
+		return Timedelta64(unit), Timedelta64(unit), nil
+	case 'f':
+		return Timedelta64(unit), Float64, nil
+	default:
+		return Timedelta64(unit), Bool, nil
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: timeLoop – The time counterpart of Ufunc.loop                              ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Converts every time operand to `unit` and runs the integer kernel                ║
+// ║   on the ticks, exactly and wrapping on overflow like NumPy. With a                ║
+// ║   float operand (or no integer kernel, as for Div) the float kernel                ║
+// ║   runs instead and time results are truncated to whole ticks.                      ║
+// ║                                                                                    ║
+// ║   NaT operands go through the float kernel as NaN, so NaT results                  ║
+// ║   come out as NaT (or NaN), comparisons as false and NotEqual as true.             ║
+// ║                                                                                    ║
+// ║   Returns: error – a kernel error, or a time that overflows `unit`                 ║
+// ║            or the output's unit                                                    ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (u *Ufunc) timeLoop(out *NDArray, inputs []*NDArray, where *NDArray, unit TimeUnit) error {
+
+	operands, strides, offsets, err := broadcastOperands(out, inputs, where)
+	if err != nil {
+		return err
+	}
+
+	args := operands[1 : 1+u.nin]
+	var mask *NDArray
+	if where != nil {
+		mask = operands[len(operands)-1]
+	}
+
+	exact := u.integer != nil
+	for _, in := range args {
+		if in.dtype.kind() == 'f' {
+			exact = false
+		}
+	}
+	float := func(x, y float64) float64 {
+		if u.nin == 1 {
+			return u.unary(x)
+		}
+		return u.binary(x, y)
+	}
+
+	var failure error
+	walk(out.shape, strides, offsets, func(offs, inner []int, n int) {
+		pos := append([]int(nil), offs...)
+		for i := 0; i < n && failure == nil; i++ {
+			if mask == nil || mask.data.float(pos[len(pos)-1]) != 0 {
+				var ticks [2]int64
+				var values [2]float64
+				nat := false
+				for k, in := range args {
+					switch kind := in.dtype.kind(); {
+					case kind == 'M' || kind == 'm':
+						t, err := convertTicks(intAt(in.data, pos[1+k]), in.dtype.unit(), unit, kind == 'M')
+						if err != nil {
+							failure = err
+							return
+						}
+						nat = nat || t == NaT
+						ticks[k] = t
+						values[k] = float64(t)
+					case kind == 'f':
+						values[k] = in.data.float(pos[1+k])
+					default:
+						ticks[k] = intAt(in.data, pos[1+k])
+						values[k] = float64(ticks[k])
+					}
+				}
+
+				var err error
+				switch {
+				case nat:
+					err = putTimeResult(out.data, pos[0], 0, float(math.NaN(), math.NaN()), false, unit)
+				case exact:
+					var r int64
+					if r, _, err = u.integer(ticks[0], ticks[1], false); err == nil {
+						err = putTimeResult(out.data, pos[0], r, 0, true, unit)
+					}
+				default:
+					err = putTimeResult(out.data, pos[0], 0, float(values[0], values[1]), false, unit)
+				}
+				if err != nil {
+					failure = err
+					return
+				}
+			}
+			for k := range pos {
+				pos[k] += inner[k]
+			}
+		}
+	})
+	return failure
+}
+
+// putTimeResult stores one result of timeLoop, the integer r when exact and the
+// float f otherwise, counted in unit when it is a time. Time outputs receive it
+// in their own unit, failing if it overflows there; non-finite floats become NaT.
+func putTimeResult(buf storage, i int, r int64, f float64, exact bool, unit TimeUnit) error {
+
+	d := buf.dtype()
+	switch {
+	case !d.isTime() && exact:
+		putInt(buf, i, r, false)
+	case !d.isTime():
+		buf.setFloat(i, f)
+	default:
+		if !exact {
+			r = NaT
+			if !math.IsNaN(f) && !math.IsInf(f, 0) {
+				r = truncate(f)
+			}
+		}
+		t, err := convertTicks(r, unit, d.unit(), d.kind() == 'M')
+		if err != nil {
+			return err
+		}
+		buf.(integers).setInt(i, t)
+	}
+	return nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: foldTimes – Time counterpart of foldAxes                                   ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Folds the ticks of `a` exactly with the integer kernel (Sum of                   ║
+// ║   timedeltas, Min / Max of either kind); any NaT makes the fold NaT.               ║
+// ║   Folds whose result would not be a time of a's dtype are refused.                 ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – shape of `a` with reduced axes set to 1             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func foldTimes(a *NDArray, reduced []bool, where *NDArray, u *Ufunc, initial float64, hasInitial bool, result DType) (*NDArray, error) {
+
+	if result != a.dtype || u.integer == nil {
+		return nil, fmt.Errorf("reduction operation '%s' is not supported for dtype %s", u.name, a.dtype)
+	}
+
+	a = direct(a)
+	kept := keptShape(a.shape, reduced)
+	res := allocOf(kept, result)
+	acc := res.data.(timeBuf).ticks
+	seen := make([]bool, len(acc))
+	if hasInitial {
+		for i := range acc {
+			acc[i] = truncate(initial)
+			seen[i] = true
+		}
+	}
+
+	shape, operands, offsets := foldPlan(a, kept, reduced, where)
+	ticks := a.data.(timeBuf).ticks
+
+	var failure error
+	walk(shape, operands, offsets, func(offs, inner []int, n int) {
+		r, x := offs[0], offs[1]
+		m := 0
+		if where != nil {
+			m = offs[2]
+		}
+		for i := 0; i < n && failure == nil; i++ {
+			if where == nil || where.data.float(m) != 0 {
+				v := ticks[x]
+				switch {
+				case !seen[r]:
+					acc[r], seen[r] = v, true
+				case acc[r] == NaT || v == NaT:
+					acc[r] = NaT
+				default:
+					acc[r], _, failure = u.integer(acc[r], v, false)
+				}
+			}
+			r += inner[0]
+			x += inner[1]
+			if where != nil {
+				m += inner[2]
+			}
+		}
+	})
+	if failure != nil {
+		return nil, failure
+	}
+	return res, nil
+}
+
+// timeLayouts formats a datetime at the precision of each unit, as NumPy prints it.
+var timeLayouts = [...]string{
+	Years:        "2006",
+	Months:       "2006-01",
+	Days:         "2006-01-02",
+	Seconds:      "2006-01-02T15:04:05",
+	Milliseconds: "2006-01-02T15:04:05.000",
+	Microseconds: "2006-01-02T15:04:05.000000",
+	Nanoseconds:  "2006-01-02T15:04:05.000000000",
+}
+
+// formatTimes lists the elements of a time buffer for String: datetimes as ISO
+// 8601 at the unit's precision, timedeltas as their tick counts, NaT as NaT.
+func formatTimes(buf storage) string {
+
+	b := buf.(timeBuf)
+	parts := make([]string, len(b.ticks))
+	for i, v := range b.ticks {
+		parts[i] = formatTime(v, b.d)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// formatTime prints one tick of time type d, see formatTimes.
+func formatTime(v int64, d DType) string {
+	switch {
+	case v == NaT:
+		return "NaT"
+	case d.kind() == 'm':
+		return strconv.FormatInt(v, 10)
+	default:
+		return ticksToTime(v, d.unit()).Format(timeLayouts[d.unit()])
+	}
+}
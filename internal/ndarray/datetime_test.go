@@ -0,0 +1,425 @@
+package ndarray_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+// date returns midnight UTC of a day.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestTimeDTypes(t *testing.T) {
+	tests := []struct {
+		d    ndarray.DType
+		name string
+	}{
+		{ndarray.Datetime64(ndarray.Years), "datetime64[Y]"},
+		{ndarray.Datetime64(ndarray.Days), "datetime64[D]"},
+		{ndarray.Datetime64(ndarray.Nanoseconds), "datetime64[ns]"},
+		{ndarray.Timedelta64(ndarray.Months), "timedelta64[M]"},
+		{ndarray.Timedelta64(ndarray.Microseconds), "timedelta64[us]"},
+	}
+	for _, tt := range tests {
+		if tt.d.String() != tt.name || tt.d.ItemSize() != 8 {
+			t.Errorf("got %s (%d bytes), want %s (8 bytes)", tt.d, tt.d.ItemSize(), tt.name)
+		}
+	}
+
+	casts := []struct {
+		from, to ndarray.DType
+		casting  ndarray.Casting
+		want     bool
+	}{
+		{ndarray.Datetime64(ndarray.Days), ndarray.Datetime64(ndarray.Seconds), ndarray.CastingSafe, true},
+		{ndarray.Datetime64(ndarray.Seconds), ndarray.Datetime64(ndarray.Days), ndarray.CastingSafe, false},
+		{ndarray.Datetime64(ndarray.Seconds), ndarray.Datetime64(ndarray.Days), ndarray.CastingSameKind, true},
+		{ndarray.Timedelta64(ndarray.Years), ndarray.Timedelta64(ndarray.Months), ndarray.CastingSafe, true},
+		{ndarray.Timedelta64(ndarray.Months), ndarray.Timedelta64(ndarray.Days), ndarray.CastingSameKind, false},
+		{ndarray.Timedelta64(ndarray.Months), ndarray.Timedelta64(ndarray.Days), ndarray.CastingUnsafe, true},
+		{ndarray.Int32, ndarray.Timedelta64(ndarray.Seconds), ndarray.CastingSafe, true},
+		{ndarray.Float64, ndarray.Timedelta64(ndarray.Seconds), ndarray.CastingSameKind, false},
+		{ndarray.Int64, ndarray.Datetime64(ndarray.Seconds), ndarray.CastingUnsafe, true},
+		{ndarray.Datetime64(ndarray.Seconds), ndarray.Int64, ndarray.CastingSameKind, false},
+		{ndarray.Datetime64(ndarray.Days), ndarray.Timedelta64(ndarray.Days), ndarray.CastingUnsafe, false},
+		{ndarray.Timedelta64(ndarray.Days), ndarray.Complex128, ndarray.CastingUnsafe, false},
+	}
+	for _, tt := range casts {
+		if got := ndarray.CanCast(tt.from, tt.to, tt.casting); got != tt.want {
+			t.Errorf("CanCast(%s, %s, %s) = %v, want %v", tt.from, tt.to, tt.casting, got, tt.want)
+		}
+	}
+
+	if got, err := ndarray.ResultType(ndarray.Datetime64(ndarray.Days), ndarray.Datetime64(ndarray.Milliseconds)); err != nil || got != ndarray.Datetime64(ndarray.Milliseconds) {
+		t.Errorf("ResultType of two datetimes: got %s, %v", got, err)
+	}
+	if got, err := ndarray.ResultType(ndarray.Timedelta64(ndarray.Seconds), ndarray.Int16); err != nil || got != ndarray.Timedelta64(ndarray.Seconds) {
+		t.Errorf("ResultType of timedelta and int16: got %s, %v", got, err)
+	}
+}
+
+func TestTimeConversions(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 12, 30, 15, 250_000_000, time.UTC)
+	before := time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC)
+	times := []time.Time{t0, before, {}}
+
+	tests := []struct {
+		unit ndarray.TimeUnit
+		want string
+	}{
+		{ndarray.Years, "[2024 1969 NaT]"},
+		{ndarray.Months, "[2024-03 1969-12 NaT]"},
+		{ndarray.Days, "[2024-03-01 1969-12-31 NaT]"},
+		{ndarray.Seconds, "[2024-03-01T12:30:15 1969-12-31T23:59:59 NaT]"},
+		{ndarray.Milliseconds, "[2024-03-01T12:30:15.250 1969-12-31T23:59:59.000 NaT]"},
+		{ndarray.Nanoseconds, "[2024-03-01T12:30:15.250000000 1969-12-31T23:59:59.000000000 NaT]"},
+	}
+	for _, tt := range tests {
+		a, err := ndarray.FromTimes(times, tt.unit)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "NDArray(shape=[3], data=" + tt.want + ")"; a.String() != want {
+			t.Errorf("unit %s: got %s, want %s", tt.unit, a, want)
+		}
+	}
+
+	// Ticks are counted from the epoch and floored
+	days, _ := ndarray.FromTimes(times, ndarray.Days)
+	ticks, _ := ndarray.ToSliceOf[int64](days)
+	if fmt.Sprint(ticks[:2]) != "[19783 -1]" {
+		t.Errorf("day ticks: got %v, want [19783 -1]", ticks[:2])
+	}
+
+	back, err := days.Times()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !back[0].Equal(date(2024, 3, 1)) || !back[1].Equal(date(1969, 12, 31)) || !back[2].IsZero() {
+		t.Errorf("times: got %v", back)
+	}
+	if v, _ := days.Value(1); v != date(1969, 12, 31) {
+		t.Errorf("value: got %v", v)
+	}
+
+	// Other locations are read in UTC
+	tokyo := time.FixedZone("JST", 9*3600)
+	local, _ := ndarray.FromTimes([]time.Time{time.Date(2024, 1, 1, 3, 0, 0, 0, tokyo)}, ndarray.Days)
+	if got, want := local.String(), "NDArray(shape=[1], data=[2023-12-31])"; got != want {
+		t.Errorf("non-UTC input: got %s, want %s", got, want)
+	}
+
+	durations := []time.Duration{1500 * time.Millisecond, -time.Nanosecond, time.Duration(ndarray.NaT)}
+	d, err := ndarray.FromDurations(durations, ndarray.Seconds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := d.String(), "NDArray(shape=[3], data=[1 -1 NaT])"; got != want {
+		t.Errorf("durations: got %s, want %s", got, want)
+	}
+	ds, err := d.Durations()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ds[0] != time.Second || ds[1] != -time.Second || ds[2] != time.Duration(ndarray.NaT) {
+		t.Errorf("back to durations: got %v", ds)
+	}
+	long, _ := typed[int64](t, 1e11).AsType(ndarray.Timedelta64(ndarray.Seconds))
+	if got, err := long.Durations(); err == nil {
+		t.Errorf("1e11 seconds as durations: got %v, want an error", got)
+	}
+	if got, err := long.Value(0); err == nil {
+		t.Errorf("1e11 seconds as a value: got %v, want an error", got)
+	}
+
+	if _, err := d.Times(); err == nil {
+		t.Errorf("expected error reading timedeltas as times")
+	}
+	if _, err := ndarray.FromTimes(times, ndarray.TimeUnit(42)); err == nil {
+		t.Errorf("expected error for an unknown unit")
+	}
+
+	// Element access through Go values
+	if err := days.SetValue(date(2000, 2, 29).Add(13*time.Hour), 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.SetValue(2*time.Minute, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := days.String()+" "+d.String(), "NDArray(shape=[3], data=[2024-03-01 1969-12-31 2000-02-29]) NDArray(shape=[3], data=[120 -1 NaT])"; got != want {
+		t.Errorf("after SetValue: got %s, want %s", got, want)
+	}
+
+	// Instants past 2262 do not fit in nanoseconds but do in coarser units
+	far := date(3000, 1, 1)
+	if err := days.SetValue(far, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := days.Value(0); v != far {
+		t.Errorf("SetValue(3000-01-01) on days: got %v", v)
+	}
+	if s, err := ndarray.ScalarOf(far); err != nil || s.String() != "NDArray(shape=[], data=[3000-01-01T00:00:00.000000])" {
+		t.Errorf("ScalarOf(3000-01-01): got %v, %v", s, err)
+	}
+	if _, err := ndarray.FromTimes([]time.Time{far}, ndarray.Nanoseconds); err == nil {
+		t.Errorf("expected error for 3000-01-01 in nanoseconds")
+	}
+	ns, _ := ndarray.FromTimes(times[:1], ndarray.Nanoseconds)
+	if err := ns.SetValue(far, 0); err == nil {
+		t.Errorf("expected error setting 3000-01-01 in nanoseconds")
+	}
+	if _, err := ndarray.ArangeDatetime(date(2000, 1, 1), far, 1, ndarray.Nanoseconds); err == nil {
+		t.Errorf("expected error for an arange ending past 2262")
+	}
+}
+
+func TestTimeAsType(t *testing.T) {
+	months, _ := ndarray.FromTimes([]time.Time{date(2024, 1, 1), date(2024, 2, 1), date(1969, 11, 1)}, ndarray.Months)
+
+	tests := []struct {
+		to   ndarray.DType
+		want string
+	}{
+		{ndarray.Datetime64(ndarray.Days), "[2024-01-01 2024-02-01 1969-11-01]"},
+		{ndarray.Datetime64(ndarray.Years), "[2024 2024 1969]"},
+		{ndarray.Int64, "[648 649 -2]"},
+		{ndarray.Float64, "[648 649 -2]"},
+	}
+	for _, tt := range tests {
+		got, err := months.AsType(tt.to)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "NDArray(shape=[3], data=" + tt.want + ")"; got.String() != want {
+			t.Errorf("astype %s: got %s, want %s", tt.to, got, want)
+		}
+	}
+
+	if _, err := months.AsType(ndarray.Timedelta64(ndarray.Months)); err == nil {
+		t.Errorf("expected error casting datetimes to timedeltas")
+	}
+
+	// Calendar timedeltas convert with the average year
+	year, _ := typed[int64](t, 1).AsType(ndarray.Timedelta64(ndarray.Years))
+	secs, _ := year.AsType(ndarray.Timedelta64(ndarray.Seconds))
+	if got, want := secs.String(), "NDArray(shape=[1], data=[31556952])"; got != want {
+		t.Errorf("one year in seconds: got %s, want %s", got, want)
+	}
+
+	nat, _ := typed(t, 1.9, math.NaN()).AsType(ndarray.Timedelta64(ndarray.Days))
+	if got, want := nat.String(), "NDArray(shape=[2], data=[1 NaT])"; got != want {
+		t.Errorf("floats to timedeltas: got %s, want %s", got, want)
+	}
+
+	// Finer units refuse ticks they cannot count instead of wrapping them
+	far, _ := ndarray.FromTimes([]time.Time{date(3000, 1, 1)}, ndarray.Days)
+	if got, err := far.AsType(ndarray.Datetime64(ndarray.Nanoseconds)); err == nil {
+		t.Errorf("3000-01-01 to datetime64[ns]: got %s, want an error", got)
+	}
+	if got, err := far.AsType(ndarray.Datetime64(ndarray.Microseconds)); err != nil || got.String() != "NDArray(shape=[1], data=[3000-01-01T00:00:00.000000])" {
+		t.Errorf("3000-01-01 to datetime64[us]: got %v, %v", got, err)
+	}
+	years, _ := typed[int64](t, math.MaxInt64/6).AsType(ndarray.Timedelta64(ndarray.Years))
+	if got, err := years.AsType(ndarray.Timedelta64(ndarray.Months)); err == nil {
+		t.Errorf("%d years to months: got %s, want an error", int64(math.MaxInt64/6), got)
+	}
+}
+
+func TestTimeArithmetic(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	days, _ := ndarray.FromTimes([]time.Time{date(2024, 3, 1), date(2024, 3, 10), {}}, ndarray.Days)
+	secs, _ := ndarray.FromTimes([]time.Time{t0, t0.Add(90 * time.Minute), t0}, ndarray.Seconds)
+	hour, _ := ndarray.FromDurations([]time.Duration{time.Hour}, ndarray.Seconds)
+	week, _ := ndarray.FromDurations([]time.Duration{7 * 24 * time.Hour}, ndarray.Days)
+	month, _ := typed[int64](t, 1).AsType(ndarray.Timedelta64(ndarray.Months))
+	jan, _ := ndarray.FromTimes([]time.Time{date(2024, 1, 1)}, ndarray.Months)
+	three, _ := ndarray.ScalarOf(int8(3))
+
+	tests := []struct {
+		name  string
+		op    func(a, b *ndarray.NDArray, opts ...ndarray.Option) (*ndarray.NDArray, error)
+		a, b  *ndarray.NDArray
+		dtype ndarray.DType
+		want  string
+	}{
+		{"datetime - datetime", ndarray.Sub, secs, days, ndarray.Timedelta64(ndarray.Seconds), "[43200 -729000 NaT]"},
+		{"datetime + timedelta", ndarray.Add, days, week, ndarray.Datetime64(ndarray.Days), "[2024-03-08 2024-03-17 NaT]"},
+		{"timedelta + datetime", ndarray.Add, hour, days, ndarray.Datetime64(ndarray.Seconds), "[2024-03-01T01:00:00 2024-03-10T01:00:00 NaT]"},
+		{"datetime - timedelta", ndarray.Sub, secs, hour, ndarray.Datetime64(ndarray.Seconds), "[2024-03-01T11:00:00 2024-03-01T12:30:00 2024-03-01T11:00:00]"},
+		{"datetime + integer", ndarray.Add, days, three, ndarray.Datetime64(ndarray.Days), "[2024-03-04 2024-03-13 NaT]"},
+		{"month arithmetic", ndarray.Add, jan, month, ndarray.Datetime64(ndarray.Months), "[2024-02]"},
+		{"months meet days", ndarray.Add, jan, week, ndarray.Datetime64(ndarray.Days), "[2024-01-08]"},
+		{"timedelta + timedelta", ndarray.Add, week, hour, ndarray.Timedelta64(ndarray.Seconds), "[608400]"},
+		{"timedelta * integer", ndarray.Mul, week, three, ndarray.Timedelta64(ndarray.Days), "[21]"},
+		{"timedelta * float", ndarray.Mul, hour, ndarray.Scalar(0.25), ndarray.Timedelta64(ndarray.Seconds), "[900]"},
+		{"timedelta / timedelta", ndarray.Div, week, hour, ndarray.Float64, "[168]"},
+		{"timedelta / integer", ndarray.Div, hour, three, ndarray.Timedelta64(ndarray.Seconds), "[1200]"},
+		{"maximum", ndarray.Maximum, secs, days, ndarray.Datetime64(ndarray.Seconds), "[2024-03-01T12:00:00 2024-03-10T00:00:00 NaT]"},
+		{"less", ndarray.Less, days, secs, ndarray.Bool, "[true false false]"},
+		{"equal with NaT", ndarray.Equal, days, days, ndarray.Bool, "[true true false]"},
+		{"not equal with NaT", ndarray.NotEqual, days, days, ndarray.Bool, "[false false true]"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.op(tt.a, tt.b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if want := "NDArray(shape=" + fmt.Sprint(got.Shape()) + ", data=" + tt.want + ")"; got.String() != want || got.DType() != tt.dtype {
+			t.Errorf("%s: got %s (%s), want %s (%s)", tt.name, got, got.DType(), want, tt.dtype)
+		}
+	}
+
+	neg, _ := ndarray.Negative(hour)
+	abs, _ := ndarray.Abs(neg)
+	if neg.String() != "NDArray(shape=[1], data=[-3600])" || abs.String() != "NDArray(shape=[1], data=[3600])" {
+		t.Errorf("negative / abs: got %s, %s", neg, abs)
+	}
+
+	errors := []struct {
+		name string
+		op   func(a, b *ndarray.NDArray, opts ...ndarray.Option) (*ndarray.NDArray, error)
+		a, b *ndarray.NDArray
+	}{
+		{"datetime + datetime", ndarray.Add, days, days},
+		{"datetime * integer", ndarray.Mul, days, three},
+		{"datetime + float", ndarray.Add, days, ndarray.Scalar(1)},
+		{"days + calendar month", ndarray.Add, days, month},
+		{"power", ndarray.Pow, hour, three},
+	}
+	for _, tt := range errors {
+		if _, err := tt.op(tt.a, tt.b); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+	if _, err := ndarray.Sqrt(hour); err == nil {
+		t.Errorf("expected error for sqrt of a timedelta")
+	}
+
+	// Operands are converted to the finer unit, which must hold them
+	far, _ := ndarray.FromTimes([]time.Time{date(3000, 1, 1)}, ndarray.Days)
+	ns, _ := ndarray.FromTimes([]time.Time{t0}, ndarray.Nanoseconds)
+	if got, err := ndarray.Sub(far, ns); err == nil {
+		t.Errorf("3000-01-01 - 2024 in ns: got %s, want an error", got)
+	}
+	farOut, _ := ndarray.FromTimes(make([]time.Time, 1), ndarray.Nanoseconds)
+	if _, err := ndarray.Add(far, week, ndarray.WithOut(farOut)); err == nil {
+		t.Errorf("expected error storing 3000-01-08 into datetime64[ns]")
+	}
+
+	// In-place, into an output of a coarser unit
+	out, _ := ndarray.FromTimes(make([]time.Time, 3), ndarray.Days)
+	if _, err := ndarray.Add(secs, hour, ndarray.WithOut(out)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := out.String(), "NDArray(shape=[3], data=[2024-03-01 2024-03-01 2024-03-01])"; got != want {
+		t.Errorf("out of unit D: got %s, want %s", got, want)
+	}
+}
+
+func TestTimeReductions(t *testing.T) {
+	d, _ := ndarray.FromDurations([]time.Duration{time.Hour, 30 * time.Minute, 2 * time.Hour, 0}, ndarray.Seconds, 2, 2)
+
+	sum, err := d.Sum()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := sum.String(), "NDArray(shape=[], data=[12600])"; got != want || sum.DType() != ndarray.Timedelta64(ndarray.Seconds) {
+		t.Errorf("sum: got %s (%s), want %s", got, sum.DType(), want)
+	}
+	if rows, _ := d.Sum(ndarray.WithAxes(1)); rows.String() != "NDArray(shape=[2], data=[5400 7200])" {
+		t.Errorf("sum along axis 1: got %s", rows)
+	}
+	if mean, _ := d.Mean(); mean.String() != "NDArray(shape=[], data=[3150])" || mean.DType() != ndarray.Timedelta64(ndarray.Seconds) {
+		t.Errorf("mean: got %s (%s)", mean, mean.DType())
+	}
+
+	dates, _ := ndarray.FromTimes([]time.Time{date(2024, 5, 1), date(2023, 1, 9), date(2024, 2, 29)}, ndarray.Days)
+	lo, _ := dates.Min()
+	hi, _ := dates.Max()
+	if lo.String() != "NDArray(shape=[], data=[2023-01-09])" || hi.String() != "NDArray(shape=[], data=[2024-05-01])" {
+		t.Errorf("min / max: got %s, %s", lo, hi)
+	}
+
+	withNaT, _ := ndarray.FromTimes([]time.Time{date(2024, 5, 1), {}}, ndarray.Days)
+	if m, _ := withNaT.Max(); m.String() != "NDArray(shape=[], data=[NaT])" {
+		t.Errorf("max with NaT: got %s", m)
+	}
+
+	if _, err := dates.Sum(); err == nil {
+		t.Errorf("expected error summing datetimes")
+	}
+	if _, err := ndarray.AddUfunc.Accumulate(d, 0); err == nil {
+		t.Errorf("expected error accumulating timedeltas")
+	}
+}
+
+func TestArangeDatetime(t *testing.T) {
+	tests := []struct {
+		name        string
+		start, stop time.Time
+		step        int64
+		unit        ndarray.TimeUnit
+		want        string
+	}{
+		{"days", date(2024, 2, 27), date(2024, 3, 2), 1, ndarray.Days, "[2024-02-27 2024-02-28 2024-02-29 2024-03-01]"},
+		{"every 3 days", date(2024, 1, 1), date(2024, 1, 7), 3, ndarray.Days, "[2024-01-01 2024-01-04]"},
+		{"months", date(2024, 11, 15), date(2025, 3, 1), 1, ndarray.Months, "[2024-11 2024-12 2025-01 2025-02]"},
+		{"backwards years", date(2024, 6, 1), date(2020, 1, 1), -2, ndarray.Years, "[2024 2022]"},
+		{"hours in seconds", date(2024, 1, 1), date(2024, 1, 1).Add(150 * time.Minute), 3600, ndarray.Seconds, "[2024-01-01T00:00:00 2024-01-01T01:00:00 2024-01-01T02:00:00]"},
+		{"empty", date(2024, 1, 2), date(2024, 1, 1), 1, ndarray.Days, "[]"},
+	}
+
+	for _, tt := range tests {
+		a, err := ndarray.ArangeDatetime(tt.start, tt.stop, tt.step, tt.unit)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if want := "NDArray(shape=" + fmt.Sprint(a.Shape()) + ", data=" + tt.want + ")"; a.String() != want {
+			t.Errorf("%s: got %s, want %s", tt.name, a, want)
+		}
+	}
+
+	if _, err := ndarray.ArangeDatetime(date(2024, 1, 1), date(2024, 2, 1), 0, ndarray.Days); err == nil {
+		t.Errorf("expected error for a zero step")
+	}
+	if _, err := ndarray.ArangeDatetime(time.Time{}, date(2024, 2, 1), 1, ndarray.Days); err == nil {
+		t.Errorf("expected error for a NaT bound")
+	}
+}
+
+func TestIsNaT(t *testing.T) {
+	a, _ := ndarray.FromTimes([]time.Time{date(2024, 1, 1), {}, date(1970, 1, 1), {}}, ndarray.Days, 2, 2)
+
+	mask, err := ndarray.IsNaT(a.T())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := mask.String(), "NDArray(shape=[2 2], data=[false false true true])"; got != want {
+		t.Errorf("isnat: got %s, want %s", got, want)
+	}
+	if _, err := ndarray.IsNaT(typed(t, 1.0)); err == nil {
+		t.Errorf("expected error for a float array")
+	}
+
+	// Joining keeps the finer unit, and refuses numbers
+	b, _ := ndarray.FromTimes([]time.Time{date(2024, 1, 1).Add(time.Hour)}, ndarray.Seconds)
+	flat := a.Flatten(ndarray.OrderC)
+	joined, err := ndarray.Concatenate([]*ndarray.NDArray{flat, b}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if joined.DType() != ndarray.Datetime64(ndarray.Seconds) {
+		t.Errorf("concatenate: got %s, want datetime64[s]", joined.DType())
+	}
+	if _, err := ndarray.Concatenate([]*ndarray.NDArray{b, typed(t, 1.0)}, 0); err == nil {
+		t.Errorf("expected error joining datetimes and floats")
+	}
+}
@@ -0,0 +1,531 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║     ██████╗ ████████╗██╗   ██╗██████╗ ███████╗                                     ║
+// ║     ██╔══██╗╚══██╔══╝╚██╗ ██╔╝██╔══██╗██╔════╝                                     ║
+// ║     ██║  ██║   ██║    ╚████╔╝ ██████╔╝█████╗                                       ║
+// ║     ██║  ██║   ██║     ╚██╔╝  ██╔═══╝ ██╔══╝                                       ║
+// ║     ██████╔╝   ██║      ██║   ██║     ███████╗                                     ║
+// ║     ╚═════╝    ╚═╝      ╚═╝   ╚═╝     ╚══════╝                                     ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Element types of NDArray: the DType tag, casting rules                            ║
+// ║  between types and conversions with AsType.                                        ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/dtype.go                 ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"fmt"
+	"time"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: DType – Element type of an NDArray                                         ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Mirrors `ndarray.dtype`. Each type has its own storage (see                      ║
+// ║   storage.go), so elements take exactly ItemSize() bytes:                          ║
+// ║                                                                                    ║
+// ║     - Float64    : IEEE-754 doubles (the default)                                  ║
+// ║     - Float32    : IEEE-754 singles                                                ║
+// ║     - Float16    : IEEE-754 halves (binary16)                                      ║
+// ║     - BFloat16   : brain floats, float32's range with 8 bits of precision          ║
+// ║     - Int8 … Int64   : signed integers                                             ║
+// ║     - Uint8 … Uint64 : unsigned integers                                           ║
+// ║     - Bool       : true / false                                                    ║
+// ║     - Complex128 : pairs of doubles                                                ║
+// ║     - Complex64  : pairs of singles                                                ║
+// ║     - Datetime64(unit), Timedelta64(unit) : int64 ticks of a TimeUnit              ║
+// ║     - Unicode(width), Bytes(width) : fixed-width strings (see text.go)             ║
+// ║                                                                                    ║
+// ║   Views share their base's dtype. Results of comparisons and logical               ║
+// ║   operations are Bool; arithmetic produces ResultType of its inputs                ║
+// ║   (see ResultType and the Ufunc docs).                                             ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   m, _ := Greater(a, Scalar(0))                                                    ║
+// ║   m.DType()          → Bool                                                        ║
+// ║   fmt.Println(m)     → NDArray(shape=[3], data=[false true true])                  ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type DType int
+
+const (
+	Float64 DType = iota
+	Bool
+	Float32
+	Int64
+	Int32
+	Uint8
+	Complex128
+	Int8
+	Int16
+	Uint16
+	Uint32
+	Uint64
+	Complex64
+	Float16
+	BFloat16
+)
+
+// dtypeInfo holds the NumPy name, kind character and item size of each DType.
+var dtypeInfo = map[DType]typeInfo{
+	Float64:    {"float64", 'f', 8},
+	Bool:       {"bool", 'b', 1},
+	Float32:    {"float32", 'f', 4},
+	Int64:      {"int64", 'i', 8},
+	Int32:      {"int32", 'i', 4},
+	Uint8:      {"uint8", 'u', 1},
+	Complex128: {"complex128", 'c', 16},
+	Int8:       {"int8", 'i', 1},
+	Int16:      {"int16", 'i', 2},
+	Uint16:     {"uint16", 'u', 2},
+	Uint32:     {"uint32", 'u', 4},
+	Uint64:     {"uint64", 'u', 8},
+	Complex64:  {"complex64", 'c', 8},
+	Float16:    {"float16", 'f', 2},
+	BFloat16:   {"bfloat16", 'f', 2},
+}
+
+type typeInfo struct {
+	name string
+	kind byte
+	size int
+}
+
+// String returns the NumPy name of the type.
+func (d DType) String() string {
+	if info, ok := d.info(); ok {
+		return info.name
+	}
+	return fmt.Sprintf("DType(%d)", int(d))
+}
+
+// ItemSize returns the number of bytes one element takes.
+func (d DType) ItemSize() int {
+	info, _ := d.info()
+	return info.size
+}
+
+// kind returns NumPy's kind character: 'b', 'u', 'i', 'f', 'c', 'M', 'm', 'U', 'S' or 'V'.
+func (d DType) kind() byte {
+	info, _ := d.info()
+	return info.kind
+}
+
+// info looks d up in dtypeInfo, among the structured types (see Struct), or among
+// the text types, whose width is part of the DType itself (see Unicode).
+func (d DType) info() (typeInfo, bool) {
+	if info, ok := dtypeInfo[d]; ok {
+		return info, true
+	}
+	if l := d.layout(); l != nil {
+		return typeInfo{l.name, 'V', l.size}, true
+	}
+	return textInfo(d)
+}
+
+// DType returns the element type of the array.
+func (a *NDArray) DType() DType { return a.dtype }
+
+// Nbytes returns the number of bytes taken by the elements of the array.
+func (a *NDArray) Nbytes() int { return a.Size() * a.dtype.ItemSize() }
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Value, SetValue – Element access in the array's own Go type                ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Get and Set go through float64, which is exact for most dtypes but               ║
+// ║   not for int64 beyond 2^53 nor for complex numbers. These keep the                ║
+// ║   element type instead:                                                            ║
+// ║                                                                                    ║
+// ║   - Value returns uint8 for Uint8 arrays, complex128 for Complex128, ...           ║
+// ║     time.Time for datetime64, time.Duration for timedelta64, string                ║
+// ║     for unicode, []byte for bytes and a map[string]any from field                  ║
+// ║     names to values for structured types                                           ║
+// ║   - SetValue accepts any Element type, int, uint, time.Time,                       ║
+// ║     time.Duration, string, []byte or a struct (see FromStructs), and               ║
+// ║     converts it to the array's dtype as AsType would (strings are                  ║
+// ║     parsed, or rejected; structs go field by field, by position)                   ║
+// ║   - One index per axis, negative indices count from the end                        ║
+// ║                                                                                    ║
+// ║   Returns: (any, error) / error – also on timedeltas beyond                        ║
+// ║            time.Duration's ±292 years                                              ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   ids, _ := ZerosOf(Int64, 2)                                                      ║
+// ║   ids.SetValue(int64(1)<<60+1, 0)                                                  ║
+// ║   v, _ := ids.Value(0)   → int64(1152921504606846977), exactly                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Value(indices ...int) (any, error) {
+
+	offset, err := a.elementOffset(indices)
+	if err != nil {
+		return nil, err
+	}
+	if a.dtype.kind() == 'm' {
+		return tickDuration(intAt(a.data, offset), a.dtype.unit())
+	}
+	return a.data.value(offset), nil
+}
+
+func (a *NDArray) SetValue(value any, indices ...int) error {
+
+	src, err := scalarStorage(value)
+	if err != nil {
+		return err
+	}
+	if !a.flags.Writeable {
+		return fmt.Errorf("assignment destination is read-only")
+	}
+
+	offset, err := a.elementOffset(indices)
+	if err != nil {
+		return err
+	}
+	if t, ok := src.(texts); ok && !a.dtype.isText() {
+		return parseElem(a.data, offset, t.text(0))
+	}
+	if t, ok := value.(time.Time); ok && a.dtype.kind() == 'M' {
+		// Count straight in the array's unit so instants it cannot hold are refused
+		ticks, err := timeToTicks(t, a.dtype.unit())
+		if err != nil {
+			return err
+		}
+		a.data.(integers).setInt(offset, ticks)
+		return nil
+	}
+	a.data.copyFrom(offset, src, 0)
+	return nil
+}
+
+// elementOffset resolves one index per axis to a data offset.
+func (a *NDArray) elementOffset(indices []int) (int, error) {
+
+	if len(indices) != len(a.shape) {
+		return 0, fmt.Errorf("number of indices (%d) does not match array dimensions (%d)", len(indices), len(a.shape))
+	}
+
+	offset := a.offset
+	for i, idx := range indices {
+		resolved, ok := resolveIndex(idx, a.shape[i], ModeRaise)
+		if !ok {
+			return 0, fmt.Errorf("index %d out of bounds for axis %d with size %d", idx, i, a.shape[i])
+		}
+		offset += resolved * a.strides[i]
+	}
+	return offset, nil
+}
+
+// realAt reads the element at a data offset as float64, refusing complex elements
+// whose imaginary part would be lost, texts and records.
+func (a *NDArray) realAt(offset int) (float64, error) {
+	if a.dtype.kind() == 'c' || a.dtype.isText() || a.dtype.isRecord() {
+		return 0, fmt.Errorf("cannot read a %s element as float64, use Value instead", a.dtype)
+	}
+	return a.data.float(offset), nil
+}
+
+// withDType converts a freshly built array to dtype d and returns it.
+func (a *NDArray) withDType(d DType) *NDArray {
+	if d != a.dtype {
+		a.data = convertStorage(a.data, d)
+		a.dtype = d
+	}
+	return a
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: Casting – How much information a conversion may lose                       ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Mirrors NumPy's `casting=` argument:                                             ║
+// ║                                                                                    ║
+// ║     - CastingSafe     : only conversions that preserve every value                 ║
+// ║     - CastingSameKind : safe ones, plus conversions within a kind or               ║
+// ║                         towards a "bigger" kind (bool → uint → int →               ║
+// ║                         float → complex), e.g. float64 → float32                   ║
+// ║     - CastingUnsafe   : anything goes                                              ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   CanCast(Int32, Float64, CastingSafe)     → true                                  ║
+// ║   CanCast(Float64, Float32, CastingSafe)   → false                                 ║
+// ║   CanCast(Float64, Float32, CastingSameKind) → true                                ║
+// ║   CanCast(Float64, Int64, CastingSameKind) → false                                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type Casting int
+
+const (
+	CastingSafe Casting = iota
+	CastingSameKind
+	CastingUnsafe
+)
+
+// String returns the NumPy name of the casting rule.
+func (c Casting) String() string {
+	switch c {
+	case CastingSafe:
+		return "safe"
+	case CastingSameKind:
+		return "same_kind"
+	case CastingUnsafe:
+		return "unsafe"
+	default:
+		return fmt.Sprintf("Casting(%d)", int(c))
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: CanCast – Whether a conversion is allowed under a casting rule             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.can_cast(from, to, casting)`, with NumPy's table:              ║
+// ║                                                                                    ║
+// ║   - bool casts safely to anything, nothing but bool casts safely to bool           ║
+// ║   - Within a kind, the target must be at least as wide                             ║
+// ║   - uint → int needs a strictly wider int                                          ║
+// ║   - Integers → float need a float wider than the integer, except that              ║
+// ║     64-bit integers count as safe for float64 (as in NumPy)                        ║
+// ║   - Floats and integers → complex follow the same rules on each half               ║
+// ║   - datetime64 / timedelta64 have rules of their own (see Datetime64)              ║
+// ║   - So do unicode and bytes (see Unicode), and structured types                    ║
+// ║     (see Struct)                                                                   ║
+// ║                                                                                    ║
+// ║   Returns: bool                                                                    ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   CanCast(Uint8, Int32, CastingSafe)  → true                                       ║
+// ║   CanCast(Int32, Float32, CastingSafe) → false                                     ║
+// ║   CanCast(Int64, Uint8, CastingSameKind) → false                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func CanCast(from, to DType, casting Casting) bool {
+
+	if from.isRecord() || to.isRecord() {
+		return recordCast(from, to, casting)
+	}
+	if from.isText() || to.isText() {
+		return textCast(from, to, casting)
+	}
+	if from.isTime() || to.isTime() {
+		return timeCast(from, to, casting)
+	}
+
+	switch casting {
+	case CastingUnsafe:
+		return true
+	case CastingSameKind:
+		if kindOrder(to.kind()) >= kindOrder(from.kind()) {
+			return true
+		}
+	}
+
+	return safeCast(from, to)
+}
+
+// safeCast reports whether every value of from is representable in to.
+func safeCast(from, to DType) bool {
+
+	if from.isRecord() || to.isRecord() {
+		return recordCast(from, to, CastingSafe)
+	}
+	if from.isText() || to.isText() {
+		return textCast(from, to, CastingSafe)
+	}
+	if from.isTime() || to.isTime() {
+		return timeCast(from, to, CastingSafe)
+	}
+
+	fk, tk := from.kind(), to.kind()
+	fs, ts := from.ItemSize(), to.ItemSize()
+	if tk == 'c' {
+		// Each half of a complex must hold the value on its own
+		ts /= 2
+	}
+
+	switch {
+	case from == to || fk == 'b':
+		return true
+	case tk == 'b':
+		return false
+	case fs == 2 && ts == 2 && fk == 'f' && tk == 'f':
+		// float16 and bfloat16 trade range for precision: neither holds the other
+		return false
+	case fk == tk:
+		return ts >= fs
+	case fk == 'u' && tk == 'i':
+		return ts > fs
+	case (fk == 'u' || fk == 'i') && (tk == 'f' || tk == 'c'):
+		return ts > fs || ts == 8
+	case fk == 'f' && tk == 'c':
+		return ts >= fs
+	default:
+		return false
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: ResultType – The dtype operands of several types combine into              ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.result_type(*dtypes)` under NumPy 2 rules (NEP 50):            ║
+// ║   the smallest type every operand casts to safely (see CanCast).                   ║
+// ║   0-d arrays count like any other operand: Scalar(1) is Float64 and                ║
+// ║   promotes, ScalarOf(int8(1)) is Int8 and does not.                                ║
+// ║                                                                                    ║
+// ║   Promotion table for the integer and float types (rows ∘ columns):                ║
+// ║                                                                                    ║
+// ║              │ b    u8   u16  u32  u64  i8   i16  i32  i64  f32  f64               ║
+// ║      ────────┼──────────────────────────────────────────────────────               ║
+// ║      bool    │ b    u8   u16  u32  u64  i8   i16  i32  i64  f32  f64               ║
+// ║      uint8   │      u8   u16  u32  u64  i16  i16  i32  i64  f32  f64               ║
+// ║      uint16  │           u16  u32  u64  i32  i32  i32  i64  f32  f64               ║
+// ║      uint32  │                u32  u64  i64  i64  i64  i64  f64  f64               ║
+// ║      uint64  │                     u64  f64  f64  f64  f64  f64  f64               ║
+// ║      int8    │                          i8   i16  i32  i64  f32  f64               ║
+// ║      int16   │                               i16  i32  i64  f32  f64               ║
+// ║      int32   │                                    i32  i64  f64  f64               ║
+// ║      int64   │                                         i64  f64  f64               ║
+// ║      float32 │                                              f32  f64               ║
+// ║                                                                                    ║
+// ║   - Mixing uint64 with any signed integer gives float64, as in NumPy               ║
+// ║   - float16 and bfloat16 each take int8 and uint8; together they give              ║
+// ║     float32, since neither holds the other                                         ║
+// ║   - complex64 takes what float32 takes, complex128 absorbs the rest                ║
+// ║   - Times of one kind promote to the finer unit, and timedelta64 takes             ║
+// ║     bool and integers; other mixes with times have no common type                  ║
+// ║   - Text types promote to the wider width, and to unicode when either              ║
+// ║     is; text and non-text types have no common type                                ║
+// ║   - With no arguments the result is Float64                                        ║
+// ║                                                                                    ║
+// ║   Returns: (DType, error) – error when the types have no common type               ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   ResultType(Int8, Uint8)     → Int16                                              ║
+// ║   ResultType(Int32, Float32)  → Float64                                            ║
+// ║   ResultType(Int64, Uint64)   → Float64                                            ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func ResultType(dtypes ...DType) (DType, error) {
+
+	if len(dtypes) == 0 {
+		return Float64, nil
+	}
+
+	res := dtypes[0]
+	for _, d := range dtypes[1:] {
+		var err error
+		if res, err = promoteTypes(res, d); err != nil {
+			return 0, err
+		}
+	}
+	return res, nil
+}
+
+// promotionOrder lists the dtypes from the narrowest to the widest; the promotion
+// of two types is the first one here that both cast to safely.
+var promotionOrder = []DType{
+	Bool, Uint8, Uint16, Uint32, Uint64, Int8, Int16, Int32, Int64,
+	Float16, BFloat16, Float32, Float64, Complex64, Complex128,
+}
+
+// promoteTypes is np.promote_types for two dtypes, failing when neither casts
+// safely to the other nor both to a common numeric type.
+func promoteTypes(a, b DType) (DType, error) {
+	switch {
+	case a.isText() && b.isText():
+		return promoteText(a, b), nil
+	case safeCast(a, b):
+		return b, nil
+	case safeCast(b, a):
+		return a, nil
+	}
+
+	for _, d := range promotionOrder {
+		if safeCast(a, d) && safeCast(b, d) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("no common dtype for %s and %s", a, b)
+}
+
+// kindOrder ranks kinds from the narrowest (bool) to the widest (complex).
+func kindOrder(kind byte) int {
+	switch kind {
+	case 'b':
+		return 0
+	case 'u':
+		return 1
+	case 'i':
+		return 2
+	case 'f':
+		return 3
+	default:
+		return 4
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: AsType – Copy of the array converted to another dtype                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a.astype(dtype, casting=...)`.                                    ║
+// ║                                                                                    ║
+// ║   - The result is always a fresh C-contiguous array                                ║
+// ║   - The casting rule defaults to CastingUnsafe, as in NumPy; pass                  ║
+// ║     WithCasting to refuse lossy conversions                                        ║
+// ║   - Floats → integers truncate toward zero; out-of-range values wrap               ║
+// ║     (300.0 → uint8 gives 44); anything non-zero → bool is true;                    ║
+// ║     complex → real types drops the imaginary part                                  ║
+// ║   - Texts → other types parse each element (see parseElem), and fail               ║
+// ║     on the first one that is not a number of the target kind                       ║
+// ║   - Structured types convert field by field, matched by position;                  ║
+// ║     other values are written to every field (see Struct)                           ║
+// ║   - Times to a finer unit fail if a tick overflows, where NumPy wraps              ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – error if the casting rule forbids it,               ║
+// ║            or on a time overflow                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = [1.7 -2.5 300]                                                               ║
+// ║   a.AsType(Int32)                         → [1 -2 300]                             ║
+// ║   a.AsType(Uint8)                         → [1 254 44]                             ║
+// ║   a.AsType(Int32, WithCasting(CastingSafe)) → error                                ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) AsType(d DType, opts ...Option) (*NDArray, error) {
+
+	if _, ok := d.info(); !ok {
+		return nil, fmt.Errorf("data type %s not understood", d)
+	}
+
+	o := collectOptions(opts)
+	casting := CastingUnsafe
+	if o.hasCasting {
+		casting = o.casting
+	}
+	if !CanCast(a.dtype, d, casting) {
+		return nil, fmt.Errorf("cannot cast array data from dtype('%s') to dtype('%s') according to the rule '%s'", a.dtype, d, casting)
+	}
+
+	if a.dtype.isText() && !d.isText() {
+		return parseTexts(a, d)
+	}
+	if a.dtype.isTime() && a.dtype.kind() == d.kind() {
+		return convertTimes(a, d)
+	}
+	return a.Copy().withDType(d), nil
+}
@@ -0,0 +1,353 @@
+package ndarray_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestNewOf(t *testing.T) {
+	tests := []struct {
+		dtype ndarray.DType
+		name  string
+		size  int
+		want  string
+	}{
+		{ndarray.Float64, "float64", 8, "NDArray(shape=[2 2], data=[1 1 1 1])"},
+		{ndarray.Float32, "float32", 4, "NDArray(shape=[2 2], data=[1 1 1 1])"},
+		{ndarray.Int64, "int64", 8, "NDArray(shape=[2 2], data=[1 1 1 1])"},
+		{ndarray.Int32, "int32", 4, "NDArray(shape=[2 2], data=[1 1 1 1])"},
+		{ndarray.Int16, "int16", 2, "NDArray(shape=[2 2], data=[1 1 1 1])"},
+		{ndarray.Int8, "int8", 1, "NDArray(shape=[2 2], data=[1 1 1 1])"},
+		{ndarray.Uint64, "uint64", 8, "NDArray(shape=[2 2], data=[1 1 1 1])"},
+		{ndarray.Uint32, "uint32", 4, "NDArray(shape=[2 2], data=[1 1 1 1])"},
+		{ndarray.Uint16, "uint16", 2, "NDArray(shape=[2 2], data=[1 1 1 1])"},
+		{ndarray.Uint8, "uint8", 1, "NDArray(shape=[2 2], data=[1 1 1 1])"},
+		{ndarray.Bool, "bool", 1, "NDArray(shape=[2 2], data=[true true true true])"},
+		{ndarray.Complex128, "complex128", 16, "NDArray(shape=[2 2], data=[1+0i 1+0i 1+0i 1+0i])"},
+		{ndarray.Complex64, "complex64", 8, "NDArray(shape=[2 2], data=[1+0i 1+0i 1+0i 1+0i])"},
+	}
+
+	for _, tt := range tests {
+		a, err := ndarray.OnesOf(tt.dtype, 2, 2)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if a.DType() != tt.dtype || a.DType().String() != tt.name {
+			t.Errorf("%s: got dtype %s", tt.name, a.DType())
+		}
+		if a.DType().ItemSize() != tt.size || a.Nbytes() != 4*tt.size {
+			t.Errorf("%s: got item size %d, nbytes %d", tt.name, a.DType().ItemSize(), a.Nbytes())
+		}
+		if got := a.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := ndarray.NewOf(ndarray.DType(99), 2); err == nil {
+		t.Errorf("expected error for unknown dtype")
+	}
+	if _, err := ndarray.ZerosOf(ndarray.Uint8, 0); err == nil {
+		t.Errorf("expected error for invalid shape")
+	}
+}
+
+func TestTypedGetSet(t *testing.T) {
+	a, _ := ndarray.ZerosOf(ndarray.Uint8, 2, 3)
+	if err := a.Set(300, 1, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.Set(7.9, -1, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := a.String(), "NDArray(shape=[2 3], data=[0 0 0 7 0 44])"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if v, _ := a.Get(1, 2); v != 44 {
+		t.Errorf("Get: got %v, want 44", v)
+	}
+
+	// Views share the typed buffer
+	row, _ := a.Slice(ndarray.At(0))
+	_ = row.Set(5, 1)
+	if v, _ := a.Value(0, 1); v != uint8(5) {
+		t.Errorf("view write: got %v (%T), want uint8(5)", v, v)
+	}
+	if row.DType() != ndarray.Uint8 {
+		t.Errorf("view dtype: got %s", row.DType())
+	}
+
+	// Value/SetValue are exact where float64 is not
+	ids, _ := ndarray.ZerosOf(ndarray.Int64, 2)
+	big := int64(1)<<60 + 1
+	if err := ids.SetValue(big, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := ids.Value(0); v != big {
+		t.Errorf("Value: got %v, want %v", v, big)
+	}
+
+	z, _ := ndarray.ZerosOf(ndarray.Complex128, 1)
+	_ = z.SetValue(complex(1, 2), 0)
+	if v, _ := z.Value(0); v != complex(1, 2) {
+		t.Errorf("complex Value: got %v", v)
+	}
+	if _, err := z.Get(0); err == nil {
+		t.Errorf("expected error reading complex with Get")
+	}
+	if err := z.SetValue("x", 0); err == nil {
+		t.Errorf("expected error for unsupported Go type")
+	}
+	if _, err := z.Value(0, 0); err == nil {
+		t.Errorf("expected error for wrong number of indices")
+	}
+}
+
+func TestAsType(t *testing.T) {
+	a, _ := ndarray.FromSlice([]float64{1.7, -2.5, 300, 0})
+
+	tests := []struct {
+		dtype ndarray.DType
+		want  string
+	}{
+		{ndarray.Int32, "NDArray(shape=[4], data=[1 -2 300 0])"},
+		{ndarray.Uint8, "NDArray(shape=[4], data=[1 254 44 0])"},
+		{ndarray.Bool, "NDArray(shape=[4], data=[true true true false])"},
+		{ndarray.Float32, "NDArray(shape=[4], data=[1.7 -2.5 300 0])"},
+		{ndarray.Complex128, "NDArray(shape=[4], data=[1.7+0i -2.5+0i 300+0i 0+0i])"},
+	}
+
+	for _, tt := range tests {
+		res, err := a.AsType(tt.dtype)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.dtype, err)
+			continue
+		}
+		if got := res.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.dtype, got, tt.want)
+		}
+	}
+
+	// Complex → real drops the imaginary part; integers convert exactly
+	z, _ := ndarray.FromSliceOf([]complex128{complex(3, 4)})
+	re, _ := z.AsType(ndarray.Float64)
+	if got, want := re.String(), "NDArray(shape=[1], data=[3])"; got != want {
+		t.Errorf("complex: got %s, want %s", got, want)
+	}
+	ids, _ := ndarray.FromSliceOf([]int64{1<<62 + 1})
+	back, _ := ids.AsType(ndarray.Int32)
+	back, _ = back.AsType(ndarray.Int64)
+	if v, _ := back.Value(0); v != int64(1) {
+		t.Errorf("int64 → int32 → int64: got %v, want 1", v)
+	}
+
+	if _, err := a.AsType(ndarray.Int32, ndarray.WithCasting(ndarray.CastingSafe)); err == nil {
+		t.Errorf("expected error for unsafe cast under CastingSafe")
+	}
+	if _, err := a.AsType(ndarray.Float32, ndarray.WithCasting(ndarray.CastingSameKind)); err != nil {
+		t.Errorf("unexpected error for same_kind cast: %v", err)
+	}
+
+	// AsType always copies, even to the same dtype
+	c, _ := a.AsType(ndarray.Float64)
+	_ = c.Set(9, 0)
+	if v, _ := a.Get(0); v != 1.7 {
+		t.Errorf("AsType should copy, original changed to %v", v)
+	}
+}
+
+func TestCanCast(t *testing.T) {
+	const (
+		safe     = ndarray.CastingSafe
+		sameKind = ndarray.CastingSameKind
+		unsafe   = ndarray.CastingUnsafe
+	)
+
+	// Expected values are those of np.can_cast
+	tests := []struct {
+		from, to ndarray.DType
+		casting  ndarray.Casting
+		want     bool
+	}{
+		{ndarray.Bool, ndarray.Uint8, safe, true},
+		{ndarray.Uint8, ndarray.Bool, safe, false},
+		{ndarray.Uint8, ndarray.Int32, safe, true},
+		{ndarray.Uint8, ndarray.Float32, safe, true},
+		{ndarray.Int32, ndarray.Int64, safe, true},
+		{ndarray.Int64, ndarray.Int32, safe, false},
+		{ndarray.Int32, ndarray.Float32, safe, false},
+		{ndarray.Int32, ndarray.Float64, safe, true},
+		{ndarray.Int64, ndarray.Float64, safe, true},
+		{ndarray.Int64, ndarray.Uint8, safe, false},
+		{ndarray.Float32, ndarray.Float64, safe, true},
+		{ndarray.Float64, ndarray.Float32, safe, false},
+		{ndarray.Float64, ndarray.Complex128, safe, true},
+		{ndarray.Int64, ndarray.Complex128, safe, true},
+		{ndarray.Complex128, ndarray.Float64, safe, false},
+		{ndarray.Float64, ndarray.Float32, sameKind, true},
+		{ndarray.Int64, ndarray.Int32, sameKind, true},
+		{ndarray.Int64, ndarray.Float32, sameKind, true},
+		{ndarray.Int64, ndarray.Uint8, sameKind, false},
+		{ndarray.Float64, ndarray.Int64, sameKind, false},
+		{ndarray.Complex128, ndarray.Float64, sameKind, false},
+		{ndarray.Float64, ndarray.Bool, sameKind, false},
+		{ndarray.Complex128, ndarray.Bool, unsafe, true},
+		{ndarray.Int8, ndarray.Int16, safe, true},
+		{ndarray.Int16, ndarray.Int8, safe, false},
+		{ndarray.Uint16, ndarray.Int16, safe, false},
+		{ndarray.Uint16, ndarray.Int32, safe, true},
+		{ndarray.Uint32, ndarray.Int64, safe, true},
+		{ndarray.Uint64, ndarray.Int64, safe, false},
+		{ndarray.Uint64, ndarray.Float64, safe, true},
+		{ndarray.Int8, ndarray.Uint64, safe, false},
+		{ndarray.Int16, ndarray.Float32, safe, true},
+		{ndarray.Uint32, ndarray.Float32, safe, false},
+		{ndarray.Int8, ndarray.Uint8, sameKind, false},
+		{ndarray.Uint8, ndarray.Int8, sameKind, true},
+		{ndarray.Uint64, ndarray.Uint8, sameKind, true},
+		{ndarray.Complex64, ndarray.Complex128, safe, true},
+		{ndarray.Complex128, ndarray.Complex64, safe, false},
+		{ndarray.Complex128, ndarray.Complex64, sameKind, true},
+		{ndarray.Float32, ndarray.Complex64, safe, true},
+		{ndarray.Float64, ndarray.Complex64, safe, false},
+		{ndarray.Int16, ndarray.Complex64, safe, true},
+	}
+
+	for _, tt := range tests {
+		if got := ndarray.CanCast(tt.from, tt.to, tt.casting); got != tt.want {
+			t.Errorf("CanCast(%s, %s, %s) = %v, want %v", tt.from, tt.to, tt.casting, got, tt.want)
+		}
+	}
+}
+
+func TestResultType(t *testing.T) {
+	// Expected values are those of np.result_type under NumPy 2
+	tests := []struct {
+		types []ndarray.DType
+		want  ndarray.DType
+	}{
+		{nil, ndarray.Float64},
+		{[]ndarray.DType{ndarray.Int8}, ndarray.Int8},
+		{[]ndarray.DType{ndarray.Bool, ndarray.Bool}, ndarray.Bool},
+		{[]ndarray.DType{ndarray.Bool, ndarray.Int8}, ndarray.Int8},
+		{[]ndarray.DType{ndarray.Int8, ndarray.Uint8}, ndarray.Int16},
+		{[]ndarray.DType{ndarray.Int16, ndarray.Uint16}, ndarray.Int32},
+		{[]ndarray.DType{ndarray.Int32, ndarray.Uint32}, ndarray.Int64},
+		{[]ndarray.DType{ndarray.Int64, ndarray.Uint64}, ndarray.Float64},
+		{[]ndarray.DType{ndarray.Int8, ndarray.Uint64}, ndarray.Float64},
+		{[]ndarray.DType{ndarray.Uint8, ndarray.Uint32}, ndarray.Uint32},
+		{[]ndarray.DType{ndarray.Int8, ndarray.Int64}, ndarray.Int64},
+		{[]ndarray.DType{ndarray.Int16, ndarray.Float32}, ndarray.Float32},
+		{[]ndarray.DType{ndarray.Int32, ndarray.Float32}, ndarray.Float64},
+		{[]ndarray.DType{ndarray.Uint16, ndarray.Float32}, ndarray.Float32},
+		{[]ndarray.DType{ndarray.Int64, ndarray.Float64}, ndarray.Float64},
+		{[]ndarray.DType{ndarray.Float32, ndarray.Complex128}, ndarray.Complex128},
+		{[]ndarray.DType{ndarray.Float32, ndarray.Complex64}, ndarray.Complex64},
+		{[]ndarray.DType{ndarray.Float64, ndarray.Complex64}, ndarray.Complex128},
+		{[]ndarray.DType{ndarray.Int32, ndarray.Complex64}, ndarray.Complex128},
+		{[]ndarray.DType{ndarray.Int8, ndarray.Uint8, ndarray.Float32}, ndarray.Float32},
+		{[]ndarray.DType{ndarray.Uint8, ndarray.Int8, ndarray.Uint16}, ndarray.Int32},
+	}
+
+	for _, tt := range tests {
+		if got, err := ndarray.ResultType(tt.types...); err != nil || got != tt.want {
+			t.Errorf("ResultType(%v) = %s, %v, want %s", tt.types, got, err, tt.want)
+		}
+	}
+
+	// Times and texts mixed with numbers have no common type
+	none := [][]ndarray.DType{
+		{ndarray.Unicode(3), ndarray.Float64},
+		{ndarray.Int8, ndarray.Bytes(2)},
+		{ndarray.Datetime64(ndarray.Days), ndarray.Float64},
+		{ndarray.Timedelta64(ndarray.Seconds), ndarray.Complex64},
+		{ndarray.Int8, ndarray.Float32, ndarray.Datetime64(ndarray.Seconds)},
+	}
+	for _, types := range none {
+		if got, err := ndarray.ResultType(types...); err == nil {
+			t.Errorf("ResultType(%v) = %s, want an error", types, got)
+		}
+	}
+}
+
+func TestTypedSliceRoundTrip(t *testing.T) {
+	pixels := []uint8{0, 64, 128, 255, 1, 2}
+	img, err := ndarray.FromSliceOf(pixels, 2, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if img.DType() != ndarray.Uint8 || img.Nbytes() != 6 {
+		t.Errorf("got dtype %s, %d bytes", img.DType(), img.Nbytes())
+	}
+
+	// The input slice was copied
+	pixels[0] = 9
+	out, err := ndarray.ToSliceOf[uint8](img.T())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := fmt.Sprint(out), "[0 255 64 1 128 2]"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	if _, err := ndarray.ToSliceOf[float64](img); err == nil {
+		t.Errorf("expected error for mismatched element type")
+	}
+	if _, err := ndarray.FromSliceOf([]int32{1, 2, 3}, 2); err == nil {
+		t.Errorf("expected error for mismatched shape")
+	}
+}
+
+func TestTypedOperations(t *testing.T) {
+	a, _ := ndarray.FromSliceOf([]int32{1, 2, 3, 4, 5, 6}, 2, 3)
+
+	// Structural operations keep the dtype
+	r, _ := a.T().Reshape(-1)
+	taken, _ := a.Take([]int{2, 0}, ndarray.ModeRaise, 1)
+	joined, _ := ndarray.Concatenate([]*ndarray.NDArray{a, a}, 0)
+	for name, res := range map[string]*ndarray.NDArray{"reshape": r, "take": taken, "concatenate": joined, "copy": a.Copy()} {
+		if res.DType() != ndarray.Int32 {
+			t.Errorf("%s: got dtype %s, want int32", name, res.DType())
+		}
+	}
+	if got, want := r.String(), "NDArray(shape=[6], data=[1 4 2 5 3 6])"; got != want {
+		t.Errorf("reshape: got %s, want %s", got, want)
+	}
+
+	// Mixing with float64 promotes to float64
+	sum, err := ndarray.Add(a, ndarray.Scalar(0.5))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := sum.String(), "NDArray(shape=[2 3], data=[1.5 2.5 3.5 4.5 5.5 6.5])"; got != want || sum.DType() != ndarray.Float64 {
+		t.Errorf("add: got %s (%s), want %s", got, sum.DType(), want)
+	}
+	total, _ := a.Sum()
+	if v, _ := total.Item(); v != 21 || total.DType() != ndarray.Int64 {
+		t.Errorf("sum: got %v (%s), want 21 (int64)", v, total.DType())
+	}
+
+	// Writing a float64 result into an int32 array needs unsafe casting
+	if _, err := ndarray.Add(a, ndarray.Scalar(0.5), ndarray.WithOut(a)); err == nil {
+		t.Errorf("expected error for float64 output into int32 under same_kind")
+	}
+	if _, err := ndarray.Add(a, ndarray.Scalar(0.5), ndarray.WithOut(a), ndarray.WithCasting(ndarray.CastingUnsafe)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := a.String(), "NDArray(shape=[2 3], data=[1 2 3 4 5 6])"; got != want || a.DType() != ndarray.Int32 {
+		t.Errorf("unsafe out: got %s, want %s", got, want)
+	}
+
+	// Comparisons on any real dtype give Bool
+	m, _ := ndarray.Greater(a, ndarray.Scalar(3))
+	if got, want := m.String(), "NDArray(shape=[2 3], data=[false false false true true true])"; got != want {
+		t.Errorf("greater: got %s, want %s", got, want)
+	}
+
+	z, _ := ndarray.OnesOf(ndarray.Complex128, 2)
+	if _, err := ndarray.Mod(z, z); err == nil {
+		t.Errorf("expected error for complex remainder")
+	}
+}
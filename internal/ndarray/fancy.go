@@ -0,0 +1,350 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║     ███████╗ █████╗ ███╗   ██╗ ██████╗██╗   ██╗                                    ║
+// ║     ██╔════╝██╔══██╗████╗  ██║██╔════╝╚██╗ ██╔╝                                    ║
+// ║     █████╗  ███████║██╔██╗ ██║██║      ╚████╔╝                                     ║
+// ║     ██╔══╝  ██╔══██║██║╚██╗██║██║       ╚██╔╝                                      ║
+// ║     ██║     ██║  ██║██║ ╚████║╚██████╗   ██║                                       ║
+// ║     ╚═╝     ╚═╝  ╚═╝╚═╝  ╚═══╝ ╚═════╝   ╚═╝                                       ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Advanced (integer-array) indexing for NDArray: gather,                            ║
+// ║  scatter and accumulating scatter with NumPy placement rules.                      ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/fancy.go                 ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"fmt"
+	"math"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Indices, List – Integer-array subscript entries                            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Indices(arr) uses every element of `arr` as a position along its axis;           ║
+// ║   List(i, j, ...) is the same for a literal 1-D list.                              ║
+// ║                                                                                    ║
+// ║   - Elements must be whole numbers; negatives count from the end                   ║
+// ║   - Several index arrays in one subscript are broadcast together                   ║
+// ║   - Boolean arrays are not accepted, use Mask for those; neither is a              ║
+// ║     nil array                                                                      ║
+// ║                                                                                    ║
+// ║   Returns: Index                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   PYTHON → GO:                                                                     ║
+// ║                                                                                    ║
+// ║   a[[0, 2]]          → a.Gather(List(0, 2))                                        ║
+// ║   a[:, idx]          → a.Gather(All, Indices(idx))                                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Indices(arr *NDArray) Index {
+	return Index{kind: indexArray, array: arr}
+}
+
+func List(indices ...int) Index {
+	data := make([]float64, len(indices))
+	for i, idx := range indices {
+		data[i] = float64(idx)
+	}
+	return Indices(newOwned(data, []int{len(data)}, []int{1}))
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   STRUCT: fancyPlan – A resolved advanced subscript                                ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Advanced indexing is reduced to basic indexing plus a list of offsets:           ║
+// ║                                                                                    ║
+// ║     - `view`     : `a` sliced by the basic entries, advanced axes kept whole       ║
+// ║     - `offsets`  : for each position of the broadcast index shape, the             ║
+// ║                    data offset of the selected sub-array of `view`                 ║
+// ║     - `rest*`    : shape / strides of the sub-array (the non-advanced axes)        ║
+// ║     - `shape`    : result shape; the broadcast index shape starts at               ║
+// ║                    `bStart` and spans `bLen` axes                                  ║
+// ║                                                                                    ║
+// ║   Following NumPy, the broadcast index dimensions replace the advanced             ║
+// ║   entries in place when those are adjacent, and go first otherwise.                ║
+// ║   Integer entries count as advanced as soon as one array is present.               ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE (a.Shape() → [5, 6, 7, 8], i and j of shape [2, 3]):                     ║
+// ║                                                                                    ║
+// ║   a[:, i, j, :]  → [5, 2, 3, 8]   (adjacent: stays in place)                       ║
+// ║   a[i, :, j, :]  → [2, 3, 6, 8]   (separated: goes first)                          ║
+// ║   a[:, 1, j, :]  → [5, 2, 3, 8]   (the integer is advanced too)                    ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type fancyPlan struct {
+	view        *NDArray
+	offsets     []int
+	restShape   []int
+	restStrides []int
+	shape       []int
+	bStart      int
+	bLen        int
+}
+
+// plan resolves a subscript containing at least one index array; ok is false when the
+// subscript is purely basic.
+func (a *NDArray) plan(indices []Index) (*fancyPlan, bool, error) {
+
+	arrays := 0
+	for _, idx := range indices {
+		if idx.kind != indexArray {
+			continue
+		}
+		if idx.array == nil {
+			return nil, false, fmt.Errorf("index array is nil")
+		}
+		if idx.array.dtype == Bool {
+			return nil, false, fmt.Errorf("boolean index arrays are not supported here, use Mask")
+		}
+		arrays++
+	}
+	if arrays == 0 {
+		return nil, false, nil
+	}
+
+	// Every entry of the expanded subscript maps to exactly one axis of the view
+	expanded, err := expandEllipsis(indices, len(a.shape))
+	if err != nil {
+		return nil, false, err
+	}
+
+	basic := make([]Index, len(expanded))
+	var advAxes []int
+	var advArrays []*NDArray
+	for k, idx := range expanded {
+		basic[k] = idx
+		switch idx.kind {
+		case indexArray:
+			basic[k] = All
+			advAxes = append(advAxes, k)
+			advArrays = append(advArrays, idx.array)
+		case indexInt:
+			basic[k] = All
+			advAxes = append(advAxes, k)
+			advArrays = append(advArrays, Scalar(float64(idx.start)))
+		}
+	}
+
+	view, err := a.Slice(basic...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	shapes := make([][]int, len(advArrays))
+	for j, arr := range advArrays {
+		shapes[j] = arr.shape
+	}
+	bShape, err := BroadcastShapes(shapes...)
+	if err != nil {
+		return nil, false, fmt.Errorf("shape mismatch: indexing arrays could not be broadcast together with shapes %v", shapes)
+	}
+
+	// Offsets of every selected sub-array, in row-major order of the index shape
+	offsets := make([]int, shapeSize(bShape))
+	for i := range offsets {
+		offsets[i] = view.offset
+	}
+	for j, arr := range advArrays {
+		b, _ := BroadcastTo(arr, bShape...)
+		axis := advAxes[j]
+		n := view.shape[axis]
+		for i, v := range gatherData(b) {
+			if v != math.Trunc(v) {
+				return nil, false, fmt.Errorf("arrays used as indices must be of integer type, got %v", v)
+			}
+			idx := int(v)
+			if idx < -n || idx >= n {
+				return nil, false, fmt.Errorf("index %d is out of bounds for axis %d with size %d", idx, axis, n)
+			}
+			if idx < 0 {
+				idx += n
+			}
+			offsets[i] += idx * view.strides[axis]
+		}
+	}
+
+	p := &fancyPlan{view: view, offsets: offsets, bLen: len(bShape)}
+	adjacent := advAxes[len(advAxes)-1]-advAxes[0] == len(advAxes)-1
+	isAdv := make([]bool, len(view.shape))
+	for _, axis := range advAxes {
+		isAdv[axis] = true
+	}
+	for axis := range view.shape {
+		if isAdv[axis] {
+			continue
+		}
+		if adjacent && axis < advAxes[0] {
+			p.bStart++
+		}
+		p.restShape = append(p.restShape, view.shape[axis])
+		p.restStrides = append(p.restStrides, view.strides[axis])
+	}
+
+	p.shape = append(append(append([]int{}, p.restShape[:p.bStart]...), bShape...), p.restShape[p.bStart:]...)
+	if len(p.shape) > 32 {
+		return nil, false, fmt.Errorf("shape has too many dimensions (max 32)")
+	}
+	return p, true, nil
+}
+
+// each calls fn with the source sub-array of every index position and the matching
+// sub-array of `target`, an array with the plan's result shape.
+func (p *fancyPlan) each(target *NDArray, fn func(src, dst *NDArray) error) error {
+
+	bShape := p.shape[p.bStart : p.bStart+p.bLen]
+	restStrides := append(append([]int{}, target.strides[:p.bStart]...), target.strides[p.bStart+p.bLen:]...)
+
+	index := make([]int, p.bLen)
+	for _, offset := range p.offsets {
+		t := target.offset
+		for i, idx := range index {
+			t += idx * target.strides[p.bStart+i]
+		}
+
+		src := p.view.view(p.restShape, p.restStrides, offset)
+		if err := fn(src, target.view(p.restShape, restStrides, t)); err != nil {
+			return err
+		}
+		nextIndex(index, bShape)
+	}
+	return nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Gather – Advanced indexing with integer arrays                             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a[idx]` where the subscript mixes integer arrays with             ║
+// ║   basic entries (At, Span, All, NewAxis, Ellipsis).                                ║
+// ║                                                                                    ║
+// ║   - All index arrays (and integers) are broadcast together                         ║
+// ║   - Their broadcast shape replaces the indexed axes in place when they             ║
+// ║     are adjacent, and becomes the leading axes otherwise (NumPy rules)             ║
+// ║   - The result is a copy; a purely basic subscript returns the same                ║
+// ║     view as Slice                                                                  ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a = [[0 1 2] [3 4 5] [6 7 8]]                                                    ║
+// ║   a.Gather(List(2, 0))                  → [[6 7 8] [0 1 2]]                        ║
+// ║   a.Gather(List(0, 1, 2), List(2, 1, 0)) → [2 4 6]   (anti-diagonal)               ║
+// ║   a.Gather(All, List(0, 0))              → [[0 0] [3 3] [6 6]]                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Gather(indices ...Index) (*NDArray, error) {
+
+	p, ok, err := a.plan(indices)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return a.Slice(indices...)
+	}
+
+	out := allocOf(p.shape, a.dtype)
+	err = p.each(out, func(src, dst *NDArray) error {
+		return assign(dst, src)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Scatter – Assign through an advanced subscript                             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a[idx] = values`.                                                 ║
+// ║                                                                                    ║
+// ║   - `values` is broadcast to the shape a.Gather(idx...) would have                 ║
+// ║   - Positions are written in row-major order of the index arrays, so               ║
+// ║     with repeated indices the last value wins, as in NumPy                         ║
+// ║   - Works in place, including on views                                             ║
+// ║                                                                                    ║
+// ║   Returns: error                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a = [0 0 0 0]                                                                    ║
+// ║   a.Scatter(FromSlice([1 2 3]), List(0, 2, 0)) → a = [3 0 2 0]                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Scatter(values *NDArray, indices ...Index) error {
+	return a.scatter(values, indices, func(src, dst *NDArray) error {
+		return assign(dst, src)
+	})
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: ScatterAdd – Accumulate through an advanced subscript                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.add.at(a, idx, values)`: like Scatter, but adds to             ║
+// ║   the selected elements without buffering, so repeated indices add                 ║
+// ║   up instead of overwriting each other.                                            ║
+// ║                                                                                    ║
+// ║   Returns: error                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a = [0 0 0 0]                                                                    ║
+// ║   a.ScatterAdd(Scalar(1), List(0, 2, 0)) → a = [2 0 1 0]   (a histogram)           ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) ScatterAdd(values *NDArray, indices ...Index) error {
+	return a.scatter(values, indices, func(src, dst *NDArray) error {
+		_, err := Add(dst, src, WithOut(dst), WithCasting(CastingUnsafe))
+		return err
+	})
+}
+
+// scatter broadcasts values to the subscript's shape and calls write(value, target) on
+// each selected sub-array of a, in order.
+func (a *NDArray) scatter(values *NDArray, indices []Index, write func(src, dst *NDArray) error) error {
+
+	if !a.flags.Writeable {
+		return fmt.Errorf("assignment destination is read-only")
+	}
+
+	p, ok, err := a.plan(indices)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		v, err := a.Slice(indices...)
+		if err != nil {
+			return err
+		}
+		p = &fancyPlan{view: v, offsets: []int{v.offset}, restShape: v.shape, restStrides: v.strides, shape: v.shape}
+	}
+
+	src, err := BroadcastTo(values, p.shape...)
+	if err != nil {
+		return fmt.Errorf("shape mismatch: value array of shape %v could not be broadcast to indexing result of shape %v",
+			values.shape, p.shape)
+	}
+	if sharesMemory(a, values) {
+		src = src.Copy()
+	}
+
+	// each walks the values with the roles swapped: the "source" is a's sub-array
+	return p.each(src, func(target, value *NDArray) error {
+		return write(value, target)
+	})
+}
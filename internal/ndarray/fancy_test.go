@@ -0,0 +1,170 @@
+package ndarray_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestGather(t *testing.T) {
+	a := seq(t, 3, 3)
+	rows, _ := ndarray.FromNested([][]int{{0}, {2}})
+
+	tests := []struct {
+		name    string
+		indices []ndarray.Index
+		want    string
+	}{
+		{"rows", []ndarray.Index{ndarray.List(2, 0)}, "NDArray(shape=[2 3], data=[6 7 8 0 1 2])"},
+		{"pairs", []ndarray.Index{ndarray.List(0, 1, 2), ndarray.List(2, 1, -3)}, "NDArray(shape=[3], data=[2 4 6])"},
+		{"columns", []ndarray.Index{ndarray.All, ndarray.List(0, 0)}, "NDArray(shape=[3 2], data=[0 0 3 3 6 6])"},
+		{"broadcast", []ndarray.Index{ndarray.Indices(rows), ndarray.List(0, 2)}, "NDArray(shape=[2 2], data=[0 2 6 8])"},
+		{"with integer", []ndarray.Index{ndarray.At(1), ndarray.List(2, 2)}, "NDArray(shape=[2], data=[5 5])"},
+		{"with range", []ndarray.Index{ndarray.Span(1, ndarray.None), ndarray.List(0)}, "NDArray(shape=[2 1], data=[3 6])"},
+	}
+
+	for _, tt := range tests {
+		res, err := a.Gather(tt.indices...)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got := res.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	// Gathered results are copies
+	res, _ := a.Gather(ndarray.List(0))
+	_ = res.Set(100, 0, 0)
+	if v, _ := a.Get(0, 0); v != 0 {
+		t.Error("expected Gather to copy")
+	}
+}
+
+func TestGatherPlacement(t *testing.T) {
+	b := seq(t, 5, 6, 7, 8)
+	i, _ := ndarray.Zeros(2, 3)
+	j, _ := ndarray.Ones(2, 3)
+	I, J := ndarray.Indices(i), ndarray.Indices(j)
+	all := ndarray.All
+
+	tests := []struct {
+		name    string
+		indices []ndarray.Index
+		want    string
+	}{
+		{"adjacent", []ndarray.Index{all, I, J, all}, "[5 2 3 8]"},
+		{"separated", []ndarray.Index{I, all, J, all}, "[2 3 6 8]"},
+		{"integer is advanced", []ndarray.Index{all, ndarray.At(1), J, all}, "[5 2 3 8]"},
+		{"integer separates", []ndarray.Index{ndarray.At(1), all, J}, "[2 3 6 8]"},
+		{"ellipsis", []ndarray.Index{ndarray.Ellipsis, J}, "[5 6 7 2 3]"},
+		{"new axis", []ndarray.Index{ndarray.NewAxis, I}, "[1 2 3 6 7 8]"},
+	}
+
+	for _, tt := range tests {
+		res, err := b.Gather(tt.indices...)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got := fmt.Sprint(res.Shape()); got != tt.want {
+			t.Errorf("%s: got shape %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	// b[[[1]], :, [[2]], :][0, 0, 4, 5] == b[1, 4, 2, 5]
+	res, _ := b.Gather(ndarray.List(1), all, ndarray.List(2), all)
+	if v, _ := res.Get(0, 4, 5); v != 581 {
+		t.Errorf("expected b[1, 4, 2, 5] = 581, got %v", v)
+	}
+}
+
+func TestGatherErrors(t *testing.T) {
+	a := seq(t, 3, 3)
+	half, _ := ndarray.FromSlice([]float64{0.5})
+	mask, _ := ndarray.FromNested([]bool{true, false, true})
+
+	tests := []struct {
+		name    string
+		indices []ndarray.Index
+	}{
+		{"out of bounds", []ndarray.Index{ndarray.List(3)}},
+		{"negative out of bounds", []ndarray.Index{ndarray.All, ndarray.List(-4)}},
+		{"not an integer", []ndarray.Index{ndarray.Indices(half)}},
+		{"boolean", []ndarray.Index{ndarray.Indices(mask)}},
+		{"nil array", []ndarray.Index{ndarray.Indices(nil)}},
+		{"shape mismatch", []ndarray.Index{ndarray.List(0, 1), ndarray.List(0, 1, 2)}},
+		{"too many indices", []ndarray.Index{ndarray.List(0), ndarray.All, ndarray.At(0)}},
+	}
+
+	for _, tt := range tests {
+		if _, err := a.Gather(tt.indices...); err == nil {
+			t.Errorf("%s: expected error, got nil", tt.name)
+		}
+	}
+
+	if _, err := a.Slice(ndarray.List(0)); err == nil {
+		t.Error("expected Slice to reject index arrays, got nil")
+	}
+}
+
+func TestScatter(t *testing.T) {
+	a, _ := ndarray.Zeros(4)
+
+	// Repeated indices: the last value wins
+	vals, _ := ndarray.FromSlice([]float64{1, 2, 3})
+	if err := a.Scatter(vals, ndarray.List(0, 2, 0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := a.String(); got != "NDArray(shape=[4], data=[3 0 2 0])" {
+		t.Errorf("unexpected Scatter: %s", got)
+	}
+
+	// Whole rows through a transposed view, with a broadcast value
+	m, _ := ndarray.Zeros(3, 2)
+	mt := m.T()
+	if err := mt.Scatter(ndarray.Scalar(9), ndarray.All, ndarray.List(2, 0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := m.String(); got != "NDArray(shape=[3 2], data=[9 9 0 0 9 9])" {
+		t.Errorf("unexpected Scatter on a view: %s", got)
+	}
+
+	if err := a.Scatter(seq(t, 2), ndarray.List(0, 1, 2)); err == nil {
+		t.Error("expected broadcast error, got nil")
+	}
+
+	ro, _ := ndarray.BroadcastTo(a, 2, 4)
+	if err := ro.Scatter(ndarray.Scalar(1), ndarray.List(0)); err == nil {
+		t.Error("expected read-only error, got nil")
+	}
+}
+
+func TestScatterAdd(t *testing.T) {
+	hist, _ := ndarray.Zeros(4)
+	if err := hist.ScatterAdd(ndarray.Scalar(1), ndarray.List(0, 2, 0, 3, 0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := hist.String(); got != "NDArray(shape=[4], data=[3 0 1 1])" {
+		t.Errorf("unexpected ScatterAdd: %s", got)
+	}
+
+	// Accumulating rows of a 2-D array
+	m, _ := ndarray.Zeros(2, 3)
+	if err := m.ScatterAdd(seq(t, 3, 3), ndarray.List(1, 1, 0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := m.String(); got != "NDArray(shape=[2 3], data=[6 7 8 3 5 7])" {
+		t.Errorf("unexpected row ScatterAdd: %s", got)
+	}
+
+	// Basic subscripts still work, as a plain in-place add
+	if err := m.ScatterAdd(ndarray.Scalar(1), ndarray.At(0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := m.String(); got != "NDArray(shape=[2 3], data=[7 8 9 3 5 7])" {
+		t.Errorf("unexpected basic ScatterAdd: %s", got)
+	}
+}
@@ -0,0 +1,209 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║     ██╗  ██╗ █████╗ ██╗     ███████╗                                               ║
+// ║     ██║  ██║██╔══██╗██║     ██╔════╝                                               ║
+// ║     ███████║███████║██║     █████╗                                                 ║
+// ║     ██╔══██║██╔══██║██║     ██╔══╝                                                 ║
+// ║     ██║  ██║██║  ██║███████╗██║                                                    ║
+// ║     ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝╚═╝                                                    ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Half-precision floats: IEEE float16 and bfloat16 encoding                         ║
+// ║  with round-to-nearest-even, NaN and Inf, and their printing.                      ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/half.go                  ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// float16 and bfloat16 hold the 16-bit pattern of one element; Go has no
+// arithmetic for them, so elements are decoded to float64 to compute.
+type (
+	float16  uint16
+	bfloat16 uint16
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Float16Bits, BFloat16Bits – Encode a number as a 16-bit float              ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Rounds v to the nearest representable value, ties to even, exactly               ║
+// ║   as IEEE 754 (and NumPy's astype) do, directly from float64 so there              ║
+// ║   is no double rounding through float32:                                           ║
+// ║                                                                                    ║
+// ║     - Float16  : 1 sign, 5 exponent, 10 mantissa bits (IEEE binary16)              ║
+// ║     - BFloat16 : 1 sign, 8 exponent, 7 mantissa bits (float32's range)             ║
+// ║                                                                                    ║
+// ║   - Values beyond the largest finite number become ±Inf                            ║
+// ║   - Values below half the smallest subnormal become ±0                             ║
+// ║   - NaN stays NaN (quiet, keeping the top bits of its payload)                     ║
+// ║                                                                                    ║
+// ║   Returns: uint16 – the bit pattern                                                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Float16Bits(1)         → 0x3c00                                                  ║
+// ║   Float16Bits(65520)     → 0x7c00   (+Inf: the tie rounds to even)                 ║
+// ║   BFloat16Bits(math.Pi)  → 0x4049   (3.140625)                                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Float16Bits(v float64) uint16  { return encodeHalf(v, 5, 10) }
+func BFloat16Bits(v float64) uint16 { return encodeHalf(v, 8, 7) }
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Float16FromBits, BFloat16FromBits – Decode a 16-bit float                  ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   The exact float64 value of a bit pattern, subnormals, ±0, ±Inf and               ║
+// ║   NaN included.                                                                    ║
+// ║                                                                                    ║
+// ║   Returns: float64                                                                 ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Float16FromBits(0x3c01)    → 1.0009765625                                        ║
+// ║   Float16FromBits(0x0001)    → 5.960464477539063e-08  (2^-24)                      ║
+// ║   BFloat16FromBits(0x7f80)   → +Inf                                                ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Float16FromBits(bits uint16) float64  { return decodeHalf(bits, 5, 10) }
+func BFloat16FromBits(bits uint16) float64 { return decodeHalf(bits, 8, 7) }
+
+// encodeHalf rounds v to a 16-bit float with the given exponent and mantissa widths.
+func encodeHalf(v float64, expBits, mantBits uint) uint16 {
+
+	b := math.Float64bits(v)
+	sign := uint16(b>>63) << (expBits + mantBits)
+	exp := int(b>>52) & 0x7ff
+	mant := b & (1<<52 - 1)
+
+	maxExp := 1<<expBits - 1
+	inf := uint16(maxExp) << mantBits
+
+	switch {
+	case exp == 0x7ff && mant == 0:
+		return sign | inf
+	case exp == 0x7ff:
+		return sign | inf | 1<<(mantBits-1) | uint16(mant>>(52-mantBits))
+	case exp == 0:
+		// Zero, or a float64 subnormal: far below any 16-bit subnormal
+		return sign
+	}
+
+	// Biased target exponent; at or below 0 the result is subnormal, which
+	// drops that many more bits
+	e := exp - 1023 + (1<<(expBits-1) - 1)
+	full := mant | 1<<52
+	shift := 52 - mantBits
+	if e <= 0 {
+		shift += uint(1 - e)
+		e = 0
+	}
+	if shift >= 64 {
+		return sign
+	}
+
+	m := full >> shift
+	rem := full & (1<<shift - 1)
+	halfway := uint64(1) << (shift - 1)
+	if rem > halfway || (rem == halfway && m&1 == 1) {
+		m++
+	}
+
+	if e == 0 {
+		// A subnormal that rounded up to 1<<mantBits is the smallest normal,
+		// whose encoding is the same number
+		return sign | uint16(m)
+	}
+	if m == 1<<(mantBits+1) {
+		m >>= 1
+		e++
+	}
+	if e >= maxExp {
+		return sign | inf
+	}
+	return sign | uint16(e)<<mantBits | uint16(m&(1<<mantBits-1))
+}
+
+// decodeHalf is the exact inverse of encodeHalf for every bit pattern.
+func decodeHalf(bits uint16, expBits, mantBits uint) float64 {
+
+	maxExp := 1<<expBits - 1
+	bias := 1<<(expBits-1) - 1
+	e := int(bits>>mantBits) & maxExp
+	m := uint64(bits) & (1<<mantBits - 1)
+
+	var v float64
+	switch e {
+	case maxExp:
+		if m == 0 {
+			v = math.Inf(1)
+		} else {
+			v = math.Float64frombits(0x7ff<<52 | 1<<51 | m<<(52-mantBits))
+		}
+	case 0:
+		v = math.Ldexp(float64(m), 1-bias-int(mantBits))
+	default:
+		v = math.Ldexp(float64(m|1<<mantBits), e-bias-int(mantBits))
+	}
+
+	if bits>>(expBits+mantBits) != 0 {
+		v = math.Copysign(v, -1)
+	}
+	return v
+}
+
+// halfRound rounds v to the precision of half dtype d.
+func halfRound(v float64, d DType) float64 {
+	if d == BFloat16 {
+		return BFloat16FromBits(BFloat16Bits(v))
+	}
+	return Float16FromBits(Float16Bits(v))
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: formatHalves – Print the elements of a 16-bit float buffer                 ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Like %v on a float slice, but each value gets the fewest digits                  ║
+// ║   that still round to the same 16-bit pattern, as NumPy prints them.               ║
+// ║                                                                                    ║
+// ║   Returns: string – e.g. "[0.1 1 -Inf]"                                            ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   float16 0.1 is stored as 0.0999755859375 and printed as 0.1                      ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func formatHalves(buf storage) string {
+
+	d := buf.dtype()
+	parts := make([]string, buf.len())
+	for i := range parts {
+		parts[i] = formatHalf(buf.float(i), d)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// formatHalf prints one element of a 16-bit float type d, see formatHalves.
+func formatHalf(v float64, d DType) string {
+
+	if math.IsNaN(v) || math.IsInf(v, 0) || v == 0 {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	for digits := 1; digits < 17; digits++ {
+		s := strconv.FormatFloat(v, 'g', digits, 64)
+		if back, _ := strconv.ParseFloat(s, 64); halfRound(back, d) == v {
+			return strconv.FormatFloat(back, 'g', -1, 64)
+		}
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,192 @@
+package ndarray_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestFloat16Bits(t *testing.T) {
+	// Reference bit patterns are those of np.float16(v).view(np.uint16)
+	tests := []struct {
+		v    float64
+		bits uint16
+	}{
+		{0, 0x0000},
+		{math.Copysign(0, -1), 0x8000},
+		{1, 0x3c00},
+		{-2, 0xc000},
+		{0.1, 0x2e66},
+		{1.0 / 3, 0x3555},
+		{math.Pi, 0x4248},
+		{65504, 0x7bff},              // largest finite
+		{65519.99, 0x7bff},           // just below the rounding boundary
+		{65520, 0x7c00},              // tie between 65504 and 65536 rounds to even: +Inf
+		{1e10, 0x7c00},               // overflow
+		{-1e10, 0xfc00},              // negative overflow
+		{math.Ldexp(1, -14), 0x0400}, // smallest normal
+		{math.Ldexp(1, -24), 0x0001}, // smallest subnormal
+		{math.Ldexp(1, -25), 0x0000}, // tie with zero rounds to even
+		{math.Ldexp(1.0000001, -25), 0x0001},
+		{math.Ldexp(3, -26), 0x0001},
+		{math.Ldexp(3, -25), 0x0002},    // 1.5 ulp ties to even
+		{math.Ldexp(1023, -24), 0x03ff}, // largest subnormal
+		{math.Ldexp(2047, -25), 0x0400}, // rounds up into the normal range
+		{1e-8, 0x0000},
+		{1 + math.Ldexp(1, -11), 0x3c00},   // tie rounds down to even
+		{1 + math.Ldexp(3, -11), 0x3c02},   // tie rounds up to even
+		{1 + math.Ldexp(1.5, -11), 0x3c01}, // above the tie
+		{2049, 0x6800},
+		{2051, 0x6802},
+		{math.Inf(1), 0x7c00},
+		{math.Inf(-1), 0xfc00},
+		{math.NaN(), 0x7e00},
+	}
+
+	for _, tt := range tests {
+		if got := ndarray.Float16Bits(tt.v); got != tt.bits {
+			t.Errorf("Float16Bits(%v) = %#04x, want %#04x", tt.v, got, tt.bits)
+		}
+	}
+}
+
+func TestBFloat16Bits(t *testing.T) {
+	// Reference bit patterns are those of ml_dtypes.bfloat16(v).view(np.uint16)
+	tests := []struct {
+		v    float64
+		bits uint16
+	}{
+		{0, 0x0000},
+		{math.Copysign(0, -1), 0x8000},
+		{1, 0x3f80},
+		{-2, 0xc000},
+		{0.1, 0x3dcd},
+		{math.Pi, 0x4049},
+		{1 + math.Ldexp(1, -8), 0x3f80}, // tie rounds down to even
+		{1 + math.Ldexp(3, -8), 0x3f82}, // tie rounds up to even
+		{3.3895313892515355e38, 0x7f7f}, // largest finite
+		{math.MaxFloat32, 0x7f80},       // rounds past the largest finite: +Inf
+		{math.Ldexp(1, -126), 0x0080},   // smallest normal
+		{math.Ldexp(1, -133), 0x0001},   // smallest subnormal
+		{math.Ldexp(1, -134), 0x0000},
+		{1e39, 0x7f80},
+		{math.Inf(-1), 0xff80},
+		{math.NaN(), 0x7fc0},
+	}
+
+	for _, tt := range tests {
+		if got := ndarray.BFloat16Bits(tt.v); got != tt.bits {
+			t.Errorf("BFloat16Bits(%v) = %#04x, want %#04x", tt.v, got, tt.bits)
+		}
+	}
+}
+
+func TestHalfFromBits(t *testing.T) {
+	tests := []struct {
+		decode func(uint16) float64
+		bits   uint16
+		want   float64
+	}{
+		{ndarray.Float16FromBits, 0x3c01, 1.0009765625},
+		{ndarray.Float16FromBits, 0x0001, math.Ldexp(1, -24)},
+		{ndarray.Float16FromBits, 0x03ff, math.Ldexp(1023, -24)},
+		{ndarray.Float16FromBits, 0x7bff, 65504},
+		{ndarray.Float16FromBits, 0xc000, -2},
+		{ndarray.Float16FromBits, 0xfc00, math.Inf(-1)},
+		{ndarray.BFloat16FromBits, 0x4049, 3.140625},
+		{ndarray.BFloat16FromBits, 0x7f7f, 3.3895313892515355e38},
+		{ndarray.BFloat16FromBits, 0x0001, math.Ldexp(1, -133)},
+		{ndarray.BFloat16FromBits, 0x7f80, math.Inf(1)},
+	}
+	for _, tt := range tests {
+		if got := tt.decode(tt.bits); got != tt.want {
+			t.Errorf("decode(%#04x) = %v, want %v", tt.bits, got, tt.want)
+		}
+	}
+
+	if v := ndarray.Float16FromBits(0x7e00); !math.IsNaN(v) {
+		t.Errorf("expected NaN, got %v", v)
+	}
+	if v := ndarray.Float16FromBits(0x8000); v != 0 || !math.Signbit(v) {
+		t.Errorf("expected -0, got %v", v)
+	}
+
+	// Every finite pattern survives a round trip
+	for b := 0; b < 1<<16; b++ {
+		bits := uint16(b)
+		if v := ndarray.Float16FromBits(bits); !math.IsNaN(v) && ndarray.Float16Bits(v) != bits {
+			t.Fatalf("float16 round trip of %#04x gave %#04x", bits, ndarray.Float16Bits(v))
+		}
+		if v := ndarray.BFloat16FromBits(bits); !math.IsNaN(v) && ndarray.BFloat16Bits(v) != bits {
+			t.Fatalf("bfloat16 round trip of %#04x gave %#04x", bits, ndarray.BFloat16Bits(v))
+		}
+	}
+}
+
+func TestHalfArrays(t *testing.T) {
+	a, err := typed(t, 0.1, 1, 65520, -1e-8, math.NaN()).AsType(ndarray.Float16)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := a.String(), "NDArray(shape=[5], data=[0.1 1 +Inf -0 NaN])"; got != want || a.DType() != ndarray.Float16 {
+		t.Errorf("astype float16: got %s (%s), want %s", got, a.DType(), want)
+	}
+	if a.DType().ItemSize() != 2 || a.Nbytes() != 10 {
+		t.Errorf("got item size %d, nbytes %d", a.DType().ItemSize(), a.Nbytes())
+	}
+	if v, _ := a.Value(0); v != float32(0.0999755859375) {
+		t.Errorf("value: got %v, want the float32 0.0999755859375", v)
+	}
+
+	b, _ := typed[float32](t, math.Pi, 1e38).AsType(ndarray.BFloat16)
+	if got, want := b.String(), "NDArray(shape=[2], data=[3.14 1e+38])"; got != want {
+		t.Errorf("astype bfloat16: got %s, want %s", got, want)
+	}
+	back, _ := b.AsType(ndarray.Float64)
+	if got, want := back.String(), "NDArray(shape=[2], data=[3.140625 9.969209968386869e+37])"; got != want {
+		t.Errorf("back to float64: got %s, want %s", got, want)
+	}
+
+	// Arithmetic stays in the 16-bit type, computed in float32
+	x, _ := typed(t, 1.0, 2048, 65504).AsType(ndarray.Float16)
+	y, _ := typed(t, math.Ldexp(1, -11), 1, 32).AsType(ndarray.Float16)
+	sum, err := ndarray.Add(x, y)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := sum.String(), "NDArray(shape=[3], data=[1 2048 +Inf])"; got != want || sum.DType() != ndarray.Float16 {
+		t.Errorf("float16 add: got %s (%s), want %s", got, sum.DType(), want)
+	}
+
+	tests := []struct {
+		a, b ndarray.DType
+		want ndarray.DType
+	}{
+		{ndarray.Float16, ndarray.Float16, ndarray.Float16},
+		{ndarray.Float16, ndarray.Uint8, ndarray.Float16},
+		{ndarray.BFloat16, ndarray.Int8, ndarray.BFloat16},
+		{ndarray.Float16, ndarray.Int16, ndarray.Float32},
+		{ndarray.Float16, ndarray.BFloat16, ndarray.Float32},
+		{ndarray.BFloat16, ndarray.Float32, ndarray.Float32},
+		{ndarray.Float16, ndarray.Float64, ndarray.Float64},
+		{ndarray.Float16, ndarray.Complex64, ndarray.Complex64},
+	}
+	for _, tt := range tests {
+		if got, err := ndarray.ResultType(tt.a, tt.b); err != nil || got != tt.want {
+			t.Errorf("ResultType(%s, %s) = %s, %v, want %s", tt.a, tt.b, got, err, tt.want)
+		}
+	}
+	if ndarray.CanCast(ndarray.Float16, ndarray.BFloat16, ndarray.CastingSafe) {
+		t.Errorf("float16 should not cast safely to bfloat16")
+	}
+
+	s, _ := x.Sum()
+	if s.DType() != ndarray.Float16 {
+		t.Errorf("sum: got %s, want float16", s.DType())
+	}
+	m, _ := ndarray.Greater(x, y)
+	if got, want := m.String(), "NDArray(shape=[3], data=[true true true])"; got != want {
+		t.Errorf("greater: got %s, want %s", got, want)
+	}
+}
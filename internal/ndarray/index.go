@@ -0,0 +1,720 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║     ██╗███╗   ██╗██████╗ ███████╗██╗  ██╗                                          ║
+// ║     ██║████╗  ██║██╔══██╗██╔════╝╚██╗██╔╝                                          ║
+// ║     ██║██╔██╗ ██║██║  ██║█████╗   ╚███╔╝                                           ║
+// ║     ██║██║╚██╗██║██║  ██║██╔══╝   ██╔██╗                                           ║
+// ║     ██║██║ ╚████║██████╔╝███████╗██╔╝ ██╗                                          ║
+// ║     ╚═╝╚═╝  ╚═══╝╚═════╝ ╚══════╝╚═╝  ╚═╝                                          ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Indexing engine for NDArray: basic slicing with ranges, steps,                    ║
+// ║  new axes and ellipsis, always returning strided views.                            ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/index.go                 ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"fmt"
+	"math"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   STRUCT: Index – One entry of a NumPy-style subscript                             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   A subscript such as `a[1, 2:8:2, np.newaxis, ...]` is a list of entries,         ║
+// ║   each of them one of:                                                             ║
+// ║                                                                                    ║
+// ║     - integer   : At(i)               → picks one position, drops the axis         ║
+// ║     - range     : Span(start, stop)   → start:stop:step, keeps the axis            ║
+// ║     - new axis  : NewAxis             → inserts an axis of length 1                ║
+// ║     - ellipsis  : Ellipsis            → as many `:` as needed                      ║
+// ║     - int array : Indices(arr), List(i, j, ...) → advanced indexing,               ║
+// ║                   only accepted by Gather / Scatter (see fancy.go)                 ║
+// ║                                                                                    ║
+// ║   Build them with the helpers below, the zero value is not meaningful.             ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   PYTHON → GO:                                                                     ║
+// ║                                                                                    ║
+// ║   a[:, 1:5:2, ...]   → a.Slice(All, Span(1, 5, 2), Ellipsis)                       ║
+// ║   a[-1]              → a.Slice(At(-1))                                             ║
+// ║   a[::-1]            → a.Slice(Span(None, None, -1))                               ║
+// ║   a[np.newaxis, 3:]  → a.Slice(NewAxis, Span(3, None))                             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type Index struct {
+	kind  indexKind
+	start int
+	stop  int
+	step  int
+	array *NDArray
+}
+
+type indexKind int
+
+const (
+	indexInt indexKind = iota + 1
+	indexRange
+	indexNewAxis
+	indexEllipsis
+	indexArray
+)
+
+// None marks an omitted bound in Span, like leaving it empty in `start:stop`.
+const None = math.MinInt
+
+var (
+	// All selects a whole axis, the `:` of a Python subscript.
+	All = Index{kind: indexRange, start: None, stop: None, step: 1}
+
+	// NewAxis inserts a new axis of length 1, like `np.newaxis`.
+	NewAxis = Index{kind: indexNewAxis}
+
+	// Ellipsis expands to as many `:` entries as needed, like `...`.
+	Ellipsis = Index{kind: indexEllipsis}
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: At – Integer subscript entry                                               ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Selects a single position along an axis and removes that axis from               ║
+// ║   the result. Negative values count from the end.                                  ║
+// ║                                                                                    ║
+// ║   Returns: Index                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a.Slice(At(-1)) → last row of a 2-D array, as a 1-D view                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func At(i int) Index {
+	return Index{kind: indexInt, start: i}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Span – Range subscript entry (start:stop:step)                             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Follows Python slice semantics exactly:                                          ║
+// ║                                                                                    ║
+// ║   - `None` leaves a bound open (its default depends on the step sign)              ║
+// ║   - Negative bounds count from the end                                             ║
+// ║   - Out-of-range bounds are clipped, never an error                                ║
+// ║   - step defaults to 1 and may be negative, but not zero                           ║
+// ║                                                                                    ║
+// ║   Returns: Index                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Span(1, 5, 2)        → 1:5:2                                                     ║
+// ║   Span(None, -1)       → :-1                                                       ║
+// ║   Span(None, None, -1) → ::-1                                                      ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Span(start, stop int, step ...int) Index {
+	s := 1
+	if len(step) > 0 {
+		s = step[0]
+	}
+	return Index{kind: indexRange, start: start, stop: stop, step: s}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Slice – Basic indexing returning a strided view                            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Applies a NumPy basic subscript to the array without copying:                    ║
+// ║                                                                                    ║
+// ║   - Integers move the offset and drop their axis                                   ║
+// ║   - Ranges scale the stride by `step` and shrink the axis                          ║
+// ║   - NewAxis adds a length-1 axis with stride 0                                     ║
+// ║   - A single Ellipsis (or the end of the list) fills remaining axes with `:`       ║
+// ║                                                                                    ║
+// ║   The result shares data with `a`, writes through it are visible in `a`.           ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a.Shape()                          → [4, 10, 3]                                  ║
+// ║   v, _ := a.Slice(All, Span(1, 5, 2), Ellipsis)                                    ║
+// ║   v.Shape()                          → [4, 2, 3]                                   ║
+// ║   v.Strides()                        → [30, 6, 1]                                  ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Slice(indices ...Index) (*NDArray, error) {
+
+	for _, idx := range indices {
+		if idx.kind == indexArray {
+			return nil, fmt.Errorf("index arrays are not basic indices, use Gather or Scatter")
+		}
+	}
+
+	expanded, err := expandEllipsis(indices, len(a.shape))
+	if err != nil {
+		return nil, err
+	}
+
+	shape := make([]int, 0, len(expanded))
+	strides := make([]int, 0, len(expanded))
+	offset := a.offset
+	axis := 0
+	for _, idx := range expanded {
+		switch idx.kind {
+		case indexInt:
+			i := idx.start
+			if i < 0 {
+				i += a.shape[axis]
+			}
+			if i < 0 || i >= a.shape[axis] {
+				return nil, fmt.Errorf("index %d is out of bounds for axis %d with size %d", idx.start, axis, a.shape[axis])
+			}
+			offset += i * a.strides[axis]
+			axis++
+
+		case indexRange:
+			start, step, length, err := idx.bounds(a.shape[axis])
+			if err != nil {
+				return nil, err
+			}
+			if length > 0 {
+				offset += start * a.strides[axis]
+			}
+			shape = append(shape, length)
+			strides = append(strides, a.strides[axis]*step)
+			axis++
+
+		case indexNewAxis:
+			shape = append(shape, 1)
+			strides = append(strides, 0)
+		}
+	}
+
+	if len(shape) > 32 {
+		return nil, fmt.Errorf("shape has too many dimensions (max 32)")
+	}
+
+	return a.view(shape, strides, offset), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: expandEllipsis – Give every axis of the array an entry                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Replaces the Ellipsis (or, without one, pads the end) with as many               ║
+// ║   All entries as there are axes not consumed by the other entries.                 ║
+// ║   Integers, ranges and index arrays consume one axis; NewAxis none.                ║
+// ║                                                                                    ║
+// ║   Returns: ([]Index, error) – error on a second ellipsis or on more                ║
+// ║            indices than dimensions                                                 ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE (3-D array):                                                             ║
+// ║   [At(0), Ellipsis]          → [At(0), All, All]                                   ║
+// ║   [NewAxis, Span(1, None)]   → [NewAxis, Span(1, None), All, All]                  ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func expandEllipsis(indices []Index, ndim int) ([]Index, error) {
+
+	consumed := 0
+	ellipsis := -1
+	for i, idx := range indices {
+		switch idx.kind {
+		case indexInt, indexRange, indexArray:
+			consumed++
+		case indexNewAxis:
+		case indexEllipsis:
+			if ellipsis >= 0 {
+				return nil, fmt.Errorf("an index can only have a single ellipsis ('...')")
+			}
+			ellipsis = i
+		default:
+			return nil, fmt.Errorf("invalid index at position %d", i)
+		}
+	}
+
+	if consumed > ndim {
+		return nil, fmt.Errorf("too many indices for array: array is %d-dimensional, but %d were indexed", ndim, consumed)
+	}
+
+	fill := ndim - consumed
+	expanded := make([]Index, 0, len(indices)+fill)
+	for i, idx := range indices {
+		if i == ellipsis {
+			for k := 0; k < fill; k++ {
+				expanded = append(expanded, All)
+			}
+			continue
+		}
+		expanded = append(expanded, idx)
+	}
+	if ellipsis < 0 {
+		for k := 0; k < fill; k++ {
+			expanded = append(expanded, All)
+		}
+	}
+	return expanded, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: bounds – Resolve a range entry against an axis length                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Same algorithm as Python's `slice.indices(n)`, plus the number of                ║
+// ║   selected positions.                                                              ║
+// ║                                                                                    ║
+// ║   Returns: (start, step, length int, err error)                                    ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Span(None, None, -1).bounds(5) → start 4, step -1, length 5                      ║
+// ║   Span(1, 100, 3).bounds(5)      → start 1, step 3,  length 2                      ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (idx Index) bounds(n int) (int, int, int, error) {
+
+	step := idx.step
+	if step == 0 {
+		return 0, 0, 0, fmt.Errorf("slice step cannot be zero")
+	}
+
+	lower, upper := 0, n
+	if step < 0 {
+		lower, upper = -1, n-1
+	}
+
+	clip := func(bound, def int) int {
+		if bound == None {
+			return def
+		}
+		if bound < 0 {
+			bound += n
+			if bound < lower {
+				bound = lower
+			}
+		} else if bound > upper {
+			bound = upper
+		}
+		return bound
+	}
+
+	var start, stop int
+	if step > 0 {
+		start, stop = clip(idx.start, lower), clip(idx.stop, upper)
+	} else {
+		start, stop = clip(idx.start, upper), clip(idx.stop, lower)
+	}
+
+	length := 0
+	if step > 0 && stop > start {
+		length = (stop-start-1)/step + 1
+	} else if step < 0 && start > stop {
+		length = (start-stop-1)/(-step) + 1
+	}
+
+	return start, step, length, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: IndexMode – What to do with out-of-range integer indices                   ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Same choices as the `mode=` argument of `np.take` / `np.put`:                    ║
+// ║                                                                                    ║
+// ║     - ModeRaise : negative indices count from the end, anything else               ║
+// ║                   outside [-n, n) is an error (the default everywhere)             ║
+// ║     - ModeWrap  : indices wrap around modulo n, so -1 → n-1 and n → 0              ║
+// ║     - ModeClip  : indices are clamped to [0, n-1]; as in NumPy this                ║
+// ║                   disables negative indexing (-1 → 0)                              ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE (axis of size 4):                                                        ║
+// ║                                                                                    ║
+// ║   index   ModeRaise   ModeWrap   ModeClip                                          ║
+// ║     -1        3          3          0                                              ║
+// ║      5      error        1          3                                              ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type IndexMode int
+
+const (
+	ModeRaise IndexMode = iota
+	ModeWrap
+	ModeClip
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: resolveIndex – Map an index onto [0, n) according to a mode                ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Shared by Get, Set, Take and Put so that every accessor agrees on what           ║
+// ║   `-1` or `n` means. Callers format their own error message.                       ║
+// ║                                                                                    ║
+// ║   Returns: (int, bool) – false when ModeRaise rejects the index                    ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func resolveIndex(idx, n int, mode IndexMode) (int, bool) {
+
+	switch mode {
+	case ModeWrap:
+		if n == 0 {
+			return 0, false
+		}
+		return ((idx % n) + n) % n, true
+
+	case ModeClip:
+		if n == 0 {
+			return 0, false
+		}
+		if idx < 0 {
+			return 0, true
+		}
+		if idx >= n {
+			return n - 1, true
+		}
+		return idx, true
+
+	default:
+		if idx < 0 {
+			idx += n
+		}
+		return idx, idx >= 0 && idx < n
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: GetMode – Read a value with an explicit index mode                         ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Same as Get, but out-of-range indices are handled by `mode`.                     ║
+// ║                                                                                    ║
+// ║   - ModeRaise behaves exactly like Get                                             ║
+// ║   - ModeWrap / ModeClip never fail on bounds                                       ║
+// ║                                                                                    ║
+// ║   Returns: (float64, error)                                                        ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a.Shape() → [3, 4]                                                               ║
+// ║   a.GetMode(ModeWrap, 4, -1) → a[1][3]                                             ║
+// ║   a.GetMode(ModeClip, 9, 9)  → a[2][3]                                             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) GetMode(mode IndexMode, indices ...int) (float64, error) {
+
+	if len(indices) != len(a.shape) {
+		return 0, fmt.Errorf("number of indices (%d) does not match array dimensions (%d)", len(indices), len(a.shape))
+	}
+
+	// Calculate the flat index from the multi-dimensional indices
+	flatIndex := a.offset
+	for i, index := range indices {
+		resolved, ok := resolveIndex(index, a.shape[i], mode)
+		if !ok {
+			return 0, fmt.Errorf("index %d out of bounds for axis %d with size %d", index, i, a.shape[i])
+		}
+		flatIndex += resolved * a.strides[i]
+	}
+
+	// Check if the flat index is within bounds
+	if flatIndex < 0 || flatIndex >= a.data.len() {
+		return 0, fmt.Errorf("flat index %d out of bounds for array of size %d", flatIndex, a.data.len())
+	}
+
+	// Return the value at the calculated index
+	return a.realAt(flatIndex)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: SetMode – Write a value with an explicit index mode                        ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Same as Set, but out-of-range indices are handled by `mode`.                     ║
+// ║                                                                                    ║
+// ║   Returns: error                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a.SetMode(1.0, ModeWrap, -1, 4) → sets a[2][0] on a [3, 4] array                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) SetMode(value float64, mode IndexMode, indices ...int) error {
+
+	if len(indices) != len(a.shape) {
+		return fmt.Errorf("number of indices (%d) does not match array dimensions (%d)", len(indices), len(a.shape))
+	}
+
+	if !a.flags.Writeable {
+		return fmt.Errorf("assignment destination is read-only")
+	}
+
+	// Bounds checking and index calculation
+	offset := a.offset
+	for i, idx := range indices {
+		resolved, ok := resolveIndex(idx, a.shape[i], mode)
+		if !ok {
+			return fmt.Errorf("index %d out of bounds for axis %d (size %d)", idx, i, a.shape[i])
+		}
+		offset += resolved * a.strides[i]
+	}
+
+	// Set the value
+	a.data.setFloat(offset, value)
+	return nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Take – Gather elements by position                                         ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.take(a, indices, axis=axis, mode=mode)`.                       ║
+// ║                                                                                    ║
+// ║   - Without axis the array is treated as flat (row-major order)                    ║
+// ║   - With an axis (negative allowed), whole sub-arrays are picked along it          ║
+// ║   - Indices may repeat and come in any order                                       ║
+// ║   - Always returns a new array, never a view                                       ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a = [[0 1 2] [3 4 5]]                                                            ║
+// ║   a.Take([]int{-1, 0}, ModeRaise)    → [5 0]                                       ║
+// ║   a.Take([]int{2, 2}, ModeRaise, 1)  → [[2 2] [5 5]]                               ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Take(indices []int, mode IndexMode, axis ...int) (*NDArray, error) {
+
+	src := a
+	ax := 0
+	if len(axis) == 0 {
+		flat, err := a.Reshape(-1)
+		if err != nil {
+			return nil, err
+		}
+		src = flat
+	} else {
+		resolved, err := normalizeAxis(axis[0], len(a.shape))
+		if err != nil {
+			return nil, err
+		}
+		ax = resolved
+	}
+
+	n := src.shape[ax]
+	positions := make([]int, len(indices))
+	for i, idx := range indices {
+		resolved, ok := resolveIndex(idx, n, mode)
+		if !ok {
+			return nil, fmt.Errorf("index %d out of bounds for axis %d with size %d", idx, ax, n)
+		}
+		positions[i] = resolved
+	}
+
+	// Result shape: the indexed axis is replaced by len(indices)
+	shape := append([]int(nil), src.shape...)
+	shape[ax] = len(indices)
+	data := src.data.fresh(shapeSize(shape))
+
+	if shapeSize(shape) > 0 {
+		index := make([]int, len(shape))
+		for n := 0; ; n++ {
+			offset := src.offset
+			for i, idx := range index {
+				if i == ax {
+					idx = positions[idx]
+				}
+				offset += idx * src.strides[i]
+			}
+			data.copyFrom(n, src.data, offset)
+
+			if !nextIndex(index, shape) {
+				break
+			}
+		}
+	}
+
+	return newStorage(data, shape, cStrides(shape)), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Put – Scatter values by flat position                                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.put(a, indices, values, mode=mode)`.                           ║
+// ║                                                                                    ║
+// ║   - Indices address the array as if it were flattened in row-major order           ║
+// ║   - Values are recycled when shorter than indices                                  ║
+// ║   - Works in place, including on views (writes go through to the base)             ║
+// ║                                                                                    ║
+// ║   Returns: error                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a = [[0 0 0] [0 0 0]]                                                            ║
+// ║   a.Put([]int{0, -1}, []float64{7, 9}, ModeRaise)                                  ║
+// ║   a = [[7 0 0] [0 0 9]]                                                            ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Put(indices []int, values []float64, mode IndexMode) error {
+
+	if !a.flags.Writeable {
+		return fmt.Errorf("assignment destination is read-only")
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	size := a.Size()
+	offsets := make([]int, len(indices))
+	for i, idx := range indices {
+		resolved, ok := resolveIndex(idx, size, mode)
+		if !ok {
+			return fmt.Errorf("index %d out of bounds for axis 0 (size %d)", idx, size)
+		}
+		offsets[i] = flatOffset(a, resolved)
+	}
+
+	for i, offset := range offsets {
+		a.data.setFloat(offset, values[i%len(values)])
+	}
+	return nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Mask – Select elements with a boolean mask                                 ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a[m]` for a boolean array `m`.                                    ║
+// ║                                                                                    ║
+// ║   - `m` must be a Bool array whose shape matches the leading axes of `a`           ║
+// ║   - Selected elements (or sub-arrays, when m has fewer dimensions) are             ║
+// ║     collected in row-major order of the mask                                       ║
+// ║   - The result is a copy of shape [count] + a.shape[m.ndim:]                       ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a = [[1 -2] [-3 4]]                                                              ║
+// ║   m, _ := Greater(a, Scalar(0))                                                    ║
+// ║   a.Mask(m)           → [1 4]                                                      ║
+// ║   a.Mask([true false]) → [[1 -2]]                                                  ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Mask(m *NDArray) (*NDArray, error) {
+
+	offsets, err := a.maskOffsets(m)
+	if err != nil {
+		return nil, err
+	}
+
+	trailShape, trailStrides := a.shape[len(m.shape):], a.strides[len(m.shape):]
+	out := allocOf(append([]int{len(offsets)}, trailShape...), a.dtype)
+	for j, offset := range offsets {
+		dst := out.view(trailShape, out.strides[1:], j*out.strides[0])
+		if err := assign(dst, a.view(trailShape, trailStrides, offset)); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: SetMask – Assign to the elements selected by a boolean mask                ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a[m] = values`.                                                   ║
+// ║                                                                                    ║
+// ║   - Same mask rules as Mask                                                        ║
+// ║   - `values` is broadcast to the shape a.Mask(m) would have, so a                  ║
+// ║     Scalar sets every selected element and an array with one entry per             ║
+// ║     selected element assigns them in order                                         ║
+// ║   - Works in place, including on views                                             ║
+// ║                                                                                    ║
+// ║   Returns: error                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   m, _ := Less(a, Scalar(0))                                                       ║
+// ║   a.SetMask(m, Scalar(0))      → negatives clipped to 0                            ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) SetMask(m, values *NDArray) error {
+
+	if !a.flags.Writeable {
+		return fmt.Errorf("assignment destination is read-only")
+	}
+
+	offsets, err := a.maskOffsets(m)
+	if err != nil {
+		return err
+	}
+
+	trailShape, trailStrides := a.shape[len(m.shape):], a.strides[len(m.shape):]
+	target := append([]int{len(offsets)}, trailShape...)
+	src, err := BroadcastTo(values, target...)
+	if err != nil {
+		return fmt.Errorf("NumPy boolean array indexing assignment cannot assign %v input values to the %d output values where the mask is true",
+			values.shape, shapeSize(target))
+	}
+	if sharesMemory(a, values) {
+		src = src.Copy()
+	}
+
+	for j, offset := range offsets {
+		s := src.view(trailShape, src.strides[1:], src.offset+j*src.strides[0])
+		if err := assign(a.view(trailShape, trailStrides, offset), s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maskOffsets validates a boolean mask against a's leading axes and returns the data
+// offsets of the selected positions in row-major order.
+func (a *NDArray) maskOffsets(m *NDArray) ([]int, error) {
+
+	if m.dtype != Bool {
+		return nil, fmt.Errorf("mask must be a boolean array, got dtype %s", m.dtype)
+	}
+	if len(m.shape) > len(a.shape) {
+		return nil, fmt.Errorf("too many indices for array: array is %d-dimensional, but %d were indexed",
+			len(a.shape), len(m.shape))
+	}
+	for axis, dim := range m.shape {
+		if dim != a.shape[axis] {
+			return nil, fmt.Errorf("boolean index did not match indexed array along axis %d; size of axis is %d but size of corresponding boolean axis is %d",
+				axis, a.shape[axis], dim)
+		}
+	}
+
+	offsets := []int{}
+	index := make([]int, len(m.shape))
+	for _, v := range gatherData(m) {
+		if v != 0 {
+			offset := a.offset
+			for k, i := range index {
+				offset += i * a.strides[k]
+			}
+			offsets = append(offsets, offset)
+		}
+		nextIndex(index, m.shape)
+	}
+	return offsets, nil
+}
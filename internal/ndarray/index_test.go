@@ -0,0 +1,233 @@
+package ndarray_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestSliceRangeAndEllipsis(t *testing.T) {
+	a := seq(t, 4, 10, 3)
+
+	v, err := a.Slice(ndarray.All, ndarray.Span(1, 5, 2), ndarray.Ellipsis)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fmt.Sprint(v.Shape()) != "[4 2 3]" {
+		t.Errorf("unexpected shape: got %v", v.Shape())
+	}
+
+	// v[1, 1, 2] is a[1, 3, 2] = 1*30 + 3*3 + 2
+	val, _ := v.Get(1, 1, 2)
+	if val != 41 {
+		t.Errorf("expected 41, got %f", val)
+	}
+
+	if v.Base() != a.Base() || v.Flags().CContiguous {
+		t.Error("expected a non-contiguous view sharing the original data")
+	}
+}
+
+func TestSliceNegativeStepAndInteger(t *testing.T) {
+	a := seq(t, 3, 4)
+
+	row, err := a.Slice(ndarray.At(-1), ndarray.Span(ndarray.None, ndarray.None, -1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := row.String(); got != "NDArray(shape=[4], data=[11 10 9 8])" {
+		t.Errorf("unexpected reversed row: %s", got)
+	}
+
+	if err := row.Set(-5, 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if val, _ := a.Get(2, 3); val != -5 {
+		t.Errorf("expected write through view, got %f", val)
+	}
+}
+
+func TestSliceNewAxis(t *testing.T) {
+	a := seq(t, 3)
+
+	v, err := a.Slice(ndarray.NewAxis, ndarray.All, ndarray.NewAxis)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fmt.Sprint(v.Shape()) != "[1 3 1]" {
+		t.Errorf("unexpected shape: got %v", v.Shape())
+	}
+}
+
+func TestSliceErrors(t *testing.T) {
+	a := seq(t, 2, 2)
+
+	if _, err := a.Slice(ndarray.At(0), ndarray.At(0), ndarray.At(0)); err == nil {
+		t.Error("expected too-many-indices error, got nil")
+	}
+
+	if _, err := a.Slice(ndarray.At(2)); err == nil {
+		t.Error("expected out-of-bounds error, got nil")
+	}
+
+	if _, err := a.Slice(ndarray.Span(0, 2, 0)); err == nil {
+		t.Error("expected zero-step error, got nil")
+	}
+
+	if _, err := a.Slice(ndarray.Ellipsis, ndarray.Ellipsis); err == nil {
+		t.Error("expected double-ellipsis error, got nil")
+	}
+}
+
+func TestGetSetNegativeIndex(t *testing.T) {
+	a := seq(t, 3, 4)
+
+	val, err := a.Get(-1, -2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if val != 10 {
+		t.Errorf("expected 10, got %f", val)
+	}
+
+	if err := a.Set(-1, -3, 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if val, _ := a.Get(0, 0); val != -1 {
+		t.Errorf("expected -1 at [0, 0], got %f", val)
+	}
+
+	if _, err := a.Get(-4, 0); err == nil {
+		t.Error("expected out-of-bounds error, got nil")
+	}
+}
+
+func TestIndexModes(t *testing.T) {
+	a := seq(t, 3, 4)
+
+	tests := []struct {
+		mode    ndarray.IndexMode
+		indices []int
+		want    float64
+	}{
+		{ndarray.ModeWrap, []int{4, -1}, 7},
+		{ndarray.ModeWrap, []int{-4, 9}, 9},
+		{ndarray.ModeClip, []int{9, 9}, 11},
+		{ndarray.ModeClip, []int{-1, 2}, 2},
+	}
+
+	for _, tt := range tests {
+		got, err := a.GetMode(tt.mode, tt.indices...)
+		if err != nil {
+			t.Fatalf("GetMode(%v, %v) failed: %v", tt.mode, tt.indices, err)
+		}
+		if got != tt.want {
+			t.Errorf("GetMode(%v, %v) = %f, want %f", tt.mode, tt.indices, got, tt.want)
+		}
+	}
+
+	if err := a.SetMode(100, ndarray.ModeWrap, 3, 4); err != nil {
+		t.Fatalf("SetMode failed: %v", err)
+	}
+	if val, _ := a.Get(0, 0); val != 100 {
+		t.Errorf("expected wrapped write at [0, 0], got %f", val)
+	}
+}
+
+func TestTakePut(t *testing.T) {
+	a := seq(t, 2, 3)
+
+	flat, err := a.Take([]int{-1, 0, 7}, ndarray.ModeWrap)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := flat.String(); got != "NDArray(shape=[3], data=[5 0 1])" {
+		t.Errorf("unexpected flat take: %s", got)
+	}
+
+	cols, err := a.Take([]int{2, 2, 0}, ndarray.ModeRaise, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cols.String(); got != "NDArray(shape=[2 3], data=[2 2 0 5 5 3])" {
+		t.Errorf("unexpected axis take: %s", got)
+	}
+
+	if _, err := a.Take([]int{6}, ndarray.ModeRaise); err == nil {
+		t.Error("expected out-of-bounds error, got nil")
+	}
+
+	col, _ := a.Slice(ndarray.All, ndarray.At(1))
+	if err := col.Put([]int{0, -1}, []float64{-7, -9}, ndarray.ModeRaise); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if got := a.String(); got != "NDArray(shape=[2 3], data=[0 -7 2 3 -9 5])" {
+		t.Errorf("unexpected array after put through view: %s", got)
+	}
+}
+
+func TestMask(t *testing.T) {
+	a, _ := ndarray.FromNested([][]float64{{1, -2}, {-3, 4}})
+
+	pos, _ := ndarray.Greater(a, ndarray.Scalar(0))
+	sel, err := a.Mask(pos)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := sel.String(); got != "NDArray(shape=[2], data=[1 4])" {
+		t.Errorf("unexpected masked elements: %s", got)
+	}
+
+	// A mask over the first axis selects whole rows
+	rowMask, _ := ndarray.FromNested([]bool{false, true})
+	rows, _ := a.Mask(rowMask)
+	if got := rows.String(); got != "NDArray(shape=[1 2], data=[-3 4])" {
+		t.Errorf("unexpected masked rows: %s", got)
+	}
+
+	// Selection follows the C order of the (transposed) view
+	at := a.T()
+	posT, _ := ndarray.Greater(at, ndarray.Scalar(-3))
+	if got, _ := at.Mask(posT); got.String() != "NDArray(shape=[3], data=[1 -2 4])" {
+		t.Errorf("unexpected masked transpose: %s", got)
+	}
+
+	if _, err := a.Mask(a); err == nil {
+		t.Error("expected error for a non-bool mask, got nil")
+	}
+	wrong, _ := ndarray.Greater(seq(t, 3), ndarray.Scalar(0))
+	if _, err := a.Mask(wrong); err == nil {
+		t.Error("expected shape mismatch error, got nil")
+	}
+}
+
+func TestSetMask(t *testing.T) {
+	a, _ := ndarray.FromNested([][]float64{{1, -2}, {-3, 4}})
+
+	neg, _ := ndarray.Less(a, ndarray.Scalar(0))
+	if err := a.SetMask(neg, ndarray.Scalar(0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := a.String(); got != "NDArray(shape=[2 2], data=[1 0 0 4])" {
+		t.Errorf("unexpected result of scalar SetMask: %s", got)
+	}
+
+	// One value per selected element, through a view
+	col, _ := a.Slice(ndarray.All, ndarray.At(1))
+	all, _ := ndarray.GreaterEqual(col, ndarray.Scalar(0))
+	vals, _ := ndarray.FromSlice([]float64{7, 8})
+	if err := col.SetMask(all, vals); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := a.String(); got != "NDArray(shape=[2 2], data=[1 7 0 8])" {
+		t.Errorf("unexpected result of SetMask on a view: %s", got)
+	}
+
+	if err := a.SetMask(neg, seq(t, 3)); err == nil {
+		t.Error("expected error for values that do not broadcast, got nil")
+	}
+}
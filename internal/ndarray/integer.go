@@ -0,0 +1,407 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║     ██╗███╗   ██╗████████╗███████╗ ██████╗ ███████╗██████╗                         ║
+// ║     ██║████╗  ██║╚══██╔══╝██╔════╝██╔════╝ ██╔════╝██╔══██╗                        ║
+// ║     ██║██╔██╗ ██║   ██║   █████╗  ██║  ███╗█████╗  ██████╔╝                        ║
+// ║     ██║██║╚██╗██║   ██║   ██╔══╝  ██║   ██║██╔══╝  ██╔══██╗                        ║
+// ║     ██║██║ ╚████║   ██║   ███████╗╚██████╔╝███████╗██║  ██║                        ║
+// ║     ╚═╝╚═╝  ╚═══╝   ╚═╝   ╚══════╝ ╚═════╝ ╚══════╝╚═╝  ╚═╝                        ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Integer arithmetic for ufuncs: exact kernels over 64-bit                          ║
+// ║  patterns, with wrap, saturate or error on overflow.                               ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/integer.go               ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: Overflow – What integer arithmetic does when a result does not fit         ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Passed with WithOverflow to ufunc calls, reductions and accumulations            ║
+// ║   that compute in an integer dtype:                                                ║
+// ║                                                                                    ║
+// ║     - OverflowWrap     : keep the low bits, two's complement (NumPy's              ║
+// ║                          behaviour and the default)                                ║
+// ║     - OverflowSaturate : clamp to the smallest / largest value of the type         ║
+// ║     - OverflowError    : fail the operation (a WithOut array may be left           ║
+// ║                          partly written)                                           ║
+// ║                                                                                    ║
+// ║   Float and complex arithmetic is never affected.                                  ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = [100 -100] (int8)                                                            ║
+// ║   Add(a, a)                                      → [-56 56]                        ║
+// ║   Add(a, a, WithOverflow(OverflowSaturate))      → [127 -128]                      ║
+// ║   Add(a, a, WithOverflow(OverflowError))         → error                           ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type Overflow int
+
+const (
+	OverflowWrap Overflow = iota
+	OverflowSaturate
+	OverflowError
+)
+
+// String returns the name of the policy.
+func (o Overflow) String() string {
+	switch o {
+	case OverflowWrap:
+		return "wrap"
+	case OverflowSaturate:
+		return "saturate"
+	case OverflowError:
+		return "error"
+	default:
+		return fmt.Sprintf("Overflow(%d)", int(o))
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: intKernel – Scalar kernel of a ufunc for integer dtypes                    ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Operands arrive as 64-bit patterns: plain int64 values, or uint64                ║
+// ║   bits when `unsigned` is set (only when computing in Uint64). Unary               ║
+// ║   kernels ignore y.                                                                ║
+// ║                                                                                    ║
+// ║   The kernel returns the result wrapped to 64 bits and whether the                 ║
+// ║   exact result left the 64-bit range: +1 above it, -1 below it, 0 when             ║
+// ║   exact. Narrower dtypes are range-checked afterwards by fitInt.                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   intAdd(math.MaxInt64, 1, false) → (math.MinInt64, +1, nil)                       ║
+// ║   intAdd(-1, 0, true)             → (-1, 0, nil)  (uint64 max + 0)                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type intKernel func(x, y int64, unsigned bool) (int64, int, error)
+
+func intAdd(x, y int64, unsigned bool) (int64, int, error) {
+	s := x + y
+	switch {
+	case unsigned && uint64(s) < uint64(x):
+		return s, 1, nil
+	case !unsigned && x > 0 && y > 0 && s < 0:
+		return s, 1, nil
+	case !unsigned && x < 0 && y < 0 && s >= 0:
+		return s, -1, nil
+	}
+	return s, 0, nil
+}
+
+func intSub(x, y int64, unsigned bool) (int64, int, error) {
+	d := x - y
+	switch {
+	case unsigned && uint64(x) < uint64(y):
+		return d, -1, nil
+	case !unsigned && x >= 0 && y < 0 && d < 0:
+		return d, 1, nil
+	case !unsigned && x < 0 && y > 0 && d >= 0:
+		return d, -1, nil
+	}
+	return d, 0, nil
+}
+
+func intMul(x, y int64, unsigned bool) (int64, int, error) {
+	if unsigned {
+		hi, lo := bits.Mul64(uint64(x), uint64(y))
+		if hi != 0 {
+			return int64(lo), 1, nil
+		}
+		return int64(lo), 0, nil
+	}
+
+	p := x * y
+	if x != 0 && (p/x != y || (x == -1 && y == math.MinInt64)) {
+		if (x < 0) != (y < 0) {
+			return p, -1, nil
+		}
+		return p, 1, nil
+	}
+	return p, 0, nil
+}
+
+// intFloorDiv follows NumPy: the quotient rounds toward -inf and x // 0 is 0.
+func intFloorDiv(x, y int64, unsigned bool) (int64, int, error) {
+	switch {
+	case y == 0:
+		return 0, 0, nil
+	case unsigned:
+		return int64(uint64(x) / uint64(y)), 0, nil
+	case x == math.MinInt64 && y == -1:
+		return x, 1, nil
+	}
+
+	q := x / y
+	if (x%y != 0) && ((x < 0) != (y < 0)) {
+		q--
+	}
+	return q, 0, nil
+}
+
+// intMod follows NumPy: the remainder takes the sign of y and x % 0 is 0.
+func intMod(x, y int64, unsigned bool) (int64, int, error) {
+	switch {
+	case y == 0:
+		return 0, 0, nil
+	case unsigned:
+		return int64(uint64(x) % uint64(y)), 0, nil
+	}
+
+	r := x % y
+	if r != 0 && (r < 0) != (y < 0) {
+		r += y
+	}
+	return r, 0, nil
+}
+
+// intPow raises x to a non-negative integer power by repeated squaring.
+func intPow(x, y int64, unsigned bool) (int64, int, error) {
+	if !unsigned && y < 0 {
+		return 0, 0, fmt.Errorf("integers to negative integer powers are not allowed")
+	}
+
+	result, base, over := int64(1), x, false
+	for e := uint64(y); e > 0; e >>= 1 {
+		if e&1 == 1 {
+			r, o, _ := intMul(result, base, unsigned)
+			result, over = r, over || o != 0
+		}
+		if e > 1 {
+			// Squaring is only done when a higher bit still needs the base
+			b, o, _ := intMul(base, base, unsigned)
+			base, over = b, over || o != 0
+		}
+	}
+
+	switch {
+	case !over:
+		return result, 0, nil
+	case !unsigned && x < 0 && y%2 == 1:
+		return result, -1, nil
+	default:
+		return result, 1, nil
+	}
+}
+
+func intMaximum(x, y int64, unsigned bool) (int64, int, error) {
+	if intLess(y, x, unsigned) {
+		return x, 0, nil
+	}
+	return y, 0, nil
+}
+
+func intMinimum(x, y int64, unsigned bool) (int64, int, error) {
+	if intLess(x, y, unsigned) {
+		return x, 0, nil
+	}
+	return y, 0, nil
+}
+
+func intNegative(x, _ int64, unsigned bool) (int64, int, error) {
+	switch {
+	case unsigned && x != 0:
+		return -x, -1, nil
+	case !unsigned && x == math.MinInt64:
+		return x, 1, nil
+	}
+	return -x, 0, nil
+}
+
+func intAbsolute(x, _ int64, unsigned bool) (int64, int, error) {
+	if !unsigned && x < 0 {
+		return intNegative(x, 0, false)
+	}
+	return x, 0, nil
+}
+
+func intSquare(x, _ int64, unsigned bool) (int64, int, error) {
+	return intMul(x, x, unsigned)
+}
+
+// intLess compares two 64-bit patterns as signed or unsigned integers.
+func intLess(x, y int64, unsigned bool) bool {
+	if unsigned {
+		return uint64(x) < uint64(y)
+	}
+	return x < y
+}
+
+func intEqual(x, y int64, _ bool) bool        { return x == y }
+func intNotEqual(x, y int64, _ bool) bool     { return x != y }
+func intLessEqual(x, y int64, u bool) bool    { return !intLess(y, x, u) }
+func intGreater(x, y int64, u bool) bool      { return intLess(y, x, u) }
+func intGreaterEqual(x, y int64, u bool) bool { return !intLess(x, y, u) }
+
+// intPredicate turns a comparison of two integers into a kernel returning 0 or 1.
+func intPredicate(test func(x, y int64, unsigned bool) bool) intKernel {
+	return func(x, y int64, unsigned bool) (int64, int, error) {
+		if test(x, y, unsigned) {
+			return 1, 0, nil
+		}
+		return 0, 0, nil
+	}
+}
+
+// intRange returns the smallest and largest value of an integer dtype narrower
+// than 64 bits, or of Int64.
+func intRange(d DType) (int64, int64) {
+	n := 8 * d.ItemSize()
+	if d.kind() == 'u' {
+		return 0, 1<<n - 1
+	}
+	return -1 << (n - 1), 1<<(n-1) - 1
+}
+
+// fitInt applies the overflow policy to a kernel result for dtype d; ok is false
+// when the policy is OverflowError and the result does not fit.
+func fitInt(r int64, ovf int, d DType, policy Overflow) (int64, bool) {
+
+	switch {
+	case d.kind() == 'b':
+		// Bool arithmetic is logical: any non-zero result is true
+		if r != 0 || ovf != 0 {
+			return 1, true
+		}
+		return 0, true
+
+	case d == Uint64:
+		if ovf == 0 || policy == OverflowWrap {
+			return r, true
+		}
+		if policy == OverflowSaturate {
+			if ovf > 0 {
+				return -1, true // all bits set: math.MaxUint64
+			}
+			return 0, true
+		}
+		return 0, false
+	}
+
+	lo, hi := intRange(d)
+	if ovf == 0 && r >= lo && r <= hi {
+		return r, true
+	}
+
+	switch policy {
+	case OverflowWrap:
+		// Keep the low bits, as a store into the narrow type would
+		n := 64 - 8*d.ItemSize()
+		if d.kind() == 'u' {
+			return int64(uint64(r) << n >> n), true
+		}
+		return r << n >> n, true
+	case OverflowSaturate:
+		if ovf > 0 || (ovf == 0 && r > hi) {
+			return hi, true
+		}
+		return lo, true
+	default:
+		return 0, false
+	}
+}
+
+// intAt reads an integer or bool element as a 64-bit pattern.
+func intAt(buf storage, offset int) int64 {
+	if b, ok := buf.(integers); ok {
+		return b.int(offset)
+	}
+	return int64(buf.float(offset))
+}
+
+// putInt writes a 64-bit pattern into any buffer; unsigned says how to read it.
+func putInt(buf storage, offset int, r int64, unsigned bool) {
+	switch b := buf.(type) {
+	case integers:
+		b.setInt(offset, r)
+	default:
+		if unsigned {
+			buf.setFloat(offset, float64(uint64(r)))
+		} else {
+			buf.setFloat(offset, float64(r))
+		}
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: intLoop – The integer counterpart of Ufunc.loop                            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Reads every operand as 64-bit integers, applies u.integer and stores             ║
+// ║   the result into `out` after fitting it into `compute` under the                  ║
+// ║   overflow policy. Predicates store their 0 / 1 as is.                             ║
+// ║                                                                                    ║
+// ║   Returns: error – overflow under OverflowError, or a kernel error                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (u *Ufunc) intLoop(out *NDArray, inputs []*NDArray, where *NDArray, compute DType, policy Overflow) error {
+
+	operands, strides, offsets, err := broadcastOperands(out, inputs, where)
+	if err != nil {
+		return err
+	}
+
+	x := operands[1]
+	var y, mask *NDArray
+	if u.nin == 2 {
+		y = operands[2]
+	}
+	if where != nil {
+		mask = operands[len(operands)-1]
+	}
+
+	unsigned := compute == Uint64
+	var failure error
+	walk(out.shape, strides, offsets, func(offs, inner []int, n int) {
+		pos := append([]int(nil), offs...)
+		for i := 0; i < n && failure == nil; i++ {
+			if mask == nil || mask.data.float(pos[len(pos)-1]) != 0 {
+				var b int64
+				if y != nil {
+					b = intAt(y.data, pos[2])
+				}
+				r, err := u.applyInt(intAt(x.data, pos[1]), b, compute, policy)
+				if err != nil {
+					failure = err
+					return
+				}
+				putInt(out.data, pos[0], r, unsigned)
+			}
+			for k := range pos {
+				pos[k] += inner[k]
+			}
+		}
+	})
+	return failure
+}
+
+// applyInt runs the integer kernel on one pair of values and fits the result.
+func (u *Ufunc) applyInt(x, y int64, compute DType, policy Overflow) (int64, error) {
+
+	r, ovf, err := u.integer(x, y, compute == Uint64)
+	if err != nil {
+		return 0, err
+	}
+	if u.predicate {
+		return r, nil
+	}
+
+	r, ok := fitInt(r, ovf, compute, policy)
+	if !ok {
+		return 0, fmt.Errorf("integer overflow in %s for dtype %s", u.name, compute)
+	}
+	return r, nil
+}
@@ -0,0 +1,265 @@
+package ndarray_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+// typed builds a 1-D array of the Go element type of values.
+func typed[T ndarray.Element](t *testing.T, values ...T) *ndarray.NDArray {
+	t.Helper()
+	a, err := ndarray.FromSliceOf(values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return a
+}
+
+func TestIntegerArithmetic(t *testing.T) {
+	big := int64(1) << 53
+
+	// Expected values and dtypes are those of NumPy 2 (default wrapping)
+	tests := []struct {
+		name  string
+		op    func(a, b *ndarray.NDArray, opts ...ndarray.Option) (*ndarray.NDArray, error)
+		a, b  *ndarray.NDArray
+		dtype ndarray.DType
+		want  string
+	}{
+		{"int8 add wraps", ndarray.Add, typed[int8](t, 100, -100), typed[int8](t, 100, -100), ndarray.Int8, "[-56 56]"},
+		{"uint8 sub wraps", ndarray.Sub, typed[uint8](t, 1, 0), typed[uint8](t, 2, 1), ndarray.Uint8, "[255 255]"},
+		{"int8 mul wraps", ndarray.Mul, typed[int8](t, 16, -128), typed[int8](t, 16, -1), ndarray.Int8, "[0 -128]"},
+		{"int64 exact above 2^53", ndarray.Add, typed(t, big), typed[int64](t, 1), ndarray.Int64, "[9007199254740993]"},
+		{"uint64 max wraps", ndarray.Add, typed[uint64](t, math.MaxUint64), typed[uint64](t, 1), ndarray.Uint64, "[0]"},
+		{"int8 + uint8", ndarray.Add, typed[int8](t, -1, 127), typed[uint8](t, 255, 255), ndarray.Int16, "[254 382]"},
+		{"int16 + int32", ndarray.Add, typed[int16](t, 1), typed[int32](t, 2), ndarray.Int32, "[3]"},
+		{"int64 + uint64", ndarray.Add, typed[int64](t, -1), typed[uint64](t, 1), ndarray.Float64, "[0]"},
+		{"int16 + float32", ndarray.Add, typed[int16](t, 1), typed[float32](t, 0.5), ndarray.Float32, "[1.5]"},
+		{"int32 + float32", ndarray.Add, typed[int32](t, 1), typed[float32](t, 0.5), ndarray.Float64, "[1.5]"},
+		{"int32 / int32", ndarray.Div, typed[int32](t, 7, 1), typed[int32](t, 2, 0), ndarray.Float64, "[3.5 +Inf]"},
+		{"floor_divide", ndarray.FloorDiv, typed[int32](t, 7, -7, 7, -7), typed[int32](t, 2, 2, -2, 0), ndarray.Int32, "[3 -4 -4 0]"},
+		{"remainder", ndarray.Mod, typed[int32](t, 7, -7, 7, -7), typed[int32](t, 2, 2, -2, 0), ndarray.Int32, "[1 1 -1 0]"},
+		{"int8 // -1 wraps", ndarray.FloorDiv, typed[int8](t, -128), typed[int8](t, -1), ndarray.Int8, "[-128]"},
+		{"power", ndarray.Pow, typed[int16](t, 2, 3, -2), typed[int16](t, 10, 2, 3), ndarray.Int16, "[1024 9 -8]"},
+		{"int8 power wraps", ndarray.Pow, typed[int8](t, 2, 3), typed[int8](t, 7, 5), ndarray.Int8, "[-128 -13]"},
+		{"uint64 maximum", ndarray.Maximum, typed[uint64](t, math.MaxUint64, 0), typed[uint64](t, 1, 1), ndarray.Uint64, "[18446744073709551615 1]"},
+		{"int8 minimum", ndarray.Minimum, typed[int8](t, -5, 5), typed[int8](t, 3, 3), ndarray.Int8, "[-5 3]"},
+		{"bool add is or", ndarray.Add, typed(t, true, false), typed(t, true, false), ndarray.Bool, "[true false]"},
+		{"bool mul is and", ndarray.Mul, typed(t, true, true), typed(t, true, false), ndarray.Bool, "[true false]"},
+		{"exact less", ndarray.Less, typed(t, big), typed(t, big+1), ndarray.Bool, "[true]"},
+		{"uint64 greater", ndarray.Greater, typed[uint64](t, math.MaxUint64), typed[uint64](t, 1), ndarray.Bool, "[true]"},
+		{"int8 == uint8", ndarray.Equal, typed[int8](t, -1), typed[uint8](t, 255), ndarray.Bool, "[false]"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.op(tt.a, tt.b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if want := "NDArray(shape=" + fmt.Sprint(got.Shape()) + ", data=" + tt.want + ")"; got.String() != want || got.DType() != tt.dtype {
+			t.Errorf("%s: got %s (%s), want %s (%s)", tt.name, got, got.DType(), want, tt.dtype)
+		}
+	}
+
+	// NumPy raises TypeError for these
+	refused := []struct {
+		name string
+		op   func(a, b *ndarray.NDArray, opts ...ndarray.Option) (*ndarray.NDArray, error)
+		a, b *ndarray.NDArray
+	}{
+		{"bool - bool", ndarray.Sub, typed(t, true, false), typed(t, true, true)},
+	}
+	for _, tt := range refused {
+		if got, err := tt.op(tt.a, tt.b); err == nil {
+			t.Errorf("%s: got %s (%s), want an error", tt.name, got, got.DType())
+		}
+	}
+	if r, err := ndarray.Sub(typed(t, true), typed[int8](t, 1)); err != nil || r.DType() != ndarray.Int8 {
+		t.Errorf("bool - int8: got %v, %v, want int8", r, err)
+	}
+}
+
+func TestUnaryIntegerArithmetic(t *testing.T) {
+	a := typed[int8](t, -128, -3, 0, 12)
+
+	// Expected values and dtypes are those of NumPy 2
+	tests := []struct {
+		name  string
+		op    func(a *ndarray.NDArray, opts ...ndarray.Option) (*ndarray.NDArray, error)
+		a     *ndarray.NDArray
+		dtype ndarray.DType
+		want  string
+	}{
+		{"negative", ndarray.Negative, a, ndarray.Int8, "[-128 3 0 -12]"},
+		{"absolute", ndarray.Abs, a, ndarray.Int8, "[-128 3 0 12]"},
+		{"square", ndarray.Square, a, ndarray.Int8, "[0 9 0 -112]"},
+		{"negative uint8", ndarray.Negative, typed[uint8](t, 5), ndarray.Uint8, "[251]"},
+		{"sqrt bool", ndarray.Sqrt, typed(t, true, false), ndarray.Float16, "[1 0]"},
+		{"sqrt int8", ndarray.Sqrt, typed[int8](t, 4, 2), ndarray.Float16, "[2 1.414]"},
+		{"sqrt uint8", ndarray.Sqrt, typed[uint8](t, 9), ndarray.Float16, "[3]"},
+		{"sqrt int16", ndarray.Sqrt, typed[int16](t, 4), ndarray.Float32, "[2]"},
+		{"sqrt uint16", ndarray.Sqrt, typed[uint16](t, 4), ndarray.Float32, "[2]"},
+		{"sqrt int32", ndarray.Sqrt, typed[int32](t, 4), ndarray.Float64, "[2]"},
+		{"exp int8", ndarray.Exp, typed[int8](t, 0), ndarray.Float16, "[1]"},
+		{"sin int64", ndarray.Sin, typed[int64](t, 0), ndarray.Float64, "[0]"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.op(tt.a)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if want := "NDArray(shape=" + fmt.Sprint(got.Shape()) + ", data=" + tt.want + ")"; got.String() != want || got.DType() != tt.dtype {
+			t.Errorf("%s: got %s (%s), want %s (%s)", tt.name, got, got.DType(), want, tt.dtype)
+		}
+	}
+
+	if got, err := ndarray.Negative(typed(t, true)); err == nil {
+		t.Errorf("negative bool: got %s, want an error", got)
+	}
+}
+
+func TestOverflowPolicies(t *testing.T) {
+	tests := []struct {
+		name   string
+		op     func(a, b *ndarray.NDArray, opts ...ndarray.Option) (*ndarray.NDArray, error)
+		a, b   *ndarray.NDArray
+		policy ndarray.Overflow
+		want   string
+	}{
+		{"int8 add wrap", ndarray.Add, typed[int8](t, 100, -100, 1), typed[int8](t, 100, -100, 1), ndarray.OverflowWrap, "[-56 56 2]"},
+		{"int8 add saturate", ndarray.Add, typed[int8](t, 100, -100, 1), typed[int8](t, 100, -100, 1), ndarray.OverflowSaturate, "[127 -128 2]"},
+		{"uint8 sub saturate", ndarray.Sub, typed[uint8](t, 1, 5), typed[uint8](t, 2, 3), ndarray.OverflowSaturate, "[0 2]"},
+		{"int16 mul saturate", ndarray.Mul, typed[int16](t, 300, -300), typed[int16](t, 300, 300), ndarray.OverflowSaturate, "[32767 -32768]"},
+		{"int64 add saturate", ndarray.Add, typed[int64](t, math.MaxInt64, math.MinInt64), typed[int64](t, 1, -1), ndarray.OverflowSaturate, "[9223372036854775807 -9223372036854775808]"},
+		{"uint64 add saturate", ndarray.Add, typed[uint64](t, math.MaxUint64), typed[uint64](t, 1), ndarray.OverflowSaturate, "[18446744073709551615]"},
+		{"uint64 sub saturate", ndarray.Sub, typed[uint64](t, 1), typed[uint64](t, 2), ndarray.OverflowSaturate, "[0]"},
+		{"int8 pow saturate", ndarray.Pow, typed[int8](t, 2, -2), typed[int8](t, 7, 7), ndarray.OverflowSaturate, "[127 -128]"},
+		{"in range error", ndarray.Add, typed[int8](t, 100), typed[int8](t, 27), ndarray.OverflowError, "[127]"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.op(tt.a, tt.b, ndarray.WithOverflow(tt.policy))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if want := "NDArray(shape=" + fmt.Sprint(got.Shape()) + ", data=" + tt.want + ")"; got.String() != want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, want)
+		}
+	}
+
+	errors := []struct {
+		name string
+		op   func(a, b *ndarray.NDArray, opts ...ndarray.Option) (*ndarray.NDArray, error)
+		a, b *ndarray.NDArray
+	}{
+		{"int8 add", ndarray.Add, typed[int8](t, 100), typed[int8](t, 28)},
+		{"uint8 sub", ndarray.Sub, typed[uint8](t, 0), typed[uint8](t, 1)},
+		{"int64 mul", ndarray.Mul, typed[int64](t, math.MaxInt64), typed[int64](t, 2)},
+		{"uint64 add", ndarray.Add, typed[uint64](t, math.MaxUint64), typed[uint64](t, 1)},
+	}
+	for _, tt := range errors {
+		if _, err := tt.op(tt.a, tt.b, ndarray.WithOverflow(ndarray.OverflowError)); err == nil {
+			t.Errorf("%s: expected overflow error", tt.name)
+		}
+	}
+
+	if _, err := ndarray.Pow(typed[int32](t, 2), typed[int32](t, -1)); err == nil {
+		t.Errorf("expected error for negative integer power")
+	}
+}
+
+func TestIntegerReductions(t *testing.T) {
+	a := typed[int8](t, 100, 100, 100)
+
+	sum, err := a.Sum()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := sum.Value(); v != int64(300) || sum.DType() != ndarray.Int64 {
+		t.Errorf("sum int8: got %v (%s), want 300 (int64)", v, sum.DType())
+	}
+
+	u := typed[uint8](t, 200, 200)
+	if s, _ := u.Sum(); s.DType() != ndarray.Uint64 {
+		t.Errorf("sum uint8: got %s, want uint64", s.DType())
+	}
+	if p, _ := u.Prod(); p.String() != "NDArray(shape=[], data=[40000])" {
+		t.Errorf("prod uint8: got %s", p)
+	}
+	if c, _ := typed(t, true, true, false).Sum(); c.String() != "NDArray(shape=[], data=[2])" || c.DType() != ndarray.Int64 {
+		t.Errorf("sum bool: got %s (%s)", c, c.DType())
+	}
+	if m, _ := a.Max(); m.DType() != ndarray.Int8 {
+		t.Errorf("max int8: got %s, want int8", m.DType())
+	}
+	if m, _ := a.Mean(); m.String() != "NDArray(shape=[], data=[100])" || m.DType() != ndarray.Float64 {
+		t.Errorf("mean int8: got %s (%s)", m, m.DType())
+	}
+
+	// The widened sum stays exact beyond 2^53
+	big := typed[int64](t, 1<<53, 1, 1)
+	if s, _ := big.Sum(); s.String() != "NDArray(shape=[], data=[9007199254740994])" {
+		t.Errorf("sum int64: got %s", s)
+	}
+	if _, err := typed[int64](t, math.MaxInt64, 1).Sum(ndarray.WithOverflow(ndarray.OverflowError)); err == nil {
+		t.Errorf("expected overflow error from sum")
+	}
+
+	// Accumulate keeps the dtype, like np.add.accumulate
+	acc, err := ndarray.AddUfunc.Accumulate(a, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := acc.String(), "NDArray(shape=[3], data=[100 -56 44])"; got != want || acc.DType() != ndarray.Int8 {
+		t.Errorf("accumulate: got %s (%s), want %s", got, acc.DType(), want)
+	}
+	acc, _ = ndarray.AddUfunc.Accumulate(a, 0, ndarray.WithOverflow(ndarray.OverflowSaturate))
+	if got, want := acc.String(), "NDArray(shape=[3], data=[100 127 127])"; got != want {
+		t.Errorf("saturating accumulate: got %s, want %s", got, want)
+	}
+}
+
+func TestIntegerScalarsAndOut(t *testing.T) {
+	a := typed[int8](t, 100, 120)
+
+	one, err := ndarray.ScalarOf(int8(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r, _ := ndarray.Add(a, one); r.DType() != ndarray.Int8 {
+		t.Errorf("int8 + ScalarOf(int8): got %s, want int8", r.DType())
+	}
+	if r, _ := ndarray.Add(a, ndarray.Scalar(1)); r.DType() != ndarray.Float64 {
+		t.Errorf("int8 + Scalar: got %s, want float64", r.DType())
+	}
+	if _, err := ndarray.ScalarOf([]float64{1}); err == nil {
+		t.Errorf("expected error for a slice scalar")
+	}
+
+	// In-place integer update; an int16 result needs same_kind into int8
+	if _, err := ndarray.Add(a, one, ndarray.WithOut(a)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := a.String(), "NDArray(shape=[2], data=[101 121])"; got != want {
+		t.Errorf("in place: got %s, want %s", got, want)
+	}
+	wide := typed[int16](t, 1000, 1000)
+	if _, err := ndarray.Add(a, wide, ndarray.WithOut(a), ndarray.WithCasting(ndarray.CastingSafe)); err == nil {
+		t.Errorf("expected error for int16 output into int8 under safe casting")
+	}
+
+	out, _ := ndarray.ZerosOf(ndarray.Float64, 2)
+	if _, err := ndarray.Add(a, a, ndarray.WithOut(out)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := out.String(), "NDArray(shape=[2], data=[-54 -14])"; got != want {
+		t.Errorf("int8 into float64 out: got %s, want %s", got, want)
+	}
+}
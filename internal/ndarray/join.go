@@ -0,0 +1,318 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║          ██╗ ██████╗ ██╗███╗   ██╗                                                 ║
+// ║          ██║██╔═══██╗██║████╗  ██║                                                 ║
+// ║          ██║██║   ██║██║██╔██╗ ██║                                                 ║
+// ║     ██   ██║██║   ██║██║██║╚██╗██║                                                 ║
+// ║     ╚█████╔╝╚██████╔╝██║██║ ╚████║                                                 ║
+// ║      ╚════╝  ╚═════╝ ╚═╝╚═╝  ╚═══╝                                                 ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Joining routines for NDArray: concatenation, stacking                             ║
+// ║  along new or existing axes, and numpy.block assembly.                             ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/join.go                  ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Concatenate – Join arrays along an existing axis                           ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.concatenate(arrays, axis, out=out)`.                           ║
+// ║                                                                                    ║
+// ║   - All inputs need the same number of dimensions (at least 1)                     ║
+// ║   - Every axis except `axis` must have the same length                             ║
+// ║   - axis may be negative; inputs may be views or broadcast arrays                  ║
+// ║   - Honours WithOut (shape must match the result exactly)                          ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   DIAGRAM:                                                                         ║
+// ║                                                                                    ║
+// ║   [2, 3] + [1, 3]  ──axis 0──▶  [3, 3]                                             ║
+// ║   [2, 3] + [2, 4]  ──axis 1──▶  [2, 7]                                             ║
+// ║                                                                                    ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Concatenate([]*NDArray{a, b}, 0)                                                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Concatenate(arrays []*NDArray, axis int, opts ...Option) (*NDArray, error) {
+
+	if len(arrays) == 0 {
+		return nil, fmt.Errorf("need at least one array to concatenate")
+	}
+
+	first := arrays[0]
+	ndim := len(first.shape)
+	if ndim == 0 {
+		return nil, fmt.Errorf("zero-dimensional arrays cannot be concatenated")
+	}
+
+	axis, err := normalizeAxis(axis, ndim)
+	if err != nil {
+		return nil, err
+	}
+
+	shape := append([]int(nil), first.shape...)
+	shape[axis] = 0
+	for i, a := range arrays {
+		if len(a.shape) != ndim {
+			return nil, fmt.Errorf("all the input arrays must have same number of dimensions, but the array "+
+				"at index 0 has %d dimension(s) and the array at index %d has %d dimension(s)", ndim, i, len(a.shape))
+		}
+		for d := range a.shape {
+			if d != axis && a.shape[d] != first.shape[d] {
+				return nil, fmt.Errorf("all the input array dimensions except for the concatenation axis must "+
+					"match exactly, but along dimension %d, the array at index 0 has size %d and the array at "+
+					"index %d has size %d", d, first.shape[d], i, a.shape[d])
+			}
+		}
+		shape[axis] += a.shape[axis]
+	}
+
+	// Copy each input into its slab of the result
+	// The result keeps the inputs' dtype when they all agree
+	common, err := commonDType(arrays)
+	if err != nil {
+		return nil, err
+	}
+	res := allocOf(shape, common)
+	start := 0
+	for _, a := range arrays {
+		slabShape := append([]int(nil), shape...)
+		slabShape[axis] = a.shape[axis]
+		slab := res.view(slabShape, res.strides, start*res.strides[axis])
+		if err := assign(slab, a); err != nil {
+			return nil, err
+		}
+		start += a.shape[axis]
+	}
+
+	return deliver(res, collectOptions(opts).out)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Stack – Join same-shaped arrays along a new axis                           ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.stack(arrays, axis, out=out)`.                                 ║
+// ║                                                                                    ║
+// ║   - All inputs must have exactly the same shape                                    ║
+// ║   - The new axis is at position `axis` of the result, which may be                 ║
+// ║     in [-(ndim+1), ndim]                                                           ║
+// ║   - Honours WithOut                                                                ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a, b have shape [3]                                                              ║
+// ║   Stack({a, b}, 0)  → shape [2, 3]                                                 ║
+// ║   Stack({a, b}, -1) → shape [3, 2]                                                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Stack(arrays []*NDArray, axis int, opts ...Option) (*NDArray, error) {
+
+	if len(arrays) == 0 {
+		return nil, fmt.Errorf("need at least one array to stack")
+	}
+
+	expanded := make([]*NDArray, len(arrays))
+	for i, a := range arrays {
+		if !sameShape(a.shape, arrays[0].shape) {
+			return nil, fmt.Errorf("all input arrays must have the same shape")
+		}
+
+		var err error
+		if expanded[i], err = a.ExpandDims(axis); err != nil {
+			return nil, err
+		}
+	}
+
+	return Concatenate(expanded, axis, opts...)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: VStack, HStack, DStack – Stack along rows, columns, depth                  ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalents of `np.vstack`, `np.hstack` and `np.dstack`.                         ║
+// ║                                                                                    ║
+// ║   - VStack: AtLeast2D on each input, then concatenate on axis 0                    ║
+// ║   - HStack: concatenate on axis 1, or on axis 0 for 1-D inputs                     ║
+// ║   - DStack: AtLeast3D on each input, then concatenate on axis 2                    ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = [1 2 3], b = [4 5 6]                                                         ║
+// ║   VStack(a, b) → shape [2, 3]                                                      ║
+// ║   HStack(a, b) → [1 2 3 4 5 6]                                                     ║
+// ║   DStack(a, b) → shape [1, 3, 2]                                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func VStack(arrays ...*NDArray) (*NDArray, error) {
+	return Concatenate(mapArrays(arrays, AtLeast2D), 0)
+}
+
+func HStack(arrays ...*NDArray) (*NDArray, error) {
+	arrays = mapArrays(arrays, AtLeast1D)
+	if len(arrays) > 0 && len(arrays[0].shape) == 1 {
+		return Concatenate(arrays, 0)
+	}
+	return Concatenate(arrays, 1)
+}
+
+func DStack(arrays ...*NDArray) (*NDArray, error) {
+	return Concatenate(mapArrays(arrays, AtLeast3D), 2)
+}
+
+// mapArrays applies fn to every array.
+func mapArrays(arrays []*NDArray, fn func(*NDArray) *NDArray) []*NDArray {
+	out := make([]*NDArray, len(arrays))
+	for i, a := range arrays {
+		out[i] = fn(a)
+	}
+	return out
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Block – Assemble an array from nested lists of blocks                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.block(arrays)`. `blocks` is a *NDArray or a                    ║
+// ║   (possibly nested) slice of them: []*NDArray, [][]*NDArray, []any...              ║
+// ║                                                                                    ║
+// ║   - The innermost lists are concatenated along the last axis, the                  ║
+// ║     next level along the second-to-last, and so on                                 ║
+// ║   - All leaves must sit at the same list depth                                     ║
+// ║   - Blocks with fewer dimensions than the result get leading axes of               ║
+// ║     length 1                                                                       ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   DIAGRAM:                                                                         ║
+// ║                                                                                    ║
+// ║   Block([][]*NDArray{{A, B},     ┌───┬───┐                                         ║
+// ║                      {C, D}})  → │ A │ B │                                         ║
+// ║                                  ├───┼───┤                                         ║
+// ║                                  │ C │ D │                                         ║
+// ║                                  └───┴───┘                                         ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Block([][]*NDArray{{Eye(2), Zeros(2, 3)}, {Ones(1, 2), Full(5, 1, 3)}})          ║
+// ║     → shape [3, 5]                                                                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Block(blocks any) (*NDArray, error) {
+
+	v := blockElem(reflect.ValueOf(blocks))
+	if !v.IsValid() {
+		return nil, fmt.Errorf("cannot build a block from nil")
+	}
+
+	// Measure the list depth and the widest block
+	listDepth, ndim := -1, 0
+	if err := blockDepth(v, 0, &listDepth, &ndim); err != nil {
+		return nil, err
+	}
+	if listDepth > ndim {
+		ndim = listDepth
+	}
+	if ndim > 32 {
+		return nil, fmt.Errorf("shape has too many dimensions (max 32)")
+	}
+
+	// A lone array is simply copied
+	if listDepth == 0 {
+		return v.Interface().(*NDArray).Copy(), nil
+	}
+	return blockConcat(v, 0, listDepth, ndim)
+}
+
+// blockDepth checks that every leaf of v sits at the same list depth and tracks the largest ndim.
+func blockDepth(v reflect.Value, depth int, listDepth, ndim *int) error {
+
+	if !v.IsValid() {
+		return fmt.Errorf("block elements must be *NDArray or slices of them, got nil")
+	}
+
+	if a, ok := v.Interface().(*NDArray); ok && a != nil {
+		if *listDepth >= 0 && *listDepth != depth {
+			return fmt.Errorf("list depths are mismatched: found a block at depth %d and another at depth %d",
+				*listDepth, depth)
+		}
+		*listDepth = depth
+		if len(a.shape) > *ndim {
+			*ndim = len(a.shape)
+		}
+		return nil
+	}
+
+	if !isSequence(v) {
+		return fmt.Errorf("block elements must be *NDArray or slices of them, got %s", v.Type())
+	}
+	if v.Len() == 0 {
+		return fmt.Errorf("lists cannot be empty")
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		if err := blockDepth(blockElem(v.Index(i)), depth+1, listDepth, ndim); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blockConcat concatenates the children of v along axis depth-listDepth of an ndim result.
+func blockConcat(v reflect.Value, depth, listDepth, ndim int) (*NDArray, error) {
+
+	if depth == listDepth {
+		a := v.Interface().(*NDArray)
+		shape := append(make([]int, ndim-len(a.shape)), a.shape...)
+		strides := append(make([]int, ndim-len(a.shape)), a.strides...)
+		for i := 0; i < ndim-len(a.shape); i++ {
+			shape[i] = 1
+		}
+		return a.view(shape, strides, a.offset), nil
+	}
+
+	parts := make([]*NDArray, v.Len())
+	for i := range parts {
+		var err error
+		if parts[i], err = blockConcat(blockElem(v.Index(i)), depth+1, listDepth, ndim); err != nil {
+			return nil, err
+		}
+	}
+	return Concatenate(parts, depth-listDepth)
+}
+
+// blockElem unwraps interfaces, but unlike elem keeps *NDArray pointers intact.
+func blockElem(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// commonDType returns the dtype every array can be stored in, see ResultType. Only
+// times or texts mixed with other types can fail to have one.
+func commonDType(arrays []*NDArray) (DType, error) {
+	types := make([]DType, len(arrays))
+	for k, a := range arrays {
+		types[k] = a.dtype
+	}
+
+	return ResultType(types...)
+}
@@ -0,0 +1,157 @@
+package ndarray_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestConcatenate(t *testing.T) {
+	a := seq(t, 2, 3)
+	b, _ := ndarray.Full(9, 1, 3)
+
+	rows, err := ndarray.Concatenate([]*ndarray.NDArray{a, b}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := rows.String(); got != "NDArray(shape=[3 3], data=[0 1 2 3 4 5 9 9 9])" {
+		t.Errorf("unexpected concatenation on axis 0: %s", got)
+	}
+
+	// Negative axis, and a transposed view as input
+	cols, err := ndarray.Concatenate([]*ndarray.NDArray{a, b.T().T()}, -2)
+	if err != nil || cols.String() != rows.String() {
+		t.Errorf("expected axis -2 to match axis 0, got %v (%v)", cols, err)
+	}
+
+	c := seq(t, 3, 2).T()
+	wide, err := ndarray.Concatenate([]*ndarray.NDArray{a, c}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := wide.String(); got != "NDArray(shape=[2 6], data=[0 1 2 0 2 4 3 4 5 1 3 5])" {
+		t.Errorf("unexpected concatenation on axis 1: %s", got)
+	}
+
+	if _, err := ndarray.Concatenate([]*ndarray.NDArray{a, b}, 1); err == nil {
+		t.Error("expected mismatched dimension error, got nil")
+	}
+	if _, err := ndarray.Concatenate([]*ndarray.NDArray{a, seq(t, 3)}, 0); err == nil {
+		t.Error("expected mismatched ndim error, got nil")
+	}
+	if _, err := ndarray.Concatenate([]*ndarray.NDArray{ndarray.Scalar(1)}, 0); err == nil {
+		t.Error("expected error for 0-d inputs, got nil")
+	}
+	if _, err := ndarray.Concatenate(nil, 0); err == nil {
+		t.Error("expected error for no inputs, got nil")
+	}
+}
+
+func TestConcatenateOut(t *testing.T) {
+	a := seq(t, 4)
+	out, _ := ndarray.New(8)
+
+	res, err := ndarray.Concatenate([]*ndarray.NDArray{a, a}, 0, ndarray.WithOut(out))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res != out || out.String() != "NDArray(shape=[8], data=[0 1 2 3 0 1 2 3])" {
+		t.Errorf("unexpected out: %s", out)
+	}
+
+	// The output may overlap the inputs
+	halves, _ := out.Reshape(2, 4)
+	first, _ := halves.Slice(ndarray.At(0))
+	if _, err := ndarray.Concatenate([]*ndarray.NDArray{first.T(), seq(t, 4)}, 0, ndarray.WithOut(out)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := out.String(); got != "NDArray(shape=[8], data=[0 1 2 3 0 1 2 3])" {
+		t.Errorf("unexpected overlapping out: %s", got)
+	}
+
+	if _, err := ndarray.Concatenate([]*ndarray.NDArray{a}, 0, ndarray.WithOut(out)); err == nil {
+		t.Error("expected out shape error, got nil")
+	}
+}
+
+func TestStacking(t *testing.T) {
+	a := seq(t, 3)
+	b, _ := ndarray.Full(7, 3)
+
+	tests := []struct {
+		name  string
+		fn    func() (*ndarray.NDArray, error)
+		shape string
+		data  string
+	}{
+		{"Stack axis 0", func() (*ndarray.NDArray, error) { return ndarray.Stack([]*ndarray.NDArray{a, b}, 0) }, "[2 3]", "[0 1 2 7 7 7]"},
+		{"Stack axis -1", func() (*ndarray.NDArray, error) { return ndarray.Stack([]*ndarray.NDArray{a, b}, -1) }, "[3 2]", "[0 7 1 7 2 7]"},
+		{"VStack", func() (*ndarray.NDArray, error) { return ndarray.VStack(a, b) }, "[2 3]", "[0 1 2 7 7 7]"},
+		{"HStack 1-D", func() (*ndarray.NDArray, error) { return ndarray.HStack(a, b) }, "[6]", "[0 1 2 7 7 7]"},
+		{"HStack 2-D", func() (*ndarray.NDArray, error) { return ndarray.HStack(seq(t, 2, 1), seq(t, 2, 2)) }, "[2 3]", "[0 0 1 1 2 3]"},
+		{"DStack", func() (*ndarray.NDArray, error) { return ndarray.DStack(a, b) }, "[1 3 2]", "[0 7 1 7 2 7]"},
+	}
+
+	for _, tt := range tests {
+		res, err := tt.fn()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got := fmt.Sprint(res.Shape()); got != tt.shape {
+			t.Errorf("%s: got shape %s, want %s", tt.name, got, tt.shape)
+		}
+		if got := fmt.Sprint(res.ToSlice()); got != tt.data {
+			t.Errorf("%s: got data %s, want %s", tt.name, got, tt.data)
+		}
+	}
+
+	if _, err := ndarray.Stack([]*ndarray.NDArray{a, seq(t, 4)}, 0); err == nil {
+		t.Error("expected shape mismatch error, got nil")
+	}
+	if _, err := ndarray.Stack([]*ndarray.NDArray{a, b}, 2); err == nil {
+		t.Error("expected out-of-range axis error, got nil")
+	}
+}
+
+func TestBlock(t *testing.T) {
+	eye, _ := ndarray.Identity(2)
+	zeros, _ := ndarray.Zeros(2, 3)
+	ones, _ := ndarray.Ones(1, 2)
+	fives, _ := ndarray.Full(5, 1, 3)
+
+	m, err := ndarray.Block([][]*ndarray.NDArray{{eye, zeros}, {ones, fives}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "NDArray(shape=[3 5], data=[1 0 0 0 0 0 1 0 0 0 1 1 5 5 5])"
+	if got := m.String(); got != want {
+		t.Errorf("unexpected block:\n got %s\nwant %s", got, want)
+	}
+
+	// 1-D blocks in a flat list behave like HStack; scalars are promoted
+	flat, err := ndarray.Block([]any{seq(t, 2), ndarray.Scalar(9)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := flat.String(); got != "NDArray(shape=[3], data=[0 1 9])" {
+		t.Errorf("unexpected flat block: %s", got)
+	}
+
+	// A list of lists of 1-D arrays is stacked into rows
+	rows, _ := ndarray.Block([][]*ndarray.NDArray{{seq(t, 2)}, {seq(t, 2)}})
+	if got := fmt.Sprint(rows.Shape()); got != "[2 2]" {
+		t.Errorf("unexpected shape for nested 1-D blocks: %s", got)
+	}
+
+	if _, err := ndarray.Block([]any{eye, []*ndarray.NDArray{eye}}); err == nil {
+		t.Error("expected mismatched depth error, got nil")
+	}
+	if _, err := ndarray.Block([]*ndarray.NDArray{}); err == nil {
+		t.Error("expected empty list error, got nil")
+	}
+	if _, err := ndarray.Block([]any{1.0}); err == nil {
+		t.Error("expected error for non-array leaf, got nil")
+	}
+}
@@ -0,0 +1,263 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║     ███╗   ███╗ █████╗ ███╗   ██╗██╗██████╗                                        ║
+// ║     ████╗ ████║██╔══██╗████╗  ██║██║██╔══██╗                                       ║
+// ║     ██╔████╔██║███████║██╔██╗ ██║██║██████╔╝                                       ║
+// ║     ██║╚██╔╝██║██╔══██║██║╚██╗██║██║██╔═══╝                                        ║
+// ║     ██║ ╚═╝ ██║██║  ██║██║ ╚████║██║██║                                            ║
+// ║     ╚═╝     ╚═╝╚═╝  ╚═╝╚═╝  ╚═══╝╚═╝╚═╝                                            ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Array manipulation routines for NDArray: splitting into                           ║
+// ║  views, tiling and repeating elements.                                             ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/manip.go                 ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import "fmt"
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: ArraySplit – Split into `n` nearly equal views along an axis               ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.array_split(a, n, axis)`.                                      ║
+// ║                                                                                    ║
+// ║   - For an axis of length l, the first l % n parts get l/n + 1                     ║
+// ║     elements and the rest l/n (some parts may be empty)                            ║
+// ║   - Every part is a view of `a`                                                    ║
+// ║                                                                                    ║
+// ║   Returns: ([]*NDArray, error)                                                     ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   ArraySplit([0 1 2 3 4 5 6], 3, 0) → [0 1 2], [3 4], [5 6]                        ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func ArraySplit(a *NDArray, n, axis int) ([]*NDArray, error) {
+
+	if n <= 0 {
+		return nil, fmt.Errorf("number sections must be larger than 0")
+	}
+
+	axis, err := normalizeAxis(axis, len(a.shape))
+	if err != nil {
+		return nil, err
+	}
+
+	length := a.shape[axis]
+	parts := make([]*NDArray, n)
+	start := 0
+	for i := range parts {
+		size := length / n
+		if i < length%n {
+			size++
+		}
+		parts[i] = axisSlab(a, axis, start, size)
+		start += size
+	}
+	return parts, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Split – Split into `n` equal views along an axis                           ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.split(a, n, axis)`: like ArraySplit, but the axis              ║
+// ║   length must be divisible by `n`.                                                 ║
+// ║                                                                                    ║
+// ║   Returns: ([]*NDArray, error)                                                     ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Split([0 1 2 3 4 5], 3, 0) → [0 1], [2 3], [4 5]                                 ║
+// ║   Split([0 1 2 3 4], 3, 0)   → error                                               ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Split(a *NDArray, n, axis int) ([]*NDArray, error) {
+
+	if n > 0 && len(a.shape) > 0 {
+		if ax, err := normalizeAxis(axis, len(a.shape)); err == nil && a.shape[ax]%n != 0 {
+			return nil, fmt.Errorf("array split does not result in an equal division")
+		}
+	}
+	return ArraySplit(a, n, axis)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: SplitAt – Split into views at the given indices along an axis              ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.split(a, indices, axis)` (and of array_split,                  ║
+// ║   which behaves identically for index lists).                                      ║
+// ║                                                                                    ║
+// ║   - Indices [i, j] give a[:i], a[i:j], a[j:] along `axis`                          ║
+// ║   - Indices follow Python slicing: negatives count from the end, large             ║
+// ║     values are clipped, and out-of-order indices give empty parts                  ║
+// ║                                                                                    ║
+// ║   Returns: ([]*NDArray, error)                                                     ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   SplitAt([0 1 2 3 4 5], []int{2, 3}, 0) → [0 1], [2], [3 4 5]                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func SplitAt(a *NDArray, indices []int, axis int) ([]*NDArray, error) {
+
+	axis, err := normalizeAxis(axis, len(a.shape))
+	if err != nil {
+		return nil, err
+	}
+
+	length := a.shape[axis]
+	clamp := func(i int) int {
+		if i < 0 {
+			i += length
+		}
+		return min(max(i, 0), length)
+	}
+
+	bounds := append(append([]int(nil), indices...), length)
+	parts := make([]*NDArray, 0, len(bounds))
+	start := 0
+	for _, idx := range bounds {
+		stop := clamp(idx)
+		parts = append(parts, axisSlab(a, axis, start, max(stop-start, 0)))
+		start = stop
+	}
+	return parts, nil
+}
+
+// axisSlab returns the view a[..., start:start+n, ...] along one axis.
+func axisSlab(a *NDArray, axis, start, n int) *NDArray {
+	shape := append([]int(nil), a.shape...)
+	shape[axis] = n
+	return a.view(shape, append([]int(nil), a.strides...), a.offset+start*a.strides[axis])
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Tile – Repeat a whole array along each axis                                ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.tile(a, reps)`.                                                ║
+// ║                                                                                    ║
+// ║   - If reps is shorter than a's rank it is padded with leading 1s;                 ║
+// ║     if longer, `a` gets leading axes of length 1                                   ║
+// ║   - Result shape is shape[i] * reps[i]; zero reps give an empty array              ║
+// ║   - Implemented as a broadcast view [r0, s0, r1, s1, ...] that is                  ║
+// ║     copied once and reshaped, so no per-element loop is needed                     ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Tile([0 1 2], 2)         → [0 1 2 0 1 2]                                         ║
+// ║   Tile([0 1 2], 2, 1)      → [[0 1 2] [0 1 2]]                                     ║
+// ║   Tile([[1 2] [3 4]], 2)   → [[1 2 1 2] [3 4 3 4]]                                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Tile(a *NDArray, reps ...int) (*NDArray, error) {
+
+	ndim := max(len(a.shape), len(reps))
+	if ndim > 32 {
+		return nil, fmt.Errorf("shape has too many dimensions (max 32)")
+	}
+
+	fullReps := make([]int, ndim)
+	shape := make([]int, ndim)
+	strides := make([]int, ndim)
+	for i := range fullReps {
+		fullReps[i], shape[i] = 1, 1
+	}
+	copy(fullReps[ndim-len(reps):], reps)
+	copy(shape[ndim-len(a.shape):], a.shape)
+	copy(strides[ndim-len(a.strides):], a.strides)
+
+	// Interleave a stride-0 repetition axis before every data axis
+	tiledShape := make([]int, 0, 2*ndim)
+	tiledStrides := make([]int, 0, 2*ndim)
+	outShape := make([]int, ndim)
+	for i, r := range fullReps {
+		if r < 0 {
+			return nil, fmt.Errorf("negative dimensions are not allowed")
+		}
+		tiledShape = append(tiledShape, r, shape[i])
+		tiledStrides = append(tiledStrides, 0, strides[i])
+		outShape[i] = r * shape[i]
+	}
+
+	tiled := a.view(tiledShape, tiledStrides, a.offset)
+	return newStorage(gather(tiled), outShape, cStrides(outShape)), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Repeat – Repeat each element along an axis                                 ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.repeat(a, repeats, axis)`.                                     ║
+// ║                                                                                    ║
+// ║   - A single count repeats every element that many times; otherwise                ║
+// ║     there must be one count per element along the axis                             ║
+// ║   - Without an axis, `a` is flattened first and the result is 1-D                  ║
+// ║   - Counts must be non-negative                                                    ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Repeat([1 2 3], []int{2})          → [1 1 2 2 3 3]                               ║
+// ║   Repeat([[1 2] [3 4]], []int{1, 2}, 0) → [[1 2] [3 4] [3 4]]                      ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Repeat(a *NDArray, repeats []int, axis ...int) (*NDArray, error) {
+
+	if len(axis) == 0 {
+		a = a.Ravel(OrderC)
+		axis = []int{0}
+	}
+
+	ax, err := normalizeAxis(axis[0], len(a.shape))
+	if err != nil {
+		return nil, err
+	}
+
+	length := a.shape[ax]
+	counts := repeats
+	if len(repeats) == 1 {
+		counts = make([]int, length)
+		for i := range counts {
+			counts[i] = repeats[0]
+		}
+	}
+	if len(counts) != length {
+		return nil, fmt.Errorf("operands could not be broadcast together with shape (%d,) (%d,)",
+			length, len(repeats))
+	}
+
+	total := 0
+	for _, c := range counts {
+		if c < 0 {
+			return nil, fmt.Errorf("repeats may not contain negative values")
+		}
+		total += c
+	}
+
+	shape := append([]int(nil), a.shape...)
+	shape[ax] = total
+	out := allocOf(shape, a.dtype)
+
+	pos := 0
+	for i, c := range counts {
+		if c == 0 {
+			continue
+		}
+		if err := assign(axisSlab(out, ax, pos, c), axisSlab(a, ax, i, 1)); err != nil {
+			return nil, err
+		}
+		pos += c
+	}
+	return out, nil
+}
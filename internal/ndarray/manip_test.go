@@ -0,0 +1,141 @@
+package ndarray_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestArraySplit(t *testing.T) {
+	a := seq(t, 7)
+
+	parts, err := ndarray.ArraySplit(a, 3, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"[0 1 2]", "[3 4]", "[5 6]"}
+	for i, p := range parts {
+		if got := fmt.Sprint(p.ToSlice()); got != want[i] {
+			t.Errorf("part %d: got %s, want %s", i, got, want[i])
+		}
+	}
+
+	// Parts are views on the input
+	_ = parts[1].Set(-1, 0)
+	if v, _ := a.Get(3); v != -1 {
+		t.Errorf("expected write through split view, got %v", v)
+	}
+
+	if _, err := ndarray.ArraySplit(a, 0, 0); err == nil {
+		t.Error("expected error for zero sections, got nil")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	m := seq(t, 2, 6)
+
+	parts, err := ndarray.Split(m, 3, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := parts[2].String(); got != "NDArray(shape=[2 2], data=[4 5 10 11])" {
+		t.Errorf("unexpected last part: %s", got)
+	}
+
+	if _, err := ndarray.Split(seq(t, 5), 3, 0); err == nil {
+		t.Error("expected unequal division error, got nil")
+	}
+
+	tests := []struct {
+		indices []int
+		want    string
+	}{
+		{[]int{2, 3}, "[[0 1] [2] [3 4 5]]"},
+		{[]int{3, 1}, "[[0 1 2] [] [1 2 3 4 5]]"},
+		{[]int{-2, 10}, "[[0 1 2 3] [4 5] []]"},
+	}
+	for _, tt := range tests {
+		parts, err := ndarray.SplitAt(seq(t, 6), tt.indices, 0)
+		if err != nil {
+			t.Errorf("SplitAt(%v): unexpected error: %v", tt.indices, err)
+			continue
+		}
+		got := make([][]float64, len(parts))
+		for i, p := range parts {
+			got[i] = p.ToSlice()
+		}
+		if fmt.Sprint(got) != tt.want {
+			t.Errorf("SplitAt(%v): got %v, want %s", tt.indices, got, tt.want)
+		}
+	}
+}
+
+func TestTile(t *testing.T) {
+	v := seq(t, 3)
+	m := seq(t, 2, 2)
+
+	tests := []struct {
+		name string
+		a    *ndarray.NDArray
+		reps []int
+		want string
+	}{
+		{"1-D", v, []int{2}, "NDArray(shape=[6], data=[0 1 2 0 1 2])"},
+		{"1-D to 2-D", v, []int{2, 1}, "NDArray(shape=[2 3], data=[0 1 2 0 1 2])"},
+		{"2-D short reps", m, []int{2}, "NDArray(shape=[2 4], data=[0 1 0 1 2 3 2 3])"},
+		{"2-D", m, []int{2, 2}, "NDArray(shape=[4 4], data=[0 1 0 1 2 3 2 3 0 1 0 1 2 3 2 3])"},
+		{"transposed", m.T(), []int{1, 2}, "NDArray(shape=[2 4], data=[0 2 0 2 1 3 1 3])"},
+		{"zero reps", v, []int{0}, "NDArray(shape=[0], data=[])"},
+	}
+
+	for _, tt := range tests {
+		res, err := ndarray.Tile(tt.a, tt.reps...)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got := res.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := ndarray.Tile(v, -1); err == nil {
+		t.Error("expected error for negative reps, got nil")
+	}
+}
+
+func TestRepeat(t *testing.T) {
+	m := seq(t, 2, 2)
+
+	tests := []struct {
+		name    string
+		repeats []int
+		axis    []int
+		want    string
+	}{
+		{"flattened", []int{2}, nil, "NDArray(shape=[8], data=[0 0 1 1 2 2 3 3])"},
+		{"per row", []int{1, 2}, []int{0}, "NDArray(shape=[3 2], data=[0 1 2 3 2 3])"},
+		{"scalar on last axis", []int{2}, []int{-1}, "NDArray(shape=[2 4], data=[0 0 1 1 2 2 3 3])"},
+		{"zero count", []int{0, 1}, []int{1}, "NDArray(shape=[2 1], data=[1 3])"},
+	}
+
+	for _, tt := range tests {
+		res, err := ndarray.Repeat(m, tt.repeats, tt.axis...)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got := res.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := ndarray.Repeat(m, []int{1, 2, 3}, 0); err == nil {
+		t.Error("expected count mismatch error, got nil")
+	}
+	if _, err := ndarray.Repeat(m, []int{-1}); err == nil {
+		t.Error("expected negative count error, got nil")
+	}
+}
@@ -28,12 +28,18 @@ import (
 // ║   ───────────────────────────────────────────────────────────────                  ║
 // ║   Inspired by NumPy's internals, this struct holds:                                ║
 // ║                                                                                    ║
-// ║     - `data []float64` : Flat memory holding the actual values                     ║
+// ║     - `data storage`   : Flat typed memory holding the actual values               ║
 // ║     - `shape []int`    : Dimensions of the array (e.g., [3, 4])                    ║
 // ║     - `strides []int`  : Jump distances to traverse dimensions                     ║
+// ║     - `offset int`     : Position of element [0, 0, ...] inside `data`             ║
+// ║     - `base *NDArray`  : Array that owns `data` when this one is a view            ║
+// ║     - `flags Flags`    : Contiguity, ownership and writeability                    ║
+// ║     - `dtype DType`    : Element type of `data` (Float64, Uint8, ...)              ║
 // ║                                                                                    ║
 // ║   These three together allow fast, flexible, and memory-efficient                  ║
 // ║   indexing and reshaping of multidimensional arrays.                               ║
+// ║   The last three let many arrays (views) share one `data` slice, so                ║
+// ║   slices, transposes and sub-blocks never need a copy.                             ║
 // ║                                                                                    ║
 // ║────────────────────────────────────────────────────────────────────────────        ║
 // ║   DIAGRAM: An n-dimensional array built over flat memory                           ║
@@ -56,11 +62,44 @@ import (
 // ║ Example: a[2][3]  → index = 2*4 + 3*1 = 11                                         ║
 // ║                    → data[11] = a23                                                ║
 // ║                                                                                    ║
+// ║ In general: data[offset + Σ index[i]*strides[i]]                                   ║
+// ║                                                                                    ║
 // ╚════════════════════════════════════════════════════════════════════════════════════╝
 type NDArray struct {
-	data    []float64
+	data    storage
 	shape   []int
 	strides []int
+	offset  int
+	base    *NDArray
+	flags   Flags
+	dtype   DType
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   STRUCT: Flags – Memory layout information of an NDArray                          ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Mirrors `ndarray.flags` in NumPy:                                                ║
+// ║                                                                                    ║
+// ║     - `CContiguous` : Elements are adjacent in row-major order                     ║
+// ║     - `FContiguous` : Elements are adjacent in column-major order                  ║
+// ║     - `OwnData`     : The array allocated `data` itself (not a view)               ║
+// ║     - `Writeable`   : Set and in-place operations are allowed                      ║
+// ║                                                                                    ║
+// ║   Axes of length 1 never break contiguity, as in NumPy's relaxed strides.          ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a, _ := New(3, 4)   → {CContiguous: true, FContiguous: false,                    ║
+// ║                          OwnData: true, Writeable: true}                           ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type Flags struct {
+	CContiguous bool
+	FContiguous bool
+	OwnData     bool
+	Writeable   bool
 }
 
 // ╔════════════════════════════════════════════════════════════════════════════════════╗
@@ -108,19 +147,61 @@ func New(shape ...int) (*NDArray, error) {
 	data := make([]float64, totalSize)
 
 	// Calculate strides (row-major order)
-	strides := make([]int, len(shape))
-	stride := 1
-	for i := len(shape) - 1; i >= 0; i-- {
-		strides[i] = stride
-		stride *= shape[i]
-	}
+	strides := cStrides(shape)
 
 	// Return the constructed NDArray
-	return &NDArray{
-		data:    data,
-		shape:   shape,
-		strides: strides,
-	}, nil
+	return newOwned(data, shape, strides), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: NewOf, ZerosOf, OnesOf, FullOf – Constructors for any dtype                ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Same as New, Zeros, Ones and Full, but the array holds elements of               ║
+// ║   type `d` instead of float64 (the `dtype=` argument in NumPy).                    ║
+// ║                                                                                    ║
+// ║   - `value` is converted to `d` as by AsType                                       ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   img, _ := ZerosOf(Uint8, 480, 640, 3)   → 921600 bytes, not 7372800              ║
+// ║   m, _ := FullOf(Bool, 1, 2, 2)           → [[true true] [true true]]              ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func NewOf(d DType, shape ...int) (*NDArray, error) {
+
+	if _, ok := d.info(); !ok {
+		return nil, fmt.Errorf("data type %s not understood", d)
+	}
+
+	a, err := New(shape...)
+	if err != nil {
+		return nil, err
+	}
+	return newStorage(makeStorage(d, a.Size()), a.shape, a.strides), nil
+}
+
+func ZerosOf(d DType, shape ...int) (*NDArray, error) {
+	return NewOf(d, shape...)
+}
+
+func OnesOf(d DType, shape ...int) (*NDArray, error) {
+	return FullOf(d, 1.0, shape...)
+}
+
+func FullOf(d DType, value float64, shape ...int) (*NDArray, error) {
+
+	a, err := NewOf(d, shape...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < a.data.len(); i++ {
+		a.data.setFloat(i, value)
+	}
+	return a, nil
 }
 
 // ╔════════════════════════════════════════════════════════════════════════════════════╗
@@ -130,8 +211,10 @@ func New(shape ...int) (*NDArray, error) {
 // ║   Retrieves a value from a specific multidimensional index.                        ║
 // ║                                                                                    ║
 // ║   - Validates number of indices                                                    ║
+// ║   - Negative indices count from the end (a.Get(-1) → last element)                 ║
 // ║   - Bounds check for each axis                                                     ║
-// ║   - Computes flat index and returns data[offset]                                   ║
+// ║   - Computes flat index (starting at the view offset) and returns data[offset]     ║
+// ║   - See GetMode for wrap/clip handling of out-of-range indices                     ║
 // ║                                                                                    ║
 // ║   Returns: (float64, error)                                                        ║
 // ║                                                                                    ║
@@ -144,27 +227,7 @@ func New(shape ...int) (*NDArray, error) {
 // ║                                                                                    ║
 // ╚════════════════════════════════════════════════════════════════════════════════════╝
 func (a *NDArray) Get(indices ...int) (float64, error) {
-
-	if len(indices) != len(a.shape) {
-		return 0, fmt.Errorf("number of indices (%d) does not match array dimensions (%d)", len(indices), len(a.shape))
-	}
-
-	// Calculate the flat index from the multi-dimensional indices
-	flatIndex := 0
-	for i, index := range indices {
-		if index < 0 || index >= a.shape[i] {
-			return 0, fmt.Errorf("index %d out of bounds for axis %d with size %d", index, i, a.shape[i])
-		}
-		flatIndex += index * a.strides[i]
-	}
-
-	// Check if the flat index is within bounds
-	if flatIndex < 0 || flatIndex >= len(a.data) {
-		return 0, fmt.Errorf("flat index %d out of bounds for array of size %d", flatIndex, len(a.data))
-	}
-
-	// Return the value at the calculated index
-	return a.data[flatIndex], nil
+	return a.GetMode(ModeRaise, indices...)
 }
 
 // ╔════════════════════════════════════════════════════════════════════════════════════╗
@@ -173,7 +236,9 @@ func (a *NDArray) Get(indices ...int) (float64, error) {
 // ║   ───────────────────────────────────────────────────────────────                  ║
 // ║   Writes a value at a specific multidimensional index.                             ║
 // ║                                                                                    ║
-// ║   - Checks dimensionality and bounds                                               ║
+// ║   - Checks dimensionality, bounds and that the array is writeable                  ║
+// ║   - Negative indices count from the end (a.Set(v, -1, 0) → last row)               ║
+// ║   - See SetMode for wrap/clip handling of out-of-range indices                     ║
 // ║   - Computes flat index using strides                                              ║
 // ║   - Updates the data[offset] with the given value                                  ║
 // ║                                                                                    ║
@@ -188,23 +253,7 @@ func (a *NDArray) Get(indices ...int) (float64, error) {
 // ║                                                                                    ║
 // ╚════════════════════════════════════════════════════════════════════════════════════╝
 func (a *NDArray) Set(value float64, indices ...int) error {
-
-	if len(indices) != len(a.shape) {
-		return fmt.Errorf("number of indices (%d) does not match array dimensions (%d)", len(indices), len(a.shape))
-	}
-
-	// Bounds checking and index calculation
-	offset := 0
-	for i, idx := range indices {
-		if idx < 0 || idx >= a.shape[i] {
-			return fmt.Errorf("index %d out of bounds for axis %d (size %d)", idx, i, a.shape[i])
-		}
-		offset += idx * a.strides[i]
-	}
-
-	// Set the value
-	a.data[offset] = value
-	return nil
+	return a.SetMode(value, ModeRaise, indices...)
 }
 
 // ╔════════════════════════════════════════════════════════════════════════════════════╗
@@ -232,25 +281,37 @@ func (a *NDArray) Shape() []int {
 // ║                                                                                    ║
 // ║   FUNC: Reshape – Change the shape of the array                                    ║
 // ║   ───────────────────────────────────────────────────────────────                  ║
-// ║   Alters the shape of the NDArray without changing the underlying data.            ║
+// ║   Returns a new NDArray with the requested shape over the same elements.           ║
 // ║                                                                                    ║
+// ║   - One dimension may be `-1`, it is inferred from the total size                  ║
 // ║   - Validates that new shape has the same total size                               ║
-// ║   - Recomputes `strides` for the new shape                                         ║
-// ║   - No memory is reallocated                                                       ║
+// ║   - Shares `data` with the original whenever the strides allow it                  ║
+// ║   - Falls back to a C-ordered copy only when a view is impossible                  ║
 // ║                                                                                    ║
-// ║   Returns: error                                                                   ║
+// ║   Returns: (*NDArray, error)                                                       ║
 // ║                                                                                    ║
 // ║────────────────────────────────────────────────────────────────────────────        ║
 // ║   EXAMPLE:                                                                         ║
 // ║   a.Shape() → [2, 6]                                                               ║
-// ║   a.Reshape(3, 4)                                                                  ║
-// ║   a.Shape() → [3, 4]                                                               ║
-// ║   Total elements remain: 12                                                        ║
+// ║   b, _ := a.Reshape(3, -1)                                                         ║
+// ║   b.Shape() → [3, 4]                                                               ║
+// ║   Total elements remain: 12, b shares memory with a                                ║
 // ║                                                                                    ║
 // ╚════════════════════════════════════════════════════════════════════════════════════╝
-func (a *NDArray) Reshape(newShape ...int) error {
-	// TODO: Implement reshape logic
-	return nil
+func (a *NDArray) Reshape(newShape ...int) (*NDArray, error) {
+
+	shape, err := resolveShape(a.Size(), newShape)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try to express the new shape over the existing memory
+	if strides, ok := reshapeStrides(a.shape, a.strides, shape); ok {
+		return a.view(shape, strides, a.offset), nil
+	}
+
+	// Non-contiguous source: materialize the elements in row-major order
+	return newStorage(gather(a), shape, cStrides(shape)), nil
 }
 
 // ╔════════════════════════════════════════════════════════════════════════════════════╗
@@ -280,7 +341,7 @@ func Zeros(shape ...int) (*NDArray, error) {
 // ║   ───────────────────────────────────────────────────────────────                  ║
 // ║   Constructs a new NDArray of the given shape, filled with ones.                   ║
 // ║                                                                                    ║
-// ║   - Uses `New` and fills `data` with 1.0                                           ║
+// ║   - Uses `Full` with 1.0                                                           ║
 // ║                                                                                    ║
 // ║   Returns: (*NDArray, error)                                                       ║
 // ║                                                                                    ║
@@ -291,8 +352,7 @@ func Zeros(shape ...int) (*NDArray, error) {
 // ║                                                                                    ║
 // ╚════════════════════════════════════════════════════════════════════════════════════╝
 func Ones(shape ...int) (*NDArray, error) {
-	// TODO: Allocate and fill with 1.0
-	return nil, nil
+	return Full(1.0, shape...)
 }
 
 // ╔════════════════════════════════════════════════════════════════════════════════════╗
@@ -302,6 +362,7 @@ func Ones(shape ...int) (*NDArray, error) {
 // ║   Builds a new NDArray of a given shape and fills it with a custom value.          ║
 // ║                                                                                    ║
 // ║   - Uses `New`, then manually fills `data` with the given value                    ║
+// ║   - Same shape validation as `New`                                                 ║
 // ║                                                                                    ║
 // ║   Returns: (*NDArray, error)                                                       ║
 // ║                                                                                    ║
@@ -312,8 +373,17 @@ func Ones(shape ...int) (*NDArray, error) {
 // ║                                                                                    ║
 // ╚════════════════════════════════════════════════════════════════════════════════════╝
 func Full(value float64, shape ...int) (*NDArray, error) {
-	// TODO: Allocate and fill with given value
-	return nil, nil
+
+	a, err := New(shape...)
+	if err != nil {
+		return nil, err
+	}
+
+	data := a.floats()
+	for i := range data {
+		data[i] = value
+	}
+	return a, nil
 }
 
 // ╔════════════════════════════════════════════════════════════════════════════════════╗
@@ -323,6 +393,7 @@ func Full(value float64, shape ...int) (*NDArray, error) {
 // ║   Returns the total number of elements in the NDArray.                             ║
 // ║                                                                                    ║
 // ║   - Computes product of dimensions in `shape`                                      ║
+// ║   - Views report their own size, not the size of the shared buffer                 ║
 // ║                                                                                    ║
 // ║   Returns: int                                                                     ║
 // ║                                                                                    ║
@@ -333,7 +404,7 @@ func Full(value float64, shape ...int) (*NDArray, error) {
 // ╚════════════════════════════════════════════════════════════════════════════════════╝
 func (a *NDArray) Size() int {
 
-	return len(a.data)
+	return shapeSize(a.shape)
 }
 
 // ╔════════════════════════════════════════════════════════════════════════════════════╗
@@ -343,6 +414,15 @@ func (a *NDArray) Size() int {
 // ║   Returns a basic human-readable string of shape and data.                         ║
 // ║                                                                                    ║
 // ║   - Implements `Stringer` interface                                                ║
+// ║   - Lists the logical elements in row-major order, so views print what they see    ║
+// ║   - Bool arrays print their elements as true / false                               ║
+// ║   - Complex elements print as re+imi, e.g. 1-2.5i                                  ║
+// ║   - Float16 / BFloat16 elements print with the fewest digits that                  ║
+// ║     round-trip, e.g. 0.1 rather than 0.0999755859375                               ║
+// ║   - Datetimes print in ISO 8601 at their unit, e.g. 2024-03-01,                    ║
+// ║     timedeltas as tick counts, missing values as NaT                               ║
+// ║   - Strings print quoted, e.g. ["New York" "Oslo"]                                 ║
+// ║   - Records print as tuples of their fields, e.g. [(1, 0.5) (2, 0.25)]             ║
 // ║   - Useful for printing arrays via `fmt.Println()`                                 ║
 // ║                                                                                    ║
 // ║   Returns: string                                                                  ║
@@ -353,5 +433,344 @@ func (a *NDArray) Size() int {
 // ║                                                                                    ║
 // ╚════════════════════════════════════════════════════════════════════════════════════╝
 func (a *NDArray) String() string {
-	return fmt.Sprintf("NDArray(shape=%v, data=%v)", a.shape, a.data)
+
+	data := gather(a)
+	switch {
+	case a.dtype.kind() == 'c':
+		return fmt.Sprintf("NDArray(shape=%v, data=%s)", a.shape, formatComplexes(data))
+	case a.dtype == Float16 || a.dtype == BFloat16:
+		return fmt.Sprintf("NDArray(shape=%v, data=%s)", a.shape, formatHalves(data))
+	case a.dtype.isTime():
+		return fmt.Sprintf("NDArray(shape=%v, data=%s)", a.shape, formatTimes(data))
+	case a.dtype.isText():
+		return fmt.Sprintf("NDArray(shape=%v, data=%s)", a.shape, formatTexts(data))
+	case a.dtype.isRecord():
+		return fmt.Sprintf("NDArray(shape=%v, data=%s)", a.shape, formatRecords(data))
+	}
+	return fmt.Sprintf("NDArray(shape=%v, data=%v)", a.shape, data.slice())
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: newOwned / view – Internal constructors                                    ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Every NDArray is built through one of these two helpers so that                  ║
+// ║   `base`, `offset` and `flags` are always consistent.                              ║
+// ║                                                                                    ║
+// ║   - newOwned : wraps a freshly allocated slice, the array owns it                  ║
+// ║   - view     : shares `a.data` with a new shape/strides/offset; the base           ║
+// ║                always points to the owner, never to an intermediate view           ║
+// ║                                                                                    ║
+// ║   Returns: *NDArray                                                                ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func newOwned(data []float64, shape, strides []int) *NDArray {
+	return newStorage(floatBuf[float64](data), shape, strides)
+}
+
+// newStorage is newOwned for a buffer of any type; the dtype is the buffer's.
+func newStorage(data storage, shape, strides []int) *NDArray {
+	return &NDArray{
+		data:    data,
+		shape:   shape,
+		strides: strides,
+		dtype:   data.dtype(),
+		flags: Flags{
+			CContiguous: isCContiguous(shape, strides),
+			FContiguous: isFContiguous(shape, strides),
+			OwnData:     true,
+			Writeable:   true,
+		},
+	}
+}
+
+// fromBuffer wraps the n elements of a buffer of any type in a C-ordered array of
+// the given shape, or a 1-D one when no shape is given.
+func fromBuffer(buf storage, n int, shape []int) (*NDArray, error) {
+
+	if len(shape) == 0 {
+		shape = []int{n}
+	}
+
+	resolved, err := resolveShape(n, shape)
+	if err != nil {
+		return nil, err
+	}
+	return newStorage(buf, resolved, cStrides(resolved)), nil
+}
+
+// alloc returns a zero-filled, C-ordered array without validating the shape;
+// unlike New it accepts 0-d shapes and zero-length axes produced by operations.
+func alloc(shape []int) *NDArray {
+	return newOwned(make([]float64, shapeSize(shape)), shape, cStrides(shape))
+}
+
+// allocOf is alloc for an array of type d.
+func allocOf(shape []int, d DType) *NDArray {
+	return newStorage(makeStorage(d, shapeSize(shape)), shape, cStrides(shape))
+}
+
+// floats returns the buffer of a Float64 array as a plain slice, for the numeric
+// kernels; callers convert other dtypes first (see asFloat64).
+func (a *NDArray) floats() []float64 {
+	return a.data.(floatBuf[float64])
+}
+
+func (a *NDArray) view(shape, strides []int, offset int) *NDArray {
+	base := a
+	if a.base != nil {
+		base = a.base
+	}
+
+	return &NDArray{
+		data:    a.data,
+		shape:   shape,
+		strides: strides,
+		offset:  offset,
+		base:    base,
+		dtype:   a.dtype,
+		flags: Flags{
+			CContiguous: isCContiguous(shape, strides),
+			FContiguous: isFContiguous(shape, strides),
+			OwnData:     false,
+			Writeable:   a.flags.Writeable,
+		},
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Strides – Return the per-axis jump distances                               ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Strides are measured in elements (not bytes, unlike NumPy).                      ║
+// ║                                                                                    ║
+// ║   - Caller should treat it as read-only                                            ║
+// ║                                                                                    ║
+// ║   Returns: []int                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a, _ := New(3, 4)                                                                ║
+// ║   a.Strides() → [4, 1]                                                             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Strides() []int {
+	return a.strides
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Flags – Query the memory layout of the array                               ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Reports contiguity, data ownership and writeability.                             ║
+// ║                                                                                    ║
+// ║   - Returned by value, changing it does not affect the array                       ║
+// ║   - Use SetWriteable to toggle write access                                        ║
+// ║                                                                                    ║
+// ║   Returns: Flags                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   b, _ := a.Reshape(4, 3)                                                          ║
+// ║   b.Flags().OwnData → false (b is a view on a)                                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Flags() Flags {
+	return a.flags
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Base – Return the array that owns the memory of a view                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Views keep a reference to the array that allocated `data`.                       ║
+// ║                                                                                    ║
+// ║   - Returns nil when the array owns its data                                       ║
+// ║   - Views of views point straight to the owner                                     ║
+// ║                                                                                    ║
+// ║   Returns: *NDArray                                                                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   b, _ := a.Reshape(12)                                                            ║
+// ║   b.Base() == a → true                                                             ║
+// ║   a.Base()      → nil                                                              ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Base() *NDArray {
+	return a.base
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: SetWriteable – Toggle write access to the array                            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Marks the array as read-only or writeable again.                                 ║
+// ║                                                                                    ║
+// ║   - A view cannot become writeable while its base is read-only                     ║
+// ║   - Only affects this array object, existing views keep their flag                 ║
+// ║                                                                                    ║
+// ║   Returns: error                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a.SetWriteable(false)                                                            ║
+// ║   a.Set(1.0, 0, 0) → error: assignment destination is read-only                    ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) SetWriteable(writeable bool) error {
+
+	if writeable && a.base != nil && !a.base.flags.Writeable {
+		return fmt.Errorf("cannot set WRITEABLE flag to True of this array")
+	}
+
+	a.flags.Writeable = writeable
+	return nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Copy – Deep copy of the array                                              ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Allocates new memory and copies the logical elements into it.                    ║
+// ║                                                                                    ║
+// ║   - The result is C-contiguous, owns its data and is writeable                     ║
+// ║   - Works on any view, whatever its strides or offset                              ║
+// ║                                                                                    ║
+// ║   Returns: *NDArray                                                                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   b := a.Copy()                                                                    ║
+// ║   b.Set(9.0, 0, 0) → a is untouched                                                ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Copy() *NDArray {
+	shape := append([]int(nil), a.shape...)
+	return newStorage(gather(a), shape, cStrides(shape))
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: AsContiguous – Row-major version of the array                              ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.ascontiguousarray`.                                            ║
+// ║                                                                                    ║
+// ║   - Returns the array itself when it is already C-contiguous                       ║
+// ║   - Otherwise returns a C-contiguous copy                                          ║
+// ║                                                                                    ║
+// ║   Returns: *NDArray                                                                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   c := a.AsContiguous()                                                            ║
+// ║   c.Flags().CContiguous → true                                                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) AsContiguous() *NDArray {
+	if a.flags.CContiguous {
+		return a
+	}
+	return a.Copy()
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Scalar – Wrap a single value as a 0-dimensional array                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   A 0-d array has shape [] and holds exactly one element. It broadcasts            ║
+// ║   against any shape, which lets a plain number take part in array ops.             ║
+// ║                                                                                    ║
+// ║   Returns: *NDArray                                                                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   s := Scalar(2.5)                                                                 ║
+// ║   s.Shape() → []                                                                   ║
+// ║   s.Get()   → 2.5                                                                  ║
+// ║   Sub(Scalar(1), a) → 1 - a                                                        ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Scalar(value float64) *NDArray {
+	return newOwned([]float64{value}, []int{}, []int{})
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: ScalarOf – Wrap a typed Go value as a 0-dimensional array                  ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Like Scalar, but the dtype follows the Go type of `value` (int is                ║
+// ║   Int64, uint is Uint64). Scalar is always Float64, which promotes                 ║
+// ║   integer arrays to float64; ScalarOf keeps them in their own type.                ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – error for unsupported Go types                      ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = [100 120] (int8)                                                             ║
+// ║   one, _ := ScalarOf(int8(1))                                                      ║
+// ║   Add(a, one)          → [101 121]   (int8)                                        ║
+// ║   Add(a, Scalar(1))    → [101 121]   (float64)                                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func ScalarOf(value any) (*NDArray, error) {
+	buf, err := scalarStorage(value)
+	if err != nil {
+		return nil, err
+	}
+	return newStorage(buf, []int{}, []int{}), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Item – Extract the single value of a size-1 array                          ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a.item()` without arguments. Mostly used to read the              ║
+// ║   result of a full reduction, which is a 0-d array.                                ║
+// ║                                                                                    ║
+// ║   Returns: (float64, error) – error if the array holds more than one value         ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   s, _ := a.Sum()                                                                  ║
+// ║   total, _ := s.Item()                                                             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Item() (float64, error) {
+
+	if a.Size() != 1 {
+		return 0, fmt.Errorf("can only convert an array of size 1 to a Go scalar, got size %d", a.Size())
+	}
+
+	return a.realAt(flatOffset(a, 0))
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Fill – Set every element to the same value                                 ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a.fill(value)`, in place.                                         ║
+// ║                                                                                    ║
+// ║   - Only the elements seen by the array are touched, so filling a view             ║
+// ║     changes just that part of its base                                             ║
+// ║                                                                                    ║
+// ║   Returns: error (if the array is read-only)                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   row, _ := a.Slice(At(0))                                                         ║
+// ║   row.Fill(7.0)            → first row of a is now all 7s                          ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Fill(value float64) error {
+	return assign(a, Scalar(value))
 }
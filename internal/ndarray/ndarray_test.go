@@ -61,3 +61,205 @@ func TestGetOutOfBounds(t *testing.T) {
 		t.Error("expected out-of-bounds error, got nil")
 	}
 }
+
+func TestReshapeSharesData(t *testing.T) {
+	a, _ := ndarray.New(2, 6)
+
+	b, err := a.Reshape(3, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(b.Shape()) != 2 || b.Shape()[0] != 3 || b.Shape()[1] != 4 {
+		t.Errorf("unexpected shape: got %v", b.Shape())
+	}
+
+	// a[1][2] is flat element 8, which is b[2][0]
+	if err := a.Set(7.0, 1, 2); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	val, err := b.Get(2, 0)
+	if err != nil {
+		t.Fatalf("unexpected error on Get: %v", err)
+	}
+
+	if val != 7.0 {
+		t.Errorf("expected reshaped view to see 7.0, got %f", val)
+	}
+}
+
+func TestReshapeInferDimension(t *testing.T) {
+	a, _ := ndarray.New(24)
+
+	b, err := a.Reshape(2, -1, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if b.Shape()[1] != 3 {
+		t.Errorf("expected inferred dimension 3, got %v", b.Shape())
+	}
+}
+
+func TestReshapeInvalid(t *testing.T) {
+	a, _ := ndarray.New(2, 6)
+
+	if _, err := a.Reshape(5, 2); err == nil {
+		t.Error("expected error for size mismatch, got nil")
+	}
+
+	if _, err := a.Reshape(5, -1); err == nil {
+		t.Error("expected error for non-divisible inferred dimension, got nil")
+	}
+
+	if _, err := a.Reshape(-1, -1); err == nil {
+		t.Error("expected error for two unknown dimensions, got nil")
+	}
+}
+
+func TestReshapeEmptyView(t *testing.T) {
+	a, _ := ndarray.New(3, 4)
+	s, err := a.Slice(ndarray.All, ndarray.Span(0, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, shape := range [][]int{{-1}, {1, -1}, {-1, 1}} {
+		b, err := s.Reshape(shape...)
+		if err != nil {
+			t.Fatalf("Reshape(%v): unexpected error: %v", shape, err)
+		}
+		if b.Size() != 0 {
+			t.Errorf("Reshape(%v): got shape %v, want an empty array", shape, b.Shape())
+		}
+	}
+	if _, err := s.Reshape(1); err == nil {
+		t.Error("expected error reshaping an empty view to size 1, got nil")
+	}
+
+	// Take without an axis flattens through Reshape
+	taken, err := s.Take([]int{}, ndarray.ModeRaise)
+	if err != nil {
+		t.Fatalf("Take: unexpected error: %v", err)
+	}
+	if taken.Size() != 0 {
+		t.Errorf("Take: got shape %v, want an empty array", taken.Shape())
+	}
+	if _, err := s.Take([]int{0}, ndarray.ModeRaise); err == nil {
+		t.Error("expected error taking index 0 of an empty view, got nil")
+	}
+
+	// So does Repeat
+	rep, err := ndarray.Repeat(s, []int{2})
+	if err != nil {
+		t.Fatalf("Repeat: unexpected error: %v", err)
+	}
+	if rep.Size() != 0 {
+		t.Errorf("Repeat: got shape %v, want an empty array", rep.Shape())
+	}
+
+	// And Diagflat and Meshgrid, which flatten their inputs
+	if d, err := ndarray.Diagflat(s, 0); err != nil || d.Size() != 0 {
+		t.Errorf("Diagflat: got %v, %v, want an empty array", d, err)
+	}
+	x, _ := ndarray.Arange(0, 3, 1)
+	grid, err := ndarray.Meshgrid(ndarray.IndexingXY, s, x)
+	if err != nil {
+		t.Fatalf("Meshgrid: unexpected error: %v", err)
+	}
+	if grid[0].Size() != 0 || grid[1].Size() != 0 {
+		t.Errorf("Meshgrid: got shapes %v and %v, want empty arrays", grid[0].Shape(), grid[1].Shape())
+	}
+
+	// And Ravel, in either order
+	for _, order := range []ndarray.Order{ndarray.OrderC, ndarray.OrderF} {
+		if r := s.Ravel(order); len(r.Shape()) != 1 || r.Size() != 0 {
+			t.Errorf("Ravel(%v): got shape %v, want [0]", order, r.Shape())
+		}
+	}
+}
+
+func TestViewFlagsAndBase(t *testing.T) {
+	a, _ := ndarray.New(2, 6)
+
+	flags := a.Flags()
+	if !flags.CContiguous || !flags.OwnData || !flags.Writeable {
+		t.Errorf("unexpected flags for new array: %+v", flags)
+	}
+
+	b, _ := a.Reshape(3, 4)
+	c, _ := b.Reshape(12)
+
+	if b.Flags().OwnData {
+		t.Error("expected reshaped array to be a view")
+	}
+
+	if b.Base() != a || c.Base() != a {
+		t.Error("expected views to point to the owning array")
+	}
+}
+
+func TestCopyIsIndependent(t *testing.T) {
+	a, _ := ndarray.New(2, 2)
+	b := a.Copy()
+
+	if err := b.Set(3.0, 0, 1); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	val, _ := a.Get(0, 1)
+	if val != 0.0 {
+		t.Errorf("expected original to stay 0.0, got %f", val)
+	}
+
+	if !b.Flags().OwnData || b.Base() != nil {
+		t.Error("expected copy to own its data")
+	}
+
+	if a.AsContiguous() != a {
+		t.Error("expected AsContiguous to return a contiguous array unchanged")
+	}
+}
+
+func TestSetWriteable(t *testing.T) {
+	a, _ := ndarray.New(2, 2)
+
+	if err := a.SetWriteable(false); err != nil {
+		t.Fatalf("SetWriteable failed: %v", err)
+	}
+
+	if err := a.Set(1.0, 0, 0); err == nil {
+		t.Error("expected error writing to read-only array, got nil")
+	}
+
+	b, _ := a.Reshape(4)
+	if err := b.SetWriteable(true); err == nil {
+		t.Error("expected error making a view of a read-only array writeable, got nil")
+	}
+}
+
+// seq builds an array of the given shape holding 0, 1, 2, ... in row-major order.
+func seq(t *testing.T, shape ...int) *ndarray.NDArray {
+	t.Helper()
+
+	size := 1
+	for _, dim := range shape {
+		size *= dim
+	}
+
+	flat, err := ndarray.New(size)
+	if err != nil {
+		t.Fatalf("error creating array: %v", err)
+	}
+	for i := 0; i < size; i++ {
+		if err := flat.Set(float64(i), i); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+
+	a, err := flat.Reshape(shape...)
+	if err != nil {
+		t.Fatalf("Reshape failed: %v", err)
+	}
+	return a
+}
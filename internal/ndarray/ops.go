@@ -17,3 +17,1966 @@
 // ╚════════════════════════════════════════════════════════════════════════════════════╝
 
 package ndarray
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   STRUCT: Ufunc – Universal function descriptor                                    ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Wraps a scalar kernel (one or two float64 inputs, one output) and turns          ║
+// ║   it into a full NumPy-style ufunc:                                                ║
+// ║                                                                                    ║
+// ║     - Call       : broadcasting, strided iteration, out= and where=                ║
+// ║     - Reduce     : fold along one or more axes       (binary only)                 ║
+// ║     - Accumulate : running fold along one axis        (binary only)                ║
+// ║     - ReduceAt   : folds over slices of one axis      (binary only)                ║
+// ║     - Outer      : every pair of elements             (binary only)                ║
+// ║     - At         : unbuffered in-place application on selected rows                ║
+// ║                                                                                    ║
+// ║   Built-in operations (AddUfunc, SqrtUfunc, ...) are ordinary Ufuncs;              ║
+// ║   custom kernels get exactly the same behaviour, except that only the              ║
+// ║   built-ins carry exact integer, complex and datetime kernels (custom              ║
+// ║   ones see integer inputs as float64 and reject complex and time ones).            ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   clampScale := NewBinaryUfunc("clamp_scale", func(x, k float64) float64 {         ║
+// ║       return math.Min(math.Max(x, 0), 1) * k                                       ║
+// ║   })                                                                               ║
+// ║   y, _ := clampScale.Call([]*NDArray{x, Scalar(10)}, WithWhere(mask))              ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type Ufunc struct {
+	name        string
+	nin         int
+	unary       func(x float64) float64
+	binary      func(x, y float64) float64
+	identity    float64
+	hasIdentity bool
+	additive    bool
+	predicate   bool
+	widens      bool
+	realValued  bool
+	smallFloats bool
+	noBool      bool
+	integer     intKernel
+	complex     complexKernel
+	time        timeSignatures
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: NewUnaryUfunc / NewBinaryUfunc – Register a custom kernel                  ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Builds a Ufunc from a scalar function.                                           ║
+// ║                                                                                    ║
+// ║   - `name` is used in error messages                                               ║
+// ║   - A binary ufunc may declare an identity (0 for add, 1 for multiply);            ║
+// ║     it seeds reductions and allows reducing empty axes or with where=              ║
+// ║                                                                                    ║
+// ║   Returns: *Ufunc                                                                  ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   hypot := NewBinaryUfunc("hypot", math.Hypot)                                     ║
+// ║   sum   := NewBinaryUfunc("my_add", func(x, y float64) float64 { return x + y }, 0)║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func NewUnaryUfunc(name string, kernel func(x float64) float64) *Ufunc {
+	return &Ufunc{name: name, nin: 1, unary: kernel}
+}
+
+func NewBinaryUfunc(name string, kernel func(x, y float64) float64, identity ...float64) *Ufunc {
+	u := &Ufunc{name: name, nin: 2, binary: kernel}
+	if len(identity) > 0 {
+		u.identity = identity[0]
+		u.hasIdentity = true
+	}
+	return u
+}
+
+// Name returns the name the ufunc was registered with.
+func (u *Ufunc) Name() string { return u.name }
+
+// NIn returns the number of inputs the kernel takes (1 or 2).
+func (u *Ufunc) NIn() int { return u.nin }
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: Option – Keyword arguments of ufuncs, reductions and creation routines     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Go has no keyword arguments, so NumPy's `out=`, `where=`, `axis=`, ...           ║
+// ║   are passed as functional options. Each method documents which ones               ║
+// ║   it honours; the others are ignored.                                              ║
+// ║                                                                                    ║
+// ║     - WithOut(arr)      : write the result into an existing array                  ║
+// ║     - WithWhere(mask)   : only compute where mask is non-zero                      ║
+// ║     - WithAxes(ax...)   : axes to reduce over (negative allowed)                   ║
+// ║     - WithKeepDims()    : keep reduced axes with length 1                          ║
+// ║     - WithInitial(v)    : starting value of a reduction                            ║
+// ║     - WithEndpoint(b)   : whether Linspace & co. include `stop`                    ║
+// ║     - WithRetStep(&s)   : receive the spacing computed by Linspace                 ║
+// ║     - WithConstantValues(b, a), WithEndValues(b, a) : border values for Pad        ║
+// ║     - WithCasting(c)    : casting rule for AsType and for WithOut arrays           ║
+// ║     - WithOverflow(p)   : what integer arithmetic does on overflow                 ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   Add(a, b, WithOut(a))                       → a += b, in place                   ║
+// ║   AddUfunc.Reduce(a, WithAxes(0, -1), WithKeepDims())                              ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type Option func(*options)
+
+type options struct {
+	ddof        int
+	out         *NDArray
+	where       *NDArray
+	axes        []int
+	hasAxes     bool
+	keepDims    bool
+	initial     float64
+	hasInitial  bool
+	summation   Summation
+	noEndpoint  bool
+	retStep     *float64
+	padConstant [2]float64
+	padEnd      [2]float64
+	casting     Casting
+	hasCasting  bool
+	overflow    Overflow
+}
+
+func WithOut(out *NDArray) Option         { return func(o *options) { o.out = out } }
+func WithWhere(mask *NDArray) Option      { return func(o *options) { o.where = mask } }
+func WithKeepDims() Option                { return func(o *options) { o.keepDims = true } }
+func WithDDof(ddof int) Option            { return func(o *options) { o.ddof = ddof } }
+func WithSummation(mode Summation) Option { return func(o *options) { o.summation = mode } }
+func WithEndpoint(endpoint bool) Option   { return func(o *options) { o.noEndpoint = !endpoint } }
+func WithRetStep(step *float64) Option    { return func(o *options) { o.retStep = step } }
+func WithCasting(casting Casting) Option {
+	return func(o *options) { o.casting, o.hasCasting = casting, true }
+}
+
+func WithOverflow(policy Overflow) Option {
+	return func(o *options) { o.overflow = policy }
+}
+
+func WithConstantValues(before, after float64) Option {
+	return func(o *options) { o.padConstant = [2]float64{before, after} }
+}
+
+func WithEndValues(before, after float64) Option {
+	return func(o *options) { o.padEnd = [2]float64{before, after} }
+}
+
+func WithInitial(value float64) Option {
+	return func(o *options) { o.initial, o.hasInitial = value, true }
+}
+
+func WithAxes(axes ...int) Option {
+	return func(o *options) {
+		o.axes = append([]int(nil), axes...)
+		o.hasAxes = true
+	}
+}
+
+func collectOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Call – Apply the ufunc elementwise                                         ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `ufunc(*inputs, out=..., where=...)`.                              ║
+// ║                                                                                    ║
+// ║   - len(inputs) must match the kernel arity                                        ║
+// ║   - Inputs are broadcast together (and against `out`, if given)                    ║
+// ║   - WithOut: result goes into that array, which must already have the              ║
+// ║     broadcast shape; it may alias an input for in-place updates                    ║
+// ║   - WithWhere: positions where the mask is 0 are not computed and keep             ║
+// ║     their previous value in `out` (0 in a freshly allocated result)                ║
+// ║   - Inputs are promoted with ResultType (see resolve): integer                     ║
+// ║     arithmetic is exact and follows WithOverflow, float32 stays                    ║
+// ║     float32 (float16 / bfloat16 too, computed in float32), integers                ║
+// ║     meet float-only kernels as float64, complex inputs go through                  ║
+// ║     the complex kernels                                                            ║
+// ║   - datetime64 / timedelta64 operands follow NumPy's datetime rules                ║
+// ║     (see timeSignatures): dates minus dates are timedeltas, ...                    ║
+// ║   - An `out` of another dtype must accept the result under the                     ║
+// ║     WithCasting rule, CastingSameKind by default (as in NumPy)                     ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – the output array (`out` when given)                 ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   SqrtUfunc.Call([]*NDArray{a})                                                    ║
+// ║   AddUfunc.Call([]*NDArray{a, b}, WithOut(a), WithWhere(mask))                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (u *Ufunc) Call(inputs []*NDArray, opts ...Option) (*NDArray, error) {
+
+	if len(inputs) != u.nin {
+		return nil, fmt.Errorf("%s() takes %d input(s), got %d", u.name, u.nin, len(inputs))
+	}
+
+	o := collectOptions(opts)
+
+	shapes := make([][]int, 0, len(inputs)+1)
+	for _, in := range inputs {
+		shapes = append(shapes, in.shape)
+	}
+
+	types := make([]DType, len(inputs))
+	for k, in := range inputs {
+		types[k] = in.dtype
+	}
+	compute, result, err := u.resolve(types...)
+	if err != nil {
+		return nil, err
+	}
+	shape, err := BroadcastShapes(shapes...)
+	if err != nil {
+		if len(inputs) == 2 {
+			return nil, fmt.Errorf("operands could not be broadcast together with shapes %v %v", inputs[0].shape, inputs[1].shape)
+		}
+		return nil, err
+	}
+
+	out := o.out
+	if out == nil {
+		out = allocOf(shape, result)
+	} else {
+		if full, err := BroadcastShapes(shape, out.shape); err != nil || !sameShape(full, out.shape) {
+			return nil, fmt.Errorf("non-broadcastable output operand with shape %v doesn't match the broadcast shape %v", out.shape, shape)
+		}
+		if !out.flags.Writeable {
+			return nil, fmt.Errorf("output array is read-only")
+		}
+		casting := CastingSameKind
+		if o.hasCasting {
+			casting = o.casting
+		}
+		if !CanCast(result, out.dtype, casting) {
+			return nil, fmt.Errorf("cannot cast ufunc '%s' output from dtype('%s') to dtype('%s') with casting rule '%s'", u.name, result, out.dtype, casting)
+		}
+	}
+
+	switch compute.kind() {
+	case 'c':
+		if err := u.complexLoop(out, inputs, o.where, compute); err != nil {
+			return nil, err
+		}
+		return out, nil
+	case 'm':
+		if err := u.timeLoop(out, inputs, o.where, compute.unit()); err != nil {
+			return nil, err
+		}
+		return out, nil
+	case 'b', 'i', 'u':
+		if err := u.intLoop(out, inputs, o.where, compute, o.overflow); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+
+	inputs, err = u.floatInputs(inputs)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.loop(out, inputs, o.where, compute); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: resolve – Pick the dtypes of a call                                        ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   The inputs are promoted with ResultType. Ufuncs with an integer                  ║
+// ║   kernel compute exactly in that dtype when it is bool or integer;                 ║
+// ║   math functions such as Sqrt compute it in the narrowest float that               ║
+// ║   holds it (Float16 for int8, Float32 for int16), and every other                  ║
+// ║   ufunc, Div included, in Float64. Sub and Negative refuse bool                    ║
+// ║   inputs, as NumPy does. Complex inputs need a complex kernel.                     ║
+// ║   Predicates always produce Bool, and Abs / Angle the real                         ║
+// ║   counterpart of a complex dtype.                                                  ║
+// ║   Calls with time operands are resolved by resolveTime; text operands              ║
+// ║   have no kernels (see package char), nor do records.                              ║
+// ║                                                                                    ║
+// ║   Returns: (compute, result DType, error) – error for complex inputs               ║
+// ║            to a ufunc without a complex kernel, or bool ones to Sub                ║
+// ║            and Negative                                                            ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   AddUfunc.resolve(Int8, Uint8)      → Int16, Int16                                ║
+// ║   DivUfunc.resolve(Int32, Int32)     → Float64, Float64                            ║
+// ║   SqrtUfunc.resolve(Int8)            → Float16, Float16                            ║
+// ║   LessUfunc.resolve(Float32, Int8)   → Float32, Bool                               ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (u *Ufunc) resolve(types ...DType) (DType, DType, error) {
+
+	for _, d := range types {
+		if d.isText() || d.isRecord() {
+			return 0, 0, fmt.Errorf("ufunc '%s' not supported for the input type %s", u.name, d)
+		}
+	}
+	for _, d := range types {
+		if d.isTime() {
+			return u.resolveTime(types...)
+		}
+	}
+
+	compute, err := ResultType(types...)
+	if err != nil {
+		return 0, 0, err
+	}
+	switch compute.kind() {
+	case 'c':
+		if u.complex == nil {
+			return 0, 0, fmt.Errorf("ufunc '%s' not supported for the input type %s", u.name, compute)
+		}
+	case 'b', 'i', 'u':
+		switch {
+		case compute == Bool && u.noBool:
+			return 0, 0, fmt.Errorf("ufunc '%s' not supported for boolean inputs", u.name)
+		case u.integer != nil:
+		case u.smallFloats:
+			compute = smallestFloat(compute)
+		default:
+			compute = Float64
+		}
+	}
+
+	switch {
+	case u.predicate:
+		return compute, Bool, nil
+	case u.realValued && compute.kind() == 'c':
+		return compute, realPart(compute), nil
+	default:
+		return compute, compute, nil
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: broadcastOperands – Line up out, inputs and mask for a walk                ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Broadcasts every input (and the optional mask) to out's shape.                   ║
+// ║   Inputs that share memory with `out` through a different layout are               ║
+// ║   copied first, so in-place calls on shifted or transposed views never             ║
+// ║   read already-written values.                                                     ║
+// ║                                                                                    ║
+// ║   Returns: (operands, strides, offsets, error) – out first, then the               ║
+// ║            inputs, then the mask                                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func broadcastOperands(out *NDArray, inputs []*NDArray, where *NDArray) ([]*NDArray, [][]int, []int, error) {
+
+	operands := append([]*NDArray{out}, inputs...)
+	if where != nil {
+		operands = append(operands, where)
+	}
+
+	strides := make([][]int, len(operands))
+	offsets := make([]int, len(operands))
+	for k, op := range operands {
+		st, err := broadcastStrides(op.shape, op.strides, out.shape)
+		if err != nil {
+			if k == len(operands)-1 && where != nil {
+				return nil, nil, nil, fmt.Errorf("where mask with shape %v cannot be broadcast to %v", where.shape, out.shape)
+			}
+			return nil, nil, nil, fmt.Errorf("operands could not be broadcast together with shapes %v %v", op.shape, out.shape)
+		}
+
+		if k > 0 && sharesMemory(op, out) && (op.offset != out.offset || !sameShape(st, out.strides)) {
+			op = op.Copy()
+			operands[k] = op
+			st, _ = broadcastStrides(op.shape, op.strides, out.shape)
+		}
+
+		strides[k] = st
+		offsets[k] = op.offset
+	}
+	return operands, strides, offsets, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: loop – Inner elementwise loop of Call                                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Walks out, the Float64 inputs and the mask in lock-step and applies              ║
+// ║   the float kernel. Results are rounded to the compute dtype (see                  ║
+// ║   rounding) and stored into `out` whatever its dtype.                              ║
+// ║                                                                                    ║
+// ║   Returns: error                                                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (u *Ufunc) loop(out *NDArray, inputs []*NDArray, where *NDArray, compute DType) error {
+
+	operands, strides, offsets, err := broadcastOperands(out, inputs, where)
+	if err != nil {
+		return err
+	}
+
+	x := operands[1]
+	var y, mask *NDArray
+	if u.nin == 2 {
+		y = operands[2]
+	}
+	if where != nil {
+		mask = operands[len(operands)-1]
+	}
+
+	xd := x.floats()
+	var od, yd []float64
+	if buf, ok := out.data.(floatBuf[float64]); ok {
+		od = buf
+	}
+	if y != nil {
+		yd = y.floats()
+	}
+	round := rounding(compute)
+
+	walk(out.shape, strides, offsets, func(offs, inner []int, n int) {
+		pos := append([]int(nil), offs...)
+		for i := 0; i < n; i++ {
+			if mask == nil || mask.data.float(pos[len(pos)-1]) != 0 {
+				var v float64
+				if u.nin == 1 {
+					v = u.unary(xd[pos[1]])
+				} else {
+					v = u.binary(xd[pos[1]], yd[pos[2]])
+				}
+				if round != nil {
+					v = round(v)
+				}
+				if od != nil {
+					od[pos[0]] = v
+				} else {
+					out.data.setFloat(pos[0], v)
+				}
+			}
+			for k := range pos {
+				pos[k] += inner[k]
+			}
+		}
+	})
+	return nil
+}
+
+// rounding returns the function that rounds a float64 result to the precision of
+// compute, or nil for Float64. Like NumPy, 16-bit floats are computed in float32
+// and then rounded once more.
+func rounding(compute DType) func(float64) float64 {
+	switch compute {
+	case Float32:
+		return func(v float64) float64 { return float64(float32(v)) }
+	case Float16, BFloat16:
+		return func(v float64) float64 { return halfRound(float64(float32(v)), compute) }
+	default:
+		return nil
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Reduce – Fold the array along axes with a binary ufunc                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `ufunc.reduce(a, axis=0, out=, keepdims=, initial=, where=)`.      ║
+// ║                                                                                    ║
+// ║   - Default axis is 0, as in NumPy; WithAxes selects others (or none)              ║
+// ║   - Elements are combined in row-major order along the reduced axes                ║
+// ║   - The fold starts from WithInitial, else the identity, else the                  ║
+// ║     first element; empty reductions need one of the first two                      ║
+// ║   - WithWhere skips masked-out elements (needs an initial value)                   ║
+// ║   - WithKeepDims keeps reduced axes with length 1                                  ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – 0-d when every axis is reduced                      ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a = [[1 2 3] [4 5 6]]                                                            ║
+// ║   AddUfunc.Reduce(a)                    → [5 7 9]                                  ║
+// ║   AddUfunc.Reduce(a, WithAxes(1))       → [6 15]                                   ║
+// ║   MaximumUfunc.Reduce(a, WithAxes(0, 1)) → 6 (shape [])                            ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (u *Ufunc) Reduce(a *NDArray, opts ...Option) (*NDArray, error) {
+
+	if u.nin != 2 {
+		return nil, fmt.Errorf("reduce only supported for binary functions")
+	}
+
+	o := collectOptions(opts)
+	axes := []int{0}
+	if o.hasAxes {
+		axes = o.axes
+	}
+
+	reduced, err := reducedAxes(axes, len(a.shape))
+	if err != nil {
+		return nil, err
+	}
+
+	compute, result, err := u.reduceTypes(a.dtype)
+	if err != nil {
+		return nil, err
+	}
+
+	initial, hasInitial := u.identity, u.hasIdentity
+	if o.hasInitial {
+		initial, hasInitial = o.initial, true
+	}
+
+	if o.where != nil {
+		if !hasInitial {
+			return nil, fmt.Errorf("reduction operation '%s' does not have an identity, so to use a where mask one has to specify 'initial'", u.name)
+		}
+		if _, err := broadcastStrides(o.where.shape, o.where.strides, a.shape); err != nil {
+			return nil, fmt.Errorf("where mask with shape %v cannot be broadcast to %v", o.where.shape, a.shape)
+		}
+	}
+
+	for axis, r := range reduced {
+		if r && a.shape[axis] == 0 && !hasInitial {
+			return nil, fmt.Errorf("zero-size array to reduction operation %s which has no identity", u.name)
+		}
+	}
+
+	var res *NDArray
+	switch compute.kind() {
+	case 'f':
+		if a, err = u.floatInput(a); err != nil {
+			return nil, err
+		}
+		res = foldAxes(a, reduced, o.where, u, o.summation, initial, hasInitial).withDType(result)
+	case 'c':
+		res, err = foldComplex(a, reduced, o.where, u, o.summation, initial, hasInitial, result)
+	case 'm':
+		res, err = foldTimes(a, reduced, o.where, u, initial, hasInitial, result)
+	default:
+		res, err = foldInts(a, reduced, o.where, u, initial, hasInitial, compute, result, o.overflow)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return finishReduction(res, a.shape, reduced, o)
+}
+
+// reduceTypes is resolve for a fold over elements of dtype d. Like NumPy, sums
+// and products of bool and narrow integers widen to 64 bits.
+func (u *Ufunc) reduceTypes(d DType) (DType, DType, error) {
+
+	compute, result, err := u.resolve(d, d)
+	if err != nil || !u.widens {
+		return compute, result, err
+	}
+
+	switch compute.kind() {
+	case 'b', 'i':
+		compute = Int64
+	case 'u':
+		compute = Uint64
+	}
+	return compute, compute, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: reducedAxes – Normalize a list of reduction axes                           ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Turns an axis list (negatives allowed) into a per-axis mask.                     ║
+// ║                                                                                    ║
+// ║   Returns: ([]bool, error) – error on out-of-range or repeated axes                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   reducedAxes([]int{0, -1}, 3) → [true false true]                                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func reducedAxes(axes []int, ndim int) ([]bool, error) {
+	mask := make([]bool, ndim)
+	for _, axis := range axes {
+		ax, err := normalizeAxis(axis, ndim)
+		if err != nil {
+			return nil, err
+		}
+		if mask[ax] {
+			return nil, fmt.Errorf("duplicate value in 'axis'")
+		}
+		mask[ax] = true
+	}
+	return mask, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: foldAxes – Core reduction loop                                             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Folds `a` along the masked axes into a C-ordered result holding one              ║
+// ║   element per kept position (the reduced axes are left with length 1).             ║
+// ║                                                                                    ║
+// ║   - The reduced axes are moved last, so each result element receives a             ║
+// ║     consecutive run of inputs in row-major order                                   ║
+// ║   - The result is seen by the walk as a broadcast operand (stride 0 on             ║
+// ║     reduced axes)                                                                  ║
+// ║   - Additive ufuncs sum every run that folds into one element with                 ║
+// ║     pairwiseSum, or keep a Neumaier compensation term per element                  ║
+// ║                                                                                    ║
+// ║   Returns: *NDArray – shape of `a` with reduced axes set to 1                      ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func foldAxes(a *NDArray, reduced []bool, where *NDArray, u *Ufunc, summation Summation, initial float64, hasInitial bool) *NDArray {
+
+	kept := keptShape(a.shape, reduced)
+	res := alloc(kept)
+	rd, ad := res.floats(), a.floats()
+	seen := make([]bool, len(rd))
+	if hasInitial {
+		for i := range rd {
+			rd[i] = initial
+			seen[i] = true
+		}
+	}
+
+	shape, operands, offsets := foldPlan(a, kept, reduced, where)
+
+	pairwise := u.additive && summation == SumPairwise
+	var comp []float64
+	if u.additive && summation == SumKahan {
+		comp = make([]float64, len(rd))
+	}
+
+	walk(shape, operands, offsets, func(offs, inner []int, n int) {
+		r, x := offs[0], offs[1]
+
+		// The whole row folds into a single result element: sum it as a block
+		if pairwise && where == nil && inner[0] == 0 {
+			block := pairwiseSum(ad, x, inner[1], n)
+			if seen[r] {
+				rd[r] += block
+			} else {
+				rd[r] = block
+				seen[r] = true
+			}
+			return
+		}
+
+		m := 0
+		if where != nil {
+			m = offs[2]
+		}
+		for i := 0; i < n; i++ {
+			if where == nil || where.data.float(m) != 0 {
+				switch {
+				case !seen[r]:
+					rd[r] = ad[x]
+					seen[r] = true
+				case comp != nil:
+					neumaierAdd(&rd[r], &comp[r], ad[x])
+				default:
+					rd[r] = u.binary(rd[r], ad[x])
+				}
+			}
+			r += inner[0]
+			x += inner[1]
+			if where != nil {
+				m += inner[2]
+			}
+		}
+	})
+
+	for i := range comp {
+		rd[i] += comp[i]
+	}
+
+	return res
+}
+
+// keptShape is shape with the reduced axes set to length 1.
+func keptShape(shape []int, reduced []bool) []int {
+	kept := make([]int, len(shape))
+	for axis, dim := range shape {
+		kept[axis] = dim
+		if reduced[axis] {
+			kept[axis] = 1
+		}
+	}
+	return kept
+}
+
+// foldPlan lays out a reduction for walk: kept axes first, reduced axes last in
+// their original order, with a C-ordered result of shape `kept` broadcast over
+// the reduced axes (stride 0), then `a`, then the optional mask.
+func foldPlan(a *NDArray, kept []int, reduced []bool, where *NDArray) ([]int, [][]int, []int) {
+
+	perm := make([]int, 0, len(a.shape))
+	for axis := range a.shape {
+		if !reduced[axis] {
+			perm = append(perm, axis)
+		}
+	}
+	for axis := range a.shape {
+		if reduced[axis] {
+			perm = append(perm, axis)
+		}
+	}
+
+	resStrides, _ := broadcastStrides(kept, cStrides(kept), a.shape)
+	operands := [][]int{resStrides, a.strides}
+	offsets := []int{0, a.offset}
+	if where != nil {
+		ms, _ := broadcastStrides(where.shape, where.strides, a.shape)
+		operands = append(operands, ms)
+		offsets = append(offsets, where.offset)
+	}
+
+	for k := range operands {
+		operands[k] = permute(operands[k], perm)
+	}
+	return permute(a.shape, perm), operands, offsets
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: foldInts – Integer counterpart of foldAxes                                 ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Folds `a` exactly in the integer (or bool) dtype `compute`, applying             ║
+// ║   the overflow policy at every step, and returns the folds as an                   ║
+// ║   array of dtype `result`.                                                         ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – shape of `a` with reduced axes set to 1             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func foldInts(a *NDArray, reduced []bool, where *NDArray, u *Ufunc, initial float64, hasInitial bool, compute, result DType, policy Overflow) (*NDArray, error) {
+
+	kept := keptShape(a.shape, reduced)
+	res := allocOf(kept, result)
+	acc := make([]int64, res.Size())
+	seen := make([]bool, len(acc))
+	if hasInitial {
+		for i := range acc {
+			acc[i] = truncate(initial)
+			seen[i] = true
+		}
+	}
+
+	shape, operands, offsets := foldPlan(a, kept, reduced, where)
+
+	var failure error
+	walk(shape, operands, offsets, func(offs, inner []int, n int) {
+		r, x := offs[0], offs[1]
+		m := 0
+		if where != nil {
+			m = offs[2]
+		}
+		for i := 0; i < n && failure == nil; i++ {
+			if where == nil || where.data.float(m) != 0 {
+				v := intAt(a.data, x)
+				if seen[r] {
+					v, failure = u.applyInt(acc[r], v, compute, policy)
+				}
+				acc[r], seen[r] = v, true
+			}
+			r += inner[0]
+			x += inner[1]
+			if where != nil {
+				m += inner[2]
+			}
+		}
+	})
+	if failure != nil {
+		return nil, failure
+	}
+
+	for i, v := range acc {
+		putInt(res.data, i, v, compute == Uint64)
+	}
+	return res, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: Summation – Algorithm used by additive reductions                          ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Naive left-to-right summation accumulates an error that grows with n.            ║
+// ║   Sum, Mean, Var, Std and AddUfunc.Reduce therefore offer:                         ║
+// ║                                                                                    ║
+// ║     - SumPairwise : (default) NumPy's scheme — every run of elements that          ║
+// ║                     folds into one result is split recursively in halves,          ║
+// ║                     error grows with log2(n); same speed as naive                  ║
+// ║     - SumKahan    : Neumaier's compensated sum, a running correction term          ║
+// ║                     per result element; error independent of n, along              ║
+// ║                     every axis, at roughly 4x the cost                             ║
+// ║     - SumNaive    : plain left-to-right accumulation                               ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a.Sum(WithSummation(SumKahan))                                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type Summation int
+
+const (
+	SumPairwise Summation = iota
+	SumKahan
+	SumNaive
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: pairwiseSum – Sum a strided run with NumPy's pairwise algorithm            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Port of `pairwise_sum` from NumPy's loops:                                       ║
+// ║                                                                                    ║
+// ║   - Fewer than 8 elements: plain loop                                              ║
+// ║   - Up to 128 elements: 8 independent accumulators combined as a tree              ║
+// ║   - Larger: split in two halves (multiple of 8) and recurse                        ║
+// ║                                                                                    ║
+// ║   Returns: float64                                                                 ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   DIAGRAM (n = 300):                                                               ║
+// ║                                                                                    ║
+// ║                 300                                                                ║
+// ║               /     \                                                              ║
+// ║            144       156                                                           ║
+// ║           /   \     /   \                                                          ║
+// ║          72   72   72    84   ← ≤ 128: 8-way unrolled blocks                       ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func pairwiseSum(data []float64, start, stride, n int) float64 {
+
+	switch {
+	case n < 8:
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			sum += data[start+i*stride]
+		}
+		return sum
+
+	case n <= 128:
+		var r [8]float64
+		for j := 0; j < 8; j++ {
+			r[j] = data[start+j*stride]
+		}
+		i := 8
+		for ; i < n-n%8; i += 8 {
+			for j := 0; j < 8; j++ {
+				r[j] += data[start+(i+j)*stride]
+			}
+		}
+		sum := ((r[0] + r[1]) + (r[2] + r[3])) + ((r[4] + r[5]) + (r[6] + r[7]))
+		for ; i < n; i++ {
+			sum += data[start+i*stride]
+		}
+		return sum
+
+	default:
+		half := n / 2
+		half -= half % 8
+		return pairwiseSum(data, start, stride, half) + pairwiseSum(data, start+half*stride, stride, n-half)
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: neumaierAdd – One step of Neumaier's compensated summation                 ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Adds x to *sum and collects the rounding error of that addition in               ║
+// ║   *comp. The exact total is (*sum + *comp) up to one rounding.                     ║
+// ║                                                                                    ║
+// ║   - Improvement on Kahan: also correct when |x| > |*sum|                           ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   sum = 1e16, x = 1  → *sum stays 1e16, *comp becomes 1                            ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func neumaierAdd(sum, comp *float64, x float64) {
+	t := *sum + x
+	if math.Abs(*sum) >= math.Abs(x) {
+		*comp += (*sum - t) + x
+	} else {
+		*comp += (x - t) + *sum
+	}
+	*sum = t
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: finishReduction – Apply keepdims and out= to a folded result               ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   `res` has the input's rank with reduced axes of length 1; this drops             ║
+// ║   those axes unless WithKeepDims was given, then copies into WithOut.              ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func finishReduction(res *NDArray, shape []int, reduced []bool, o options) (*NDArray, error) {
+
+	if !o.keepDims {
+		squeezed := make([]int, 0, len(shape))
+		for axis, dim := range shape {
+			if !reduced[axis] {
+				squeezed = append(squeezed, dim)
+			}
+		}
+		res = newStorage(res.data, squeezed, cStrides(squeezed))
+	}
+
+	return deliver(res, o.out)
+}
+
+// deliver copies a computed result into the caller's out= array, if any.
+func deliver(res, out *NDArray) (*NDArray, error) {
+	if out == nil {
+		return res, nil
+	}
+	if !sameShape(out.shape, res.shape) {
+		return nil, fmt.Errorf("output parameter has shape %v, expected %v", out.shape, res.shape)
+	}
+	if err := assign(out, res); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Accumulate – Running fold along one axis                                   ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `ufunc.accumulate(a, axis=axis, out=out)`:                         ║
+// ║   r[0] = a[0], r[i] = f(r[i-1], a[i]) along `axis`.                                ║
+// ║                                                                                    ║
+// ║   - Result has the same shape as `a`                                               ║
+// ║   - Honours WithOut                                                                ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   AddUfunc.Accumulate([1 2 3 4], 0) → [1 3 6 10]   (cumsum)                        ║
+// ║   MulUfunc.Accumulate([1 2 3 4], 0) → [1 2 6 24]   (cumprod)                       ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (u *Ufunc) Accumulate(a *NDArray, axis int, opts ...Option) (*NDArray, error) {
+
+	if u.nin != 2 {
+		return nil, fmt.Errorf("accumulate only supported for binary functions")
+	}
+
+	ax, err := normalizeAxis(axis, len(a.shape))
+	if err != nil {
+		return nil, err
+	}
+
+	compute, result, err := u.resolve(a.dtype, a.dtype)
+	if err != nil {
+		return nil, err
+	}
+	if compute.isTime() {
+		return nil, fmt.Errorf("accumulate not supported for dtype %s", a.dtype)
+	}
+
+	// Floats accumulate in float64; integers and complex numbers in their own dtype
+	var rd, ad []float64
+	work := compute
+	if compute.kind() == 'f' {
+		if a, err = u.floatInput(a); err != nil {
+			return nil, err
+		}
+		work = Float64
+	}
+
+	o := collectOptions(opts)
+	res := allocOf(append([]int(nil), a.shape...), work)
+	if work == Float64 {
+		rd, ad = res.floats(), a.floats()
+	}
+	stride := res.strides[ax]
+	unsigned, cplx := compute == Uint64, compute.kind() == 'c'
+
+	// Walk every position with the accumulated axis collapsed, then run along it
+	outer := append([]int(nil), a.shape...)
+	outer[ax] = 1
+	n := a.shape[ax]
+	var failure error
+	walk(outer, [][]int{res.strides, a.strides}, []int{0, a.offset}, func(offs, inner []int, count int) {
+		r, x := offs[0], offs[1]
+		for i := 0; i < count && failure == nil; i++ {
+			rr, xx := r, x
+			for j := 0; j < n && failure == nil; j++ {
+				switch {
+				case cplx && j == 0:
+					res.data.setComplex(rr, a.data.complex(xx))
+				case cplx:
+					res.data.setComplex(rr, u.complex(res.data.complex(rr-stride), a.data.complex(xx)))
+				case rd == nil && j == 0:
+					putInt(res.data, rr, intAt(a.data, xx), unsigned)
+				case rd == nil:
+					var v int64
+					v, failure = u.applyInt(intAt(res.data, rr-stride), intAt(a.data, xx), compute, o.overflow)
+					putInt(res.data, rr, v, unsigned)
+				case j == 0:
+					rd[rr] = ad[xx]
+				default:
+					rd[rr] = u.binary(rd[rr-stride], ad[xx])
+				}
+				rr += stride
+				xx += a.strides[ax]
+			}
+			r += inner[0]
+			x += inner[1]
+		}
+	})
+	if failure != nil {
+		return nil, failure
+	}
+
+	return deliver(res.withDType(result), o.out)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Outer – Apply the ufunc to every pair (a[i...], b[j...])                   ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `ufunc.outer(a, b)`: the result has shape                          ║
+// ║   a.Shape() + b.Shape(). Implemented as a broadcast: `a` is viewed with            ║
+// ║   extra trailing axes of stride 0. Honours WithOut and WithWhere.                  ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   MulUfunc.Outer([1 2 3], [10 20]) → [[10 20] [20 40] [30 60]]                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (u *Ufunc) Outer(a, b *NDArray, opts ...Option) (*NDArray, error) {
+
+	if u.nin != 2 {
+		return nil, fmt.Errorf("outer product only supported for binary functions")
+	}
+
+	if len(a.shape)+len(b.shape) > 32 {
+		return nil, fmt.Errorf("shape has too many dimensions (max 32)")
+	}
+
+	shape := append(append([]int(nil), a.shape...), make([]int, len(b.shape))...)
+	strides := append(append([]int(nil), a.strides...), make([]int, len(b.shape))...)
+	for i := len(a.shape); i < len(shape); i++ {
+		shape[i] = 1
+	}
+
+	return u.Call([]*NDArray{a.view(shape, strides, a.offset), b}, opts...)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: ReduceAt – Reductions over consecutive slices of one axis                  ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `ufunc.reduceat(a, indices, axis)`. For every i:                   ║
+// ║                                                                                    ║
+// ║   - indices[i] < indices[i+1] → reduce(a[indices[i]:indices[i+1]])                 ║
+// ║   - otherwise                 → a[indices[i]]                                      ║
+// ║   - the last entry reduces a[indices[-1]:]                                         ║
+// ║                                                                                    ║
+// ║   Honours WithOut.                                                                 ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – `axis` has length len(indices)                      ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   AddUfunc.ReduceAt([0 1 2 3 4 5 6 7], []int{0, 4, 1, 5}, 0)                       ║
+// ║   → [0+1+2+3, 4, 1+2+3+4, 5+6+7] = [6 4 10 18]                                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (u *Ufunc) ReduceAt(a *NDArray, indices []int, axis int, opts ...Option) (*NDArray, error) {
+
+	if u.nin != 2 {
+		return nil, fmt.Errorf("reduceat only supported for binary functions")
+	}
+
+	ax, err := normalizeAxis(axis, len(a.shape))
+	if err != nil {
+		return nil, err
+	}
+
+	n := a.shape[ax]
+	for _, idx := range indices {
+		if idx < 0 || idx >= n {
+			return nil, fmt.Errorf("index %d out-of-bounds in %s.reduceat [0, %d)", idx, u.name, n)
+		}
+	}
+
+	o := collectOptions(opts)
+	shape := append([]int(nil), a.shape...)
+	shape[ax] = len(indices)
+	_, result, err := u.reduceTypes(a.dtype)
+	if err != nil {
+		return nil, err
+	}
+	res := allocOf(shape, result)
+
+	sel := make([]Index, len(a.shape))
+	for i := range sel {
+		sel[i] = All
+	}
+
+	for i, start := range indices {
+		stop := n
+		if i+1 < len(indices) {
+			stop = indices[i+1]
+		}
+		if stop <= start {
+			stop = start + 1
+		}
+
+		sel[ax] = Span(start, stop)
+		block, err := a.Slice(sel...)
+		if err != nil {
+			return nil, err
+		}
+		folded, err := u.Reduce(block, WithAxes(ax), WithKeepDims())
+		if err != nil {
+			return nil, err
+		}
+
+		sel[ax] = Span(i, i+1)
+		dst, err := res.Slice(sel...)
+		if err != nil {
+			return nil, err
+		}
+		if err := assign(dst, folded); err != nil {
+			return nil, err
+		}
+	}
+
+	return deliver(res, o.out)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: At – Unbuffered in-place application on selected positions                 ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `ufunc.at(a, indices, b)`: a[indices] = f(a[indices], b),          ║
+// ║   applied one index at a time, so repeated indices accumulate instead              ║
+// ║   of overwriting each other.                                                       ║
+// ║                                                                                    ║
+// ║   - Indices select positions along axis 0 (negatives allowed)                      ║
+// ║   - `b` is broadcast to (len(indices),) + a.Shape()[1:]; pass nil for              ║
+// ║     unary ufuncs                                                                   ║
+// ║   - `a` is modified in place and must be writeable                                 ║
+// ║                                                                                    ║
+// ║   Returns: error                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a = [1 2 3 4]                                                                    ║
+// ║   AddUfunc.At(a, []int{0, 1, 1}, Scalar(10))                                       ║
+// ║   a = [11 22 3 4]     (index 1 received +10 twice)                                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (u *Ufunc) At(a *NDArray, indices []int, b *NDArray) error {
+
+	if len(a.shape) == 0 {
+		return fmt.Errorf("%s.at requires an array with at least one dimension", u.name)
+	}
+	if !a.flags.Writeable {
+		return fmt.Errorf("assignment destination is read-only")
+	}
+	if u.nin == 2 && b == nil {
+		return fmt.Errorf("second operand needed for ufunc %s", u.name)
+	}
+	if u.nin == 1 && b != nil {
+		return fmt.Errorf("second operand provided when ufunc %s is unary", u.name)
+	}
+
+	var operands *NDArray
+	if b != nil {
+		shape := append([]int{len(indices)}, a.shape[1:]...)
+		stretched, err := BroadcastTo(b, shape...)
+		if err != nil {
+			return err
+		}
+		operands = stretched
+	}
+
+	for i, idx := range indices {
+		row, err := a.Slice(At(idx))
+		if err != nil {
+			return err
+		}
+
+		inputs := []*NDArray{row}
+		if operands != nil {
+			value, err := operands.Slice(At(i))
+			if err != nil {
+				return err
+			}
+			inputs = append(inputs, value)
+		}
+
+		if _, err := u.Call(inputs, WithOut(row), WithCasting(CastingUnsafe)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   KERNELS: scalar implementations of the arithmetic operations                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Each follows the float64 semantics of the matching NumPy function:               ║
+// ║                                                                                    ║
+// ║     - Mod / FloorDiv use floored division (sign of the divisor),                   ║
+// ║       computed together by divmod exactly like `npy_divmod`                        ║
+// ║     - Division by zero yields ±Inf or NaN, never an error                          ║
+// ║     - Maximum / Minimum propagate NaN                                              ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   mod(-7, 3)      →  2      (C's fmod would give -1)                               ║
+// ║   floorDiv(-7, 3) → -3                                                             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func add(x, y float64) float64 { return x + y }
+func sub(x, y float64) float64 { return x - y }
+func mul(x, y float64) float64 { return x * y }
+func div(x, y float64) float64 { return x / y }
+func neg(x float64) float64    { return -x }
+func square(x float64) float64 { return x * x }
+
+func mod(x, y float64) float64 {
+	_, m := divmod(x, y)
+	return m
+}
+
+func floorDiv(x, y float64) float64 {
+	d, _ := divmod(x, y)
+	return d
+}
+
+func divmod(x, y float64) (float64, float64) {
+
+	m := math.Mod(x, y)
+	if y == 0 {
+		return x / y, m
+	}
+
+	d := (x - m) / y
+	if m != 0 {
+		if (y < 0) != (m < 0) {
+			m += y
+			d -= 1.0
+		}
+	} else {
+		m = math.Copysign(0, y)
+	}
+
+	if d != 0 {
+		floor := math.Floor(d)
+		if d-floor > 0.5 {
+			floor += 1.0
+		}
+		d = floor
+	} else {
+		d = math.Copysign(0, x/y)
+	}
+
+	return d, m
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   VARS: Built-in ufuncs                                                            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   The descriptors behind the arithmetic functions below. Use them to               ║
+// ║   reach the ufunc methods, e.g. AddUfunc.Accumulate for a cumulative sum.          ║
+// ║                                                                                    ║
+// ║   Binary : Add, Sub, Mul, Div, Pow, Mod, FloorDiv, Maximum, Minimum                ║
+// ║   Unary  : Negative, Abs, Sqrt, Square, Exp, Log, Sin, Cos, Conj, Angle            ║
+// ║                                                                                    ║
+// ║   Comparison and logical ufuncs produce Bool arrays:                               ║
+// ║   Binary : Equal, NotEqual, Less, LessEqual, Greater, GreaterEqual,                ║
+// ║            LogicalAnd, LogicalOr, LogicalXor                                       ║
+// ║   Unary  : LogicalNot                                                              ║
+// ║                                                                                    ║
+// ║   All but Mod and FloorDiv accept complex inputs; complex values are               ║
+// ║   ordered lexicographically (real part first) as in NumPy.                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+var (
+	AddUfunc      = temporal(widening(additive(integral(complexAware(NewBinaryUfunc("add", add, 0), complexAdd), intAdd))), timeAdd)
+	SubUfunc      = temporal(noBool(integral(complexAware(NewBinaryUfunc("subtract", sub), complexSub), intSub)), timeSub)
+	MulUfunc      = temporal(widening(integral(complexAware(NewBinaryUfunc("multiply", mul, 1), complexMul), intMul)), timeMul)
+	DivUfunc      = temporal(complexAware(NewBinaryUfunc("divide", div), complexDiv), timeDiv)
+	PowUfunc      = integral(complexAware(NewBinaryUfunc("power", math.Pow), complexPow), intPow)
+	ModUfunc      = integral(NewBinaryUfunc("remainder", mod), intMod)
+	FloorDivUfunc = integral(NewBinaryUfunc("floor_divide", floorDiv), intFloorDiv)
+	MaximumUfunc  = temporal(integral(complexAware(NewBinaryUfunc("maximum", math.Max), complexMaximum), intMaximum), timeExtreme)
+	MinimumUfunc  = temporal(integral(complexAware(NewBinaryUfunc("minimum", math.Min), complexMinimum), intMinimum), timeExtreme)
+
+	NegativeUfunc = temporal(noBool(integral(complexAware(NewUnaryUfunc("negative", neg), complexNeg), intNegative)), timeSigned)
+	AbsUfunc      = temporal(realValued(integral(complexAware(NewUnaryUfunc("absolute", math.Abs), complexAbs), intAbsolute)), timeSigned)
+	SqrtUfunc     = smallFloats(complexAware(NewUnaryUfunc("sqrt", math.Sqrt), complexUnary(cmplx.Sqrt)))
+	SquareUfunc   = integral(complexAware(NewUnaryUfunc("square", square), complexSq), intSquare)
+	ExpUfunc      = smallFloats(complexAware(NewUnaryUfunc("exp", math.Exp), complexUnary(cmplx.Exp)))
+	LogUfunc      = smallFloats(complexAware(NewUnaryUfunc("log", math.Log), complexUnary(cmplx.Log)))
+	SinUfunc      = smallFloats(complexAware(NewUnaryUfunc("sin", math.Sin), complexUnary(cmplx.Sin)))
+	CosUfunc      = smallFloats(complexAware(NewUnaryUfunc("cos", math.Cos), complexUnary(cmplx.Cos)))
+	ConjUfunc     = integral(complexAware(NewUnaryUfunc("conjugate", func(x float64) float64 { return x }), complexConj), func(x, _ int64, _ bool) (int64, int, error) { return x, 0, nil })
+	AngleUfunc    = smallFloats(realValued(complexAware(NewUnaryUfunc("angle", func(x float64) float64 { return math.Atan2(0, x) }), complexAngle)))
+
+	EqualUfunc        = temporal(predicate(integral(complexAware(NewBinaryUfunc("equal", func(x, y float64) float64 { return truth(x == y) }), complexPredicate(func(x, y complex128) bool { return x == y })), intPredicate(intEqual))), timeCompare)
+	NotEqualUfunc     = temporal(predicate(integral(complexAware(NewBinaryUfunc("not_equal", func(x, y float64) float64 { return truth(x != y) }), complexPredicate(func(x, y complex128) bool { return x != y })), intPredicate(intNotEqual))), timeCompare)
+	LessUfunc         = temporal(predicate(integral(complexAware(NewBinaryUfunc("less", func(x, y float64) float64 { return truth(x < y) }), complexPredicate(complexLess)), intPredicate(intLess))), timeCompare)
+	LessEqualUfunc    = temporal(predicate(integral(complexAware(NewBinaryUfunc("less_equal", func(x, y float64) float64 { return truth(x <= y) }), complexPredicate(func(x, y complex128) bool { return !complexLess(y, x) })), intPredicate(intLessEqual))), timeCompare)
+	GreaterUfunc      = temporal(predicate(integral(complexAware(NewBinaryUfunc("greater", func(x, y float64) float64 { return truth(x > y) }), complexPredicate(func(x, y complex128) bool { return complexLess(y, x) })), intPredicate(intGreater))), timeCompare)
+	GreaterEqualUfunc = temporal(predicate(integral(complexAware(NewBinaryUfunc("greater_equal", func(x, y float64) float64 { return truth(x >= y) }), complexPredicate(func(x, y complex128) bool { return !complexLess(x, y) })), intPredicate(intGreaterEqual))), timeCompare)
+
+	LogicalAndUfunc = predicate(integral(complexAware(NewBinaryUfunc("logical_and", func(x, y float64) float64 { return truth(x != 0 && y != 0) }, 1), complexPredicate(func(x, y complex128) bool { return x != 0 && y != 0 })), intPredicate(func(x, y int64, _ bool) bool { return x != 0 && y != 0 })))
+	LogicalOrUfunc  = predicate(integral(complexAware(NewBinaryUfunc("logical_or", func(x, y float64) float64 { return truth(x != 0 || y != 0) }, 0), complexPredicate(func(x, y complex128) bool { return x != 0 || y != 0 })), intPredicate(func(x, y int64, _ bool) bool { return x != 0 || y != 0 })))
+	LogicalXorUfunc = predicate(integral(complexAware(NewBinaryUfunc("logical_xor", func(x, y float64) float64 { return truth((x != 0) != (y != 0)) }, 0), complexPredicate(func(x, y complex128) bool { return (x != 0) != (y != 0) })), intPredicate(func(x, y int64, _ bool) bool { return (x != 0) != (y != 0) })))
+	LogicalNotUfunc = predicate(integral(complexAware(NewUnaryUfunc("logical_not", func(x float64) float64 { return truth(x == 0) }), complexPredicate(func(x, _ complex128) bool { return x == 0 })), intPredicate(func(x, _ int64, _ bool) bool { return x == 0 })))
+)
+
+// additive marks a ufunc as a sum, enabling pairwise / compensated reductions.
+func additive(u *Ufunc) *Ufunc {
+	u.additive = true
+	return u
+}
+
+// predicate marks a ufunc whose results are truth values, so they are tagged Bool.
+func predicate(u *Ufunc) *Ufunc {
+	u.predicate = true
+	return u
+}
+
+// integral gives a ufunc an exact kernel for bool and integer inputs.
+func integral(u *Ufunc, kernel intKernel) *Ufunc {
+	u.integer = kernel
+	return u
+}
+
+// complexAware gives a ufunc a kernel for complex inputs.
+func complexAware(u *Ufunc, kernel complexKernel) *Ufunc {
+	u.complex = kernel
+	return u
+}
+
+// realValued marks a ufunc whose result is real even for complex inputs.
+func realValued(u *Ufunc) *Ufunc {
+	u.realValued = true
+	return u
+}
+
+// temporal gives a ufunc its datetime64 / timedelta64 signatures.
+func temporal(u *Ufunc, signatures timeSignatures) *Ufunc {
+	u.time = signatures
+	return u
+}
+
+// smallFloats marks a float-only ufunc that, like NumPy's math functions, has a loop
+// for every float width: bool and integer inputs compute in the narrowest float
+// that holds them (float16 for int8) instead of float64.
+func smallFloats(u *Ufunc) *Ufunc {
+	u.smallFloats = true
+	return u
+}
+
+// smallestFloat is the narrowest IEEE float type that d casts to safely.
+func smallestFloat(d DType) DType {
+	for _, f := range []DType{Float16, Float32} {
+		if safeCast(d, f) {
+			return f
+		}
+	}
+	return Float64
+}
+
+// noBool marks a ufunc that NumPy refuses for booleans, such as subtract.
+func noBool(u *Ufunc) *Ufunc {
+	u.noBool = true
+	return u
+}
+
+// widening marks a ufunc whose reductions over small integers widen to 64 bits.
+func widening(u *Ufunc) *Ufunc {
+	u.widens = true
+	return u
+}
+
+// floatInput returns a as a Float64 array, converting other real dtypes and copying
+// field views (see direct); the kernels work on float64, complex values would lose
+// their imaginary part, and texts and records are not numbers.
+func (u *Ufunc) floatInput(a *NDArray) (*NDArray, error) {
+	switch {
+	case a.dtype == Float64:
+		return direct(a), nil
+	case a.dtype.kind() == 'c' || a.dtype.isText() || a.dtype.isRecord():
+		return nil, fmt.Errorf("ufunc '%s' not supported for the input type %s", u.name, a.dtype)
+	default:
+		return a.Copy().withDType(Float64), nil
+	}
+}
+
+// floatInputs applies floatInput to every input.
+func (u *Ufunc) floatInputs(inputs []*NDArray) ([]*NDArray, error) {
+	out := make([]*NDArray, len(inputs))
+	for k, in := range inputs {
+		conv, err := u.floatInput(in)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = conv
+	}
+	return out, nil
+}
+
+// truth encodes a Go bool as a Bool element.
+func truth(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Add, Sub, Mul, Div – Elementwise arithmetic between two arrays             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   a + b, a - b, a * b and a / b with broadcasting.                                 ║
+// ║                                                                                    ║
+// ║   - Shapes are broadcast with NumPy rules                                          ║
+// ║   - Inputs may be any views (sliced, transposed, broadcast)                        ║
+// ║   - The result is a new C-ordered array, unless WithOut is given                   ║
+// ║   - Accepts the same options as Ufunc.Call (WithOut, WithWhere)                    ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – error if the shapes cannot be broadcast             ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a.Shape() → [2, 3],  b.Shape() → [3]                                             ║
+// ║   c, _ := Add(a, b)    → c[i][j] = a[i][j] + b[j]                                  ║
+// ║   Add(a, b, WithOut(a)) → a += b                                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Add(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return AddUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func Sub(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return SubUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func Mul(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return MulUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func Div(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return DivUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Pow, Mod, FloorDiv – Power and floored division                            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   a ** b, a % b and a // b with broadcasting (Python semantics).                   ║
+// ║                                                                                    ║
+// ║   - Mod takes the sign of the divisor, FloorDiv rounds toward -Inf                 ║
+// ║   - a == FloorDiv(a, b) * b + Mod(a, b)                                            ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   Mod([-7 7], [3])      → [2 1]                                                    ║
+// ║   FloorDiv([-7 7], [3]) → [-3 2]                                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Pow(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return PowUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func Mod(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return ModUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func FloorDiv(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return FloorDivUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Maximum, Minimum – Elementwise larger / smaller value                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Compares the two operands position by position after broadcasting.               ║
+// ║                                                                                    ║
+// ║   - NaN wins: if either operand is NaN, the result is NaN                          ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   Maximum([1 5 3], [4 2 NaN]) → [4 5 NaN]                                          ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Maximum(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return MaximumUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func Minimum(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return MinimumUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: <Op>Scalar – Arithmetic between an array and a float64                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Convenience forms where the right operand is a plain number; the                 ║
+// ║   scalar is wrapped with Scalar() and broadcast like any 0-d array.                ║
+// ║   For a scalar on the left, call the array form: Sub(Scalar(1), a).                ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   MulScalar(a, 2)   → 2 * a                                                        ║
+// ║   PowScalar(a, 0.5) → sqrt of every element                                        ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func AddScalar(a *NDArray, s float64) (*NDArray, error)      { return Add(a, Scalar(s)) }
+func SubScalar(a *NDArray, s float64) (*NDArray, error)      { return Sub(a, Scalar(s)) }
+func MulScalar(a *NDArray, s float64) (*NDArray, error)      { return Mul(a, Scalar(s)) }
+func DivScalar(a *NDArray, s float64) (*NDArray, error)      { return Div(a, Scalar(s)) }
+func PowScalar(a *NDArray, s float64) (*NDArray, error)      { return Pow(a, Scalar(s)) }
+func ModScalar(a *NDArray, s float64) (*NDArray, error)      { return Mod(a, Scalar(s)) }
+func FloorDivScalar(a *NDArray, s float64) (*NDArray, error) { return FloorDiv(a, Scalar(s)) }
+func MaximumScalar(a *NDArray, s float64) (*NDArray, error)  { return Maximum(a, Scalar(s)) }
+func MinimumScalar(a *NDArray, s float64) (*NDArray, error)  { return Minimum(a, Scalar(s)) }
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Negative, Abs, Sqrt, Square, Exp, Log, Sin, Cos – Unary math               ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Elementwise application of the matching built-in unary ufunc.                    ║
+// ║                                                                                    ║
+// ║   - Domain errors follow IEEE rules (Sqrt(-1) → NaN, Log(0) → -Inf)                ║
+// ║   - Complex inputs use math/cmplx (Sqrt(-1+0i) → 0+1i); Abs of a                   ║
+// ║     complex array is its real-valued magnitude                                     ║
+// ║   - Accepts WithOut and WithWhere                                                  ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   Sqrt([1 4 9])           → [1 2 3]                                                ║
+// ║   Exp(a, WithOut(a))      → a = exp(a), in place                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Negative(a *NDArray, opts ...Option) (*NDArray, error) {
+	return NegativeUfunc.Call([]*NDArray{a}, opts...)
+}
+
+func Abs(a *NDArray, opts ...Option) (*NDArray, error) {
+	return AbsUfunc.Call([]*NDArray{a}, opts...)
+}
+
+func Sqrt(a *NDArray, opts ...Option) (*NDArray, error) {
+	return SqrtUfunc.Call([]*NDArray{a}, opts...)
+}
+
+func Square(a *NDArray, opts ...Option) (*NDArray, error) {
+	return SquareUfunc.Call([]*NDArray{a}, opts...)
+}
+
+func Exp(a *NDArray, opts ...Option) (*NDArray, error) {
+	return ExpUfunc.Call([]*NDArray{a}, opts...)
+}
+
+func Log(a *NDArray, opts ...Option) (*NDArray, error) {
+	return LogUfunc.Call([]*NDArray{a}, opts...)
+}
+
+func Sin(a *NDArray, opts ...Option) (*NDArray, error) {
+	return SinUfunc.Call([]*NDArray{a}, opts...)
+}
+
+func Cos(a *NDArray, opts ...Option) (*NDArray, error) {
+	return CosUfunc.Call([]*NDArray{a}, opts...)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Equal, NotEqual, Less, LessEqual, Greater, GreaterEqual – Comparisons      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   a == b, a != b, a < b, a <= b, a > b and a >= b with broadcasting.               ║
+// ║                                                                                    ║
+// ║   - The result is a Bool array                                                     ║
+// ║   - NaN compares unequal to everything, itself included                            ║
+// ║   - Accepts the same options as Ufunc.Call (WithOut, WithWhere)                    ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a = [1 5 3]                                                                      ║
+// ║   Greater(a, Scalar(2))  → [false true true]                                       ║
+// ║   Equal(a, a.T())        → [true true true]                                        ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Equal(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return EqualUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func NotEqual(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return NotEqualUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func Less(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return LessUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func LessEqual(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return LessEqualUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func Greater(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return GreaterUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func GreaterEqual(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return GreaterEqualUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: LogicalAnd, LogicalOr, LogicalXor, LogicalNot – Truth-value logic          ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.logical_and` & co.                                             ║
+// ║                                                                                    ║
+// ║   - Any non-zero element (NaN included) counts as true                             ║
+// ║   - Inputs may be Bool or Float64; the result is a Bool array                      ║
+// ║   - Accepts the same options as Ufunc.Call (WithOut, WithWhere)                    ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   LogicalAnd([true false], [true true]) → [true false]                             ║
+// ║   LogicalNot([0 2.5])                   → [true false]                             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func LogicalAnd(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return LogicalAndUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func LogicalOr(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return LogicalOrUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func LogicalXor(a, b *NDArray, opts ...Option) (*NDArray, error) {
+	return LogicalXorUfunc.Call([]*NDArray{a, b}, opts...)
+}
+
+func LogicalNot(a *NDArray, opts ...Option) (*NDArray, error) {
+	return LogicalNotUfunc.Call([]*NDArray{a}, opts...)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: reductionAxes – Resolve the axes of an NDArray reduction method            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Unlike Ufunc.Reduce (axis 0 by default), the reduction methods reduce            ║
+// ║   every axis unless WithAxes says otherwise, like `np.sum(a)`.                     ║
+// ║                                                                                    ║
+// ║   Returns: (options, []bool, error) – options plus the per-axis mask               ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) reductionAxes(opts []Option) (options, []bool, error) {
+
+	o := collectOptions(opts)
+	if !o.hasAxes {
+		o.axes = make([]int, len(a.shape))
+		for axis := range o.axes {
+			o.axes[axis] = axis
+		}
+		o.hasAxes = true
+	}
+
+	reduced, err := reducedAxes(o.axes, len(a.shape))
+	return o, reduced, err
+}
+
+// withAllAxes puts "every axis" in front of the caller's options, so an
+// explicit WithAxes still wins.
+func (a *NDArray) withAllAxes(opts []Option) []Option {
+	axes := make([]int, len(a.shape))
+	for axis := range axes {
+		axes[axis] = axis
+	}
+	return append([]Option{WithAxes(axes...)}, opts...)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Sum, Prod, Min, Max – Axis-aware reductions                                ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a.sum()`, `a.prod()`, `a.min()`, `a.max()`.                       ║
+// ║                                                                                    ║
+// ║   - Reduce every axis by default; WithAxes picks some (negatives allowed)          ║
+// ║   - WithKeepDims keeps reduced axes with length 1                                  ║
+// ║   - WithOut, WithWhere and WithInitial behave as in Ufunc.Reduce                   ║
+// ║   - Sum is pairwise by default, see Summation for the alternatives                 ║
+// ║   - Sum and Prod of bool or integer arrays are exact and widen to Int64            ║
+// ║     (Uint64 for unsigned); Min and Max keep the dtype                              ║
+// ║   - Min / Max of an empty selection is an error (no identity)                      ║
+// ║   - Works on any view, contiguous or not                                           ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – 0-d when every axis is reduced                      ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a = [[1 2 3] [4 5 6]]                                                            ║
+// ║   a.Sum()                            → 21 (shape [])                               ║
+// ║   a.Sum(WithAxes(-1))                → [6 15]                                      ║
+// ║   a.Max(WithAxes(0), WithKeepDims()) → [[4 5 6]]                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Sum(opts ...Option) (*NDArray, error) {
+	return AddUfunc.Reduce(a, a.withAllAxes(opts)...)
+}
+
+func (a *NDArray) Prod(opts ...Option) (*NDArray, error) {
+	return MulUfunc.Reduce(a, a.withAllAxes(opts)...)
+}
+
+func (a *NDArray) Min(opts ...Option) (*NDArray, error) {
+	return MinimumUfunc.Reduce(a, a.withAllAxes(opts)...)
+}
+
+func (a *NDArray) Max(opts ...Option) (*NDArray, error) {
+	return MaximumUfunc.Reduce(a, a.withAllAxes(opts)...)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Any, All – Test whether any / all elements are true                        ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a.any()` and `a.all()`: logical-or / logical-and                  ║
+// ║   reductions.                                                                      ║
+// ║                                                                                    ║
+// ║   - Reduce every axis by default; WithAxes picks some                              ║
+// ║   - WithKeepDims, WithOut and WithWhere behave as in Sum                           ║
+// ║   - Any of an empty selection is false, All is true                                ║
+// ║   - The result is a Bool array                                                     ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – 0-d when every axis is reduced                      ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   m = [[true false] [true true]]                                                   ║
+// ║   m.All()              → false (shape [])                                          ║
+// ║   m.All(WithAxes(1))   → [false true]                                              ║
+// ║   m.Any(WithAxes(0))   → [true true]                                               ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Any(opts ...Option) (*NDArray, error) {
+	return LogicalOrUfunc.Reduce(a, a.withAllAxes(opts)...)
+}
+
+func (a *NDArray) All(opts ...Option) (*NDArray, error) {
+	return LogicalAndUfunc.Reduce(a, a.withAllAxes(opts)...)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Mean – Arithmetic mean along axes                                          ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Sum divided by the number of reduced elements.                                   ║
+// ║                                                                                    ║
+// ║   - Honours WithAxes, WithKeepDims and WithOut                                     ║
+// ║   - The mean of an empty selection is NaN, as in NumPy                             ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a = [[1 2 3] [4 5 6]]                                                            ║
+// ║   a.Mean()               → 3.5                                                     ║
+// ║   a.Mean(WithAxes(0))    → [2.5 3.5 4.5]                                           ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Mean(opts ...Option) (*NDArray, error) {
+
+	o, reduced, err := a.reductionAxes(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.meanOver(reduced, o.keepDims, o.summation)
+	if err != nil {
+		return nil, err
+	}
+	return deliver(res, o.out)
+}
+
+// meanOver sums over the masked axes and divides by their element count.
+func (a *NDArray) meanOver(reduced []bool, keepDims bool, summation Summation) (*NDArray, error) {
+
+	axes, count := []int{}, 1
+	for axis, r := range reduced {
+		if r {
+			axes = append(axes, axis)
+			count *= a.shape[axis]
+		}
+	}
+
+	// Bool and integer means are taken in float64, as in NumPy; timedeltas in ticks
+	if k := a.dtype.kind(); k != 'f' && k != 'c' && k != 'm' && !a.dtype.isText() && !a.dtype.isRecord() {
+		a = a.Copy().withDType(Float64)
+	}
+
+	opts := []Option{WithAxes(axes...), WithSummation(summation)}
+	if keepDims {
+		opts = append(opts, WithKeepDims())
+	}
+	sum, err := AddUfunc.Reduce(a, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return Div(sum, Scalar(float64(count)), WithOut(sum))
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Var, Std – Variance and standard deviation along axes                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Var = Σ (x - mean)² / (N - ddof), Std = sqrt(Var).                               ║
+// ║                                                                                    ║
+// ║   - WithDDof(1) gives the unbiased sample estimate (default ddof is 0)             ║
+// ║   - Honours WithAxes, WithKeepDims and WithOut                                     ║
+// ║   - Two passes (mean first, then squared deviations) for stability                 ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a = [1 2 3 4]                                                                    ║
+// ║   a.Var()            → 1.25                                                        ║
+// ║   a.Var(WithDDof(1)) → 1.6666…                                                     ║
+// ║   a.Std()            → 1.1180…                                                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Var(opts ...Option) (*NDArray, error) {
+
+	o, reduced, err := a.reductionAxes(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.varianceOver(reduced, o.keepDims, o.ddof, o.summation)
+	if err != nil {
+		return nil, err
+	}
+	return deliver(res, o.out)
+}
+
+func (a *NDArray) Std(opts ...Option) (*NDArray, error) {
+
+	o, reduced, err := a.reductionAxes(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.varianceOver(reduced, o.keepDims, o.ddof, o.summation)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := Sqrt(res, WithOut(res)); err != nil {
+		return nil, err
+	}
+	return deliver(res, o.out)
+}
+
+// varianceOver computes Σ (x - mean)² / (N - ddof) over the masked axes.
+func (a *NDArray) varianceOver(reduced []bool, keepDims bool, ddof int, summation Summation) (*NDArray, error) {
+
+	mean, err := a.meanOver(reduced, true, summation)
+	if err != nil {
+		return nil, err
+	}
+
+	dev, err := Sub(a, mean)
+	if err != nil {
+		return nil, err
+	}
+	if dev.dtype.kind() == 'c' {
+		// |x - mean|², so complex variances are real
+		if dev, err = Abs(dev); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := Square(dev, WithOut(dev)); err != nil {
+		return nil, err
+	}
+
+	axes, count := []int{}, 1
+	for axis, r := range reduced {
+		if r {
+			axes = append(axes, axis)
+			count *= a.shape[axis]
+		}
+	}
+
+	opts := []Option{WithAxes(axes...), WithSummation(summation)}
+	if keepDims {
+		opts = append(opts, WithKeepDims())
+	}
+	sum, err := AddUfunc.Reduce(dev, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return Div(sum, Scalar(float64(count-ddof)), WithOut(sum))
+}
@@ -0,0 +1,478 @@
+package ndarray_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestAddBroadcast(t *testing.T) {
+	a := seq(t, 2, 3)
+	b := seq(t, 3)
+
+	c, err := ndarray.Add(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := c.String(); got != "NDArray(shape=[2 3], data=[0 2 4 3 5 7])" {
+		t.Errorf("unexpected sum: %s", got)
+	}
+
+	if _, err := ndarray.Add(a, seq(t, 2)); err == nil {
+		t.Error("expected broadcast error, got nil")
+	}
+}
+
+func TestArithmeticOnViews(t *testing.T) {
+	a := seq(t, 3, 4)
+
+	// Column 1 viewed as a [3] array with stride 4
+	col, _ := a.Slice(ndarray.All, ndarray.At(1))
+	rev, _ := a.Slice(ndarray.At(0), ndarray.Span(ndarray.None, 0, -1))
+
+	c, err := ndarray.Mul(col, rev)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// [1 5 9] * [3 2 1]
+	if got := c.String(); got != "NDArray(shape=[3], data=[3 10 9])" {
+		t.Errorf("unexpected product: %s", got)
+	}
+}
+
+func TestScalarOperations(t *testing.T) {
+	a := seq(t, 4)
+
+	tests := []struct {
+		name string
+		fn   func(*ndarray.NDArray, float64) (*ndarray.NDArray, error)
+		s    float64
+		want string
+	}{
+		{"AddScalar", ndarray.AddScalar, 1, "[1 2 3 4]"},
+		{"SubScalar", ndarray.SubScalar, 1, "[-1 0 1 2]"},
+		{"MulScalar", ndarray.MulScalar, 2, "[0 2 4 6]"},
+		{"DivScalar", ndarray.DivScalar, 2, "[0 0.5 1 1.5]"},
+		{"PowScalar", ndarray.PowScalar, 2, "[0 1 4 9]"},
+		{"MaximumScalar", ndarray.MaximumScalar, 2, "[2 2 2 3]"},
+		{"MinimumScalar", ndarray.MinimumScalar, 2, "[0 1 2 2]"},
+	}
+
+	for _, tt := range tests {
+		c, err := tt.fn(a, tt.s)
+		if err != nil {
+			t.Fatalf("%s failed: %v", tt.name, err)
+		}
+		if got := c.String(); got != "NDArray(shape=[4], data="+tt.want+")" {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestModFloorDivSemantics(t *testing.T) {
+	a, _ := ndarray.SubScalar(seq(t, 4), 2) // [-2 -1 0 1]
+	a, _ = ndarray.MulScalar(a, 3.5)        // [-7 -3.5 0 3.5]
+
+	m, _ := ndarray.ModScalar(a, 3)
+	if got := m.String(); got != "NDArray(shape=[4], data=[2 2.5 0 0.5])" {
+		t.Errorf("unexpected mod: %s", got)
+	}
+
+	d, _ := ndarray.FloorDivScalar(a, 3)
+	if got := d.String(); got != "NDArray(shape=[4], data=[-3 -2 0 1])" {
+		t.Errorf("unexpected floor division: %s", got)
+	}
+
+	z, _ := ndarray.DivScalar(a, 0)
+	if val, _ := z.Get(0); !math.IsInf(val, -1) {
+		t.Errorf("expected -Inf dividing by zero, got %f", val)
+	}
+}
+
+func TestUfuncCallOutAndWhere(t *testing.T) {
+	a := seq(t, 2, 3)
+	mask, _ := ndarray.ModScalar(seq(t, 3), 2) // [0 1 0]
+
+	out, _ := ndarray.New(2, 3)
+	res, err := ndarray.AddUfunc.Call([]*ndarray.NDArray{a, ndarray.Scalar(10)}, ndarray.WithOut(out), ndarray.WithWhere(mask))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if res != out {
+		t.Error("expected the out array to be returned")
+	}
+	if got := out.String(); got != "NDArray(shape=[2 3], data=[0 11 0 0 14 0])" {
+		t.Errorf("unexpected masked result: %s", got)
+	}
+
+	wrong, _ := ndarray.New(3)
+	if _, err := ndarray.Add(a, a, ndarray.WithOut(wrong)); err == nil {
+		t.Error("expected error for mismatched out shape, got nil")
+	}
+}
+
+func TestUfuncInPlaceOnOverlappingView(t *testing.T) {
+	a := seq(t, 5)
+
+	// a[1:] += a[:-1] must read the original values
+	dst, _ := a.Slice(ndarray.Span(1, ndarray.None))
+	src, _ := a.Slice(ndarray.Span(ndarray.None, -1))
+	if _, err := ndarray.Add(dst, src, ndarray.WithOut(dst)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := a.String(); got != "NDArray(shape=[5], data=[0 1 3 5 7])" {
+		t.Errorf("unexpected in-place result: %s", got)
+	}
+}
+
+func TestCustomUfunc(t *testing.T) {
+	clampScale := ndarray.NewBinaryUfunc("clamp_scale", func(x, k float64) float64 {
+		return math.Min(math.Max(x, 0), 1) * k
+	})
+
+	x, _ := ndarray.SubScalar(seq(t, 4), 1) // [-1 0 1 2]
+	y, err := clampScale.Call([]*ndarray.NDArray{x, ndarray.Scalar(10)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := y.String(); got != "NDArray(shape=[4], data=[0 0 10 10])" {
+		t.Errorf("unexpected custom ufunc result: %s", got)
+	}
+
+	if _, err := clampScale.Call([]*ndarray.NDArray{x}); err == nil {
+		t.Error("expected arity error, got nil")
+	}
+}
+
+func TestUfuncReduceAccumulate(t *testing.T) {
+	a := seq(t, 2, 3)
+
+	r, err := ndarray.AddUfunc.Reduce(a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := r.String(); got != "NDArray(shape=[3], data=[3 5 7])" {
+		t.Errorf("unexpected reduce: %s", got)
+	}
+
+	r, _ = ndarray.MaximumUfunc.Reduce(a, ndarray.WithAxes(-1), ndarray.WithKeepDims())
+	if got := r.String(); got != "NDArray(shape=[2 1], data=[2 5])" {
+		t.Errorf("unexpected keepdims reduce: %s", got)
+	}
+
+	r, _ = ndarray.SubUfunc.Reduce(a, ndarray.WithAxes(1))
+	if got := r.String(); got != "NDArray(shape=[2], data=[-3 -6])" {
+		t.Errorf("unexpected ordered reduce: %s", got)
+	}
+
+	if _, err := ndarray.MaximumUfunc.Reduce(a, ndarray.WithWhere(a)); err == nil {
+		t.Error("expected error for where without initial, got nil")
+	}
+
+	c, _ := ndarray.AddUfunc.Accumulate(a, 1)
+	if got := c.String(); got != "NDArray(shape=[2 3], data=[0 1 3 3 7 12])" {
+		t.Errorf("unexpected accumulate: %s", got)
+	}
+}
+
+func TestUfuncOuterReduceAtAt(t *testing.T) {
+	o, err := ndarray.MulUfunc.Outer(seq(t, 3), seq(t, 2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := o.String(); got != "NDArray(shape=[3 2], data=[0 0 0 1 0 2])" {
+		t.Errorf("unexpected outer: %s", got)
+	}
+
+	r, err := ndarray.AddUfunc.ReduceAt(seq(t, 8), []int{0, 4, 1, 5}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := r.String(); got != "NDArray(shape=[4], data=[6 4 10 18])" {
+		t.Errorf("unexpected reduceat: %s", got)
+	}
+
+	a := seq(t, 4)
+	if err := ndarray.AddUfunc.At(a, []int{0, 1, 1, -1}, ndarray.Scalar(10)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := a.String(); got != "NDArray(shape=[4], data=[10 21 2 13])" {
+		t.Errorf("unexpected at: %s", got)
+	}
+
+	if err := ndarray.NegativeUfunc.At(a, []int{2}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if val, _ := a.Get(2); val != -2 {
+		t.Errorf("expected -2 after unary at, got %f", val)
+	}
+}
+
+func TestReductions(t *testing.T) {
+	a := seq(t, 2, 3)
+
+	tests := []struct {
+		name string
+		fn   func(...ndarray.Option) (*ndarray.NDArray, error)
+		opts []ndarray.Option
+		want string
+	}{
+		{"Sum", a.Sum, nil, "NDArray(shape=[], data=[15])"},
+		{"Sum axis -1", a.Sum, []ndarray.Option{ndarray.WithAxes(-1)}, "NDArray(shape=[2], data=[3 12])"},
+		{"Prod axis 0", a.Prod, []ndarray.Option{ndarray.WithAxes(0)}, "NDArray(shape=[3], data=[0 4 10])"},
+		{"Min keepdims", a.Min, []ndarray.Option{ndarray.WithAxes(1), ndarray.WithKeepDims()}, "NDArray(shape=[2 1], data=[0 3])"},
+		{"Max", a.Max, nil, "NDArray(shape=[], data=[5])"},
+		{"Mean axis 0", a.Mean, []ndarray.Option{ndarray.WithAxes(0)}, "NDArray(shape=[3], data=[1.5 2.5 3.5])"},
+		{"Var axis 1", a.Var, []ndarray.Option{ndarray.WithAxes(1)}, "NDArray(shape=[2], data=[0.6666666666666666 0.6666666666666666])"},
+		{"Var ddof", a.Var, []ndarray.Option{ndarray.WithAxes(1), ndarray.WithDDof(1)}, "NDArray(shape=[2], data=[1 1])"},
+		{"Std keepdims", a.Std, []ndarray.Option{ndarray.WithKeepDims(), ndarray.WithDDof(1)}, "NDArray(shape=[1 1], data=[1.8708286933869707])"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.fn(tt.opts...)
+		if err != nil {
+			t.Fatalf("%s failed: %v", tt.name, err)
+		}
+		if got.String() != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReductionsOnViews(t *testing.T) {
+	a := seq(t, 4, 5)
+
+	// Every other row, reversed columns: a non-contiguous view
+	v, _ := a.Slice(ndarray.Span(ndarray.None, ndarray.None, 2), ndarray.Span(ndarray.None, ndarray.None, -1))
+
+	s, err := v.Sum(ndarray.WithAxes(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.String(); got != "NDArray(shape=[5], data=[18 16 14 12 10])" {
+		t.Errorf("unexpected sum over view: %s", got)
+	}
+
+	m, _ := v.Mean()
+	if val, _ := m.Item(); val != 7 {
+		t.Errorf("expected mean 7, got %f", val)
+	}
+
+	if _, err := a.Sum(ndarray.WithAxes(0, 0)); err == nil {
+		t.Error("expected duplicate axis error, got nil")
+	}
+	if _, err := a.Sum(ndarray.WithAxes(2)); err == nil {
+		t.Error("expected axis out of bounds error, got nil")
+	}
+}
+
+// fromValues builds a 1-D array holding values.
+func fromValues(t *testing.T, values []float64) *ndarray.NDArray {
+	t.Helper()
+
+	a, err := ndarray.New(len(values))
+	if err != nil {
+		t.Fatalf("error creating array: %v", err)
+	}
+	for i, v := range values {
+		if err := a.Set(v, i); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+	return a
+}
+
+// exactSum adds values with enough precision to be exact, then rounds once.
+func exactSum(values []float64) float64 {
+	sum := new(big.Float).SetPrec(4096)
+	for _, v := range values {
+		sum.Add(sum, new(big.Float).SetPrec(4096).SetFloat64(v))
+	}
+	f, _ := sum.Float64()
+	return f
+}
+
+func sumWith(t *testing.T, a *ndarray.NDArray, mode ndarray.Summation) float64 {
+	t.Helper()
+
+	s, err := a.Sum(ndarray.WithSummation(mode))
+	if err != nil {
+		t.Fatalf("Sum failed: %v", err)
+	}
+	val, _ := s.Item()
+	return val
+}
+
+func TestSummationErrorBounds(t *testing.T) {
+	const n = 1 << 20
+	const eps = 0x1p-52
+
+	rng := rand.New(rand.NewSource(1))
+	values := make([]float64, n)
+	absSum := 0.0
+	for i := range values {
+		values[i] = (rng.Float64() - 0.3) * math.Pow(10, float64(rng.Intn(9)-4))
+		absSum += math.Abs(values[i])
+	}
+	exact := exactSum(values)
+	a := fromValues(t, values)
+
+	pairwise := math.Abs(sumWith(t, a, ndarray.SumPairwise) - exact)
+	if bound := eps * math.Log2(n) * absSum; pairwise > bound {
+		t.Errorf("pairwise error %g exceeds bound %g", pairwise, bound)
+	}
+
+	kahan := math.Abs(sumWith(t, a, ndarray.SumKahan) - exact)
+	if bound := 2*eps*math.Abs(exact) + n*eps*eps*absSum; kahan > bound {
+		t.Errorf("compensated error %g exceeds bound %g", kahan, bound)
+	}
+}
+
+func TestSummationIllConditioned(t *testing.T) {
+	const n = 1 << 16
+
+	// 1 followed by many values below half an ulp of 1
+	values := make([]float64, n+1)
+	values[0] = 1
+	for i := 1; i <= n; i++ {
+		values[i] = 1e-16
+	}
+	exact := exactSum(values)
+	a := fromValues(t, values)
+
+	if naive := sumWith(t, a, ndarray.SumNaive); naive != 1 {
+		t.Errorf("expected naive summation to lose the small terms, got %.17g", naive)
+	}
+
+	if kahan := sumWith(t, a, ndarray.SumKahan); kahan != exact {
+		t.Errorf("compensated sum = %.17g, want %.17g", kahan, exact)
+	}
+
+	if pairwise := sumWith(t, a, ndarray.SumPairwise); math.Abs(pairwise-exact) > 1e-3*(exact-1) {
+		t.Errorf("pairwise sum = %.17g, want close to %.17g", pairwise, exact)
+	}
+
+	// Same data down the first column of a [n+1, 2] array: a strided run
+	interleaved := make([]float64, 2*(n+1))
+	for i, v := range values {
+		interleaved[2*i] = v
+	}
+	grid, _ := fromValues(t, interleaved).Reshape(n+1, 2)
+	col, _ := grid.Slice(ndarray.All, ndarray.At(0))
+	s, _ := col.Sum(ndarray.WithSummation(ndarray.SumKahan))
+	if val, _ := s.Item(); val != exact {
+		t.Errorf("compensated column sum = %.17g, want %.17g", val, exact)
+	}
+}
+
+func TestComparisons(t *testing.T) {
+	a, _ := ndarray.FromSlice([]float64{1, 5, 3, math.NaN()})
+	b := ndarray.Scalar(3)
+
+	tests := []struct {
+		name string
+		fn   func(a, b *ndarray.NDArray, opts ...ndarray.Option) (*ndarray.NDArray, error)
+		want string
+	}{
+		{"Equal", ndarray.Equal, "[false false true false]"},
+		{"NotEqual", ndarray.NotEqual, "[true true false true]"},
+		{"Less", ndarray.Less, "[true false false false]"},
+		{"LessEqual", ndarray.LessEqual, "[true false true false]"},
+		{"Greater", ndarray.Greater, "[false true false false]"},
+		{"GreaterEqual", ndarray.GreaterEqual, "[false true true false]"},
+	}
+
+	for _, tt := range tests {
+		res, err := tt.fn(a, b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if res.DType() != ndarray.Bool {
+			t.Errorf("%s: expected bool dtype, got %s", tt.name, res.DType())
+		}
+		if got := res.String(); got != "NDArray(shape=[4], data="+tt.want+")" {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	// Broadcasting a column against a row
+	m, _ := ndarray.Less(seq(t, 3, 1), seq(t, 3))
+	if got := m.String(); got != "NDArray(shape=[3 3], data=[false true true false false true false false false])" {
+		t.Errorf("unexpected broadcast comparison: %s", got)
+	}
+	if m.T().DType() != ndarray.Bool {
+		t.Error("expected views of a bool array to stay bool")
+	}
+
+	if sum, _ := ndarray.Add(m, m); sum.DType() != ndarray.Bool {
+		t.Errorf("expected bool arithmetic to stay bool, got %s", sum.DType())
+	}
+}
+
+func TestLogicalOps(t *testing.T) {
+	x, _ := ndarray.FromSlice([]float64{0, 0, 2, math.NaN()})
+	y, _ := ndarray.FromSlice([]float64{0, 1, 0, -1})
+
+	and, _ := ndarray.LogicalAnd(x, y)
+	or, _ := ndarray.LogicalOr(x, y)
+	xor, _ := ndarray.LogicalXor(x, y)
+	not, _ := ndarray.LogicalNot(x)
+
+	for name, tt := range map[string]struct {
+		got  *ndarray.NDArray
+		want string
+	}{
+		"and": {and, "[false false false true]"},
+		"or":  {or, "[false true true true]"},
+		"xor": {xor, "[false true true false]"},
+		"not": {not, "[true true false false]"},
+	} {
+		if got := fmt.Sprint(tt.got); got != "NDArray(shape=[4], data="+tt.want+")" {
+			t.Errorf("%s: got %s, want %s", name, got, tt.want)
+		}
+	}
+}
+
+func TestAnyAll(t *testing.T) {
+	m, _ := ndarray.Greater(seq(t, 2, 3), ndarray.Scalar(0))
+
+	all, err := m.All()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := all.String(); got != "NDArray(shape=[], data=[false])" {
+		t.Errorf("unexpected All: %s", got)
+	}
+
+	rows, _ := m.All(ndarray.WithAxes(-1))
+	if got := rows.String(); got != "NDArray(shape=[2], data=[false true])" {
+		t.Errorf("unexpected All over rows: %s", got)
+	}
+
+	cols, _ := m.Any(ndarray.WithAxes(0), ndarray.WithKeepDims())
+	if got := cols.String(); got != "NDArray(shape=[1 3], data=[true true true])" {
+		t.Errorf("unexpected Any over columns: %s", got)
+	}
+
+	// Works on float input, and on empty selections
+	if anyVal, _ := seq(t, 3).Any(); anyVal.String() != "NDArray(shape=[], data=[true])" {
+		t.Errorf("unexpected Any of float input: %s", anyVal)
+	}
+	empty, _ := ndarray.FromSlice(nil)
+	if a, _ := empty.Any(); a.String() != "NDArray(shape=[], data=[false])" {
+		t.Errorf("expected Any of empty to be false, got %s", a)
+	}
+	if a, _ := empty.All(); a.String() != "NDArray(shape=[], data=[true])" {
+		t.Errorf("expected All of empty to be true, got %s", a)
+	}
+}
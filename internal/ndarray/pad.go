@@ -0,0 +1,191 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║     ██████╗  █████╗ ██████╗                                                        ║
+// ║     ██╔══██╗██╔══██╗██╔══██╗                                                       ║
+// ║     ██████╔╝███████║██║  ██║                                                       ║
+// ║     ██╔═══╝ ██╔══██║██║  ██║                                                       ║
+// ║     ██║     ██║  ██║██████╔╝                                                       ║
+// ║     ╚═╝     ╚═╝  ╚═╝╚═════╝                                                        ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Padding for NDArray: constant, edge, reflect, symmetric,                          ║
+// ║  wrap and linear-ramp modes with per-axis widths.                                  ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/pad.go                   ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"fmt"
+	"math"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: PadMode – How Pad fills the new border                                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Mirrors the `mode=` argument of `np.pad`, shown for [1 2 3] padded               ║
+// ║   by 2 on each side:                                                               ║
+// ║                                                                                    ║
+// ║     - PadConstant   : a fixed value (WithConstantValues)   0 0|1 2 3|0 0           ║
+// ║     - PadEdge       : the edge value repeated              1 1|1 2 3|3 3           ║
+// ║     - PadReflect    : mirror, edge not repeated            3 2|1 2 3|2 1           ║
+// ║     - PadSymmetric  : mirror, edge repeated                2 1|1 2 3|3 2           ║
+// ║     - PadWrap       : the opposite end, periodically       2 3|1 2 3|1 2           ║
+// ║     - PadLinearRamp : ramp from an end value (WithEndValues) to the edge           ║
+// ║                                                            0 .5|1 2 3|1.5 0        ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type PadMode int
+
+const (
+	PadConstant PadMode = iota
+	PadEdge
+	PadReflect
+	PadSymmetric
+	PadWrap
+	PadLinearRamp
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Pad – Grow an array by a border on each axis                               ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.pad(a, pad_width, mode)`.                                      ║
+// ║                                                                                    ║
+// ║   - widths[i] = {before, after} for axis i; a single pair is used for              ║
+// ║     every axis. Widths must be non-negative                                        ║
+// ║   - Axes are padded one after the other, so corners are filled from                ║
+// ║     the already padded earlier axes, exactly as NumPy does                         ║
+// ║   - WithConstantValues(before, after) sets PadConstant's values                    ║
+// ║     (default 0); WithEndValues(before, after) sets PadLinearRamp's                 ║
+// ║     outer values (default 0)                                                       ║
+// ║   - Modes other than PadConstant cannot extend an empty axis                       ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   Pad([1 2 3], [][2]int{{2, 1}}, PadReflect)      → [3 2 1 2 3 2]                  ║
+// ║   Pad(img, [][2]int{{1, 1}, {0, 0}}, PadConstant,                                  ║
+// ║       WithConstantValues(-1, -1))                  → one row of -1 above/below     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Pad(a *NDArray, widths [][2]int, mode PadMode, opts ...Option) (*NDArray, error) {
+
+	ndim := len(a.shape)
+	switch {
+	case len(widths) == 1:
+		pair := widths[0]
+		widths = make([][2]int, ndim)
+		for i := range widths {
+			widths[i] = pair
+		}
+	case len(widths) != ndim:
+		return nil, fmt.Errorf("pad widths for %d axes do not match array of dimension %d", len(widths), ndim)
+	}
+
+	shape := make([]int, ndim)
+	for i, w := range widths {
+		if w[0] < 0 || w[1] < 0 {
+			return nil, fmt.Errorf("index can't contain negative values")
+		}
+		if a.shape[i] == 0 && w[0]+w[1] > 0 && mode != PadConstant {
+			return nil, fmt.Errorf("can't extend empty axis %d using modes other than 'constant'", i)
+		}
+		shape[i] = w[0] + a.shape[i] + w[1]
+	}
+
+	// Copy the input into the middle of the result
+	out := allocOf(shape, a.dtype)
+	offset := 0
+	for i, w := range widths {
+		offset += w[0] * out.strides[i]
+	}
+	if err := assign(out.view(a.shape, out.strides, offset), a); err != nil {
+		return nil, err
+	}
+
+	o := collectOptions(opts)
+	for axis, w := range widths {
+		padAxis(out, axis, w[0], a.shape[axis], w[1], mode, o)
+	}
+	return out, nil
+}
+
+// padAxis fills the before/after border of one axis; the original data spans [start, start+n).
+func padAxis(out *NDArray, axis, start, n, after int, mode PadMode, o options) {
+
+	for i := 0; i < start+after; i++ {
+		// p is the padded position, d its signed distance into the border
+		p, d, side := start-1-i, i+1, 0
+		if i >= start {
+			p, d, side = start+n+(i-start), i-start+1, 1
+		}
+		dst := axisSlab(out, axis, p, 1)
+
+		switch mode {
+		case PadConstant:
+			_ = dst.Fill(o.padConstant[side])
+
+		case PadLinearRamp:
+			edge := start
+			width := start
+			if side == 1 {
+				edge, width = start+n-1, after
+			}
+			// Linspace(end, edge, width, endpoint=false), read from the outside in
+			frac := float64(width-d) / float64(width)
+			end := o.padEnd[side]
+			src := axisSlab(out, axis, edge, 1)
+			integral := out.dtype.kind() == 'i' || out.dtype.kind() == 'u'
+			walk(dst.shape, [][]int{dst.strides, src.strides}, []int{dst.offset, src.offset},
+				func(offs, inner []int, m int) {
+					x, e := offs[0], offs[1]
+					for k := 0; k < m; k++ {
+						v := end + (out.data.float(e)-end)*frac
+						if integral {
+							// NumPy rounds the ramp for integer arrays
+							v = math.Round(v)
+						}
+						out.data.setFloat(x, v)
+						x += inner[0]
+						e += inner[1]
+					}
+				})
+
+		default:
+			_ = assign(dst, axisSlab(out, axis, start+padSource(p-start, n, mode), 1))
+		}
+	}
+}
+
+// padSource maps a position relative to the data start onto the data index [0, n) it copies.
+func padSource(rel, n int, mode PadMode) int {
+
+	if mode == PadEdge || n == 1 {
+		return min(max(rel, 0), n-1)
+	}
+
+	switch mode {
+	case PadWrap:
+		return ((rel % n) + n) % n
+	case PadReflect:
+		period := 2 * (n - 1)
+		r := ((rel % period) + period) % period
+		if r >= n {
+			r = period - r
+		}
+		return r
+	default: // PadSymmetric
+		period := 2 * n
+		r := ((rel % period) + period) % period
+		if r >= n {
+			r = period - 1 - r
+		}
+		return r
+	}
+}
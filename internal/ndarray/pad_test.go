@@ -0,0 +1,86 @@
+package ndarray_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestPad1D(t *testing.T) {
+	a, _ := ndarray.FromSlice([]float64{1, 2, 3})
+
+	tests := []struct {
+		name   string
+		widths [][2]int
+		mode   ndarray.PadMode
+		opts   []ndarray.Option
+		want   string
+	}{
+		{"constant", [][2]int{{2, 1}}, ndarray.PadConstant, []ndarray.Option{ndarray.WithConstantValues(-1, 9)}, "[-1 -1 1 2 3 9]"},
+		{"edge", [][2]int{{2, 2}}, ndarray.PadEdge, nil, "[1 1 1 2 3 3 3]"},
+		{"reflect", [][2]int{{2, 2}}, ndarray.PadReflect, nil, "[3 2 1 2 3 2 1]"},
+		{"symmetric", [][2]int{{2, 2}}, ndarray.PadSymmetric, nil, "[2 1 1 2 3 3 2]"},
+		{"wrap", [][2]int{{2, 2}}, ndarray.PadWrap, nil, "[2 3 1 2 3 1 2]"},
+		{"linear ramp", [][2]int{{2, 2}}, ndarray.PadLinearRamp, nil, "[0 0.5 1 2 3 1.5 0]"},
+		{"linear ramp ends", [][2]int{{2, 1}}, ndarray.PadLinearRamp, []ndarray.Option{ndarray.WithEndValues(5, -3)}, "[5 3 1 2 3 -3]"},
+		{"wide reflect", [][2]int{{5, 5}}, ndarray.PadReflect, nil, "[2 1 2 3 2 1 2 3 2 1 2 3 2]"},
+		{"wide symmetric", [][2]int{{5, 5}}, ndarray.PadSymmetric, nil, "[2 3 3 2 1 1 2 3 3 2 1 1 2]"},
+		{"wide wrap", [][2]int{{4, 4}}, ndarray.PadWrap, nil, "[3 1 2 3 1 2 3 1 2 3 1]"},
+	}
+
+	for _, tt := range tests {
+		res, err := ndarray.Pad(a, tt.widths, tt.mode, tt.opts...)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got := fmt.Sprint(res.ToSlice()); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPad2D(t *testing.T) {
+	m := seq(t, 2, 3)
+
+	// Corners come from the already padded first axis, as in NumPy
+	c, err := ndarray.Pad(m, [][2]int{{1, 0}, {0, 1}}, ndarray.PadConstant, ndarray.WithConstantValues(9, 7))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := c.String(); got != "NDArray(shape=[3 4], data=[9 9 9 7 0 1 2 7 3 4 5 7])" {
+		t.Errorf("unexpected constant padding: %s", got)
+	}
+
+	r, _ := ndarray.Pad(m, [][2]int{{1, 1}}, ndarray.PadReflect)
+	want := "NDArray(shape=[4 5], data=[4 3 4 5 4 1 0 1 2 1 4 3 4 5 4 1 0 1 2 1])"
+	if got := r.String(); got != want {
+		t.Errorf("unexpected reflect padding:\n got %s\nwant %s", got, want)
+	}
+
+	// Padding a transposed view
+	e, _ := ndarray.Pad(m.T(), [][2]int{{0, 1}, {1, 0}}, ndarray.PadEdge)
+	if got := e.String(); got != "NDArray(shape=[4 3], data=[0 0 3 1 1 4 2 2 5 2 2 5])" {
+		t.Errorf("unexpected edge padding of a view: %s", got)
+	}
+}
+
+func TestPadErrors(t *testing.T) {
+	m := seq(t, 2, 3)
+
+	if _, err := ndarray.Pad(m, [][2]int{{1, 1}, {1, 1}, {1, 1}}, ndarray.PadConstant); err == nil {
+		t.Error("expected error for too many widths, got nil")
+	}
+	if _, err := ndarray.Pad(m, [][2]int{{-1, 0}}, ndarray.PadConstant); err == nil {
+		t.Error("expected error for negative widths, got nil")
+	}
+
+	empty, _ := ndarray.FromSlice(nil)
+	if _, err := ndarray.Pad(empty, [][2]int{{1, 1}}, ndarray.PadWrap); err == nil {
+		t.Error("expected error extending an empty axis, got nil")
+	}
+	if res, err := ndarray.Pad(empty, [][2]int{{1, 1}}, ndarray.PadConstant); err != nil || res.Size() != 2 {
+		t.Errorf("expected constant padding of an empty axis to work, got %v (%v)", res, err)
+	}
+}
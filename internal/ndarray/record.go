@@ -0,0 +1,791 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║     ██████╗ ███████╗ ██████╗ ██████╗ ██████╗ ██████╗                               ║
+// ║     ██╔══██╗██╔════╝██╔════╝██╔═══██╗██╔══██╗██╔══██╗                              ║
+// ║     ██████╔╝█████╗  ██║     ██║   ██║██████╔╝██║  ██║                              ║
+// ║     ██╔══██╗██╔══╝  ██║     ██║   ██║██╔══██╗██║  ██║                              ║
+// ║     ██║  ██║███████╗╚██████╗╚██████╔╝██║  ██║██████╔╝                              ║
+// ║     ╚═╝  ╚═╝╚══════╝ ╚═════╝ ╚═════╝ ╚═╝  ╚═╝╚═════╝                               ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Structured dtypes: records of named, typed fields at                              ║
+// ║  fixed offsets, their storage, field views and struct rows.                        ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/record.go                ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: Field – One named member of a structured dtype                             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   The name, element type and byte offset of a field within each                    ║
+// ║   record, as listed by DType.Fields and taken by Struct and StructAt.              ║
+// ║   The type may be any dtype, another structured one included.                      ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type Field struct {
+	Name   string
+	DType  DType
+	Offset int
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Struct, StructAt – The structured dtype of a list of fields                ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.dtype([('id', 'i8'), ('value', 'f4')])` and of its             ║
+// ║   dict form with 'offsets' and 'itemsize'. Every element is a record               ║
+// ║   holding one value of each field; a.Field(name) views one of them                 ║
+// ║   across the array.                                                                ║
+// ║                                                                                    ║
+// ║     - Struct(fields...)           : packed one after the other, in                 ║
+// ║                                     order; their Offset is ignored                 ║
+// ║     - StructAt(itemSize, fields...) : each at its own Offset within                ║
+// ║                                     records of itemSize bytes                      ║
+// ║                                                                                    ║
+// ║   There must be at least one field, and names must be non-empty and                ║
+// ║   distinct. Equal field lists give the same DType, of kind 'V' and                 ║
+// ║   named as NumPy prints it. Casting follows NumPy:                                 ║
+// ║                                                                                    ║
+// ║   - Safe / Same kind : between structured types with as many fields,               ║
+// ║               matched by position, when every pair casts by the rule               ║
+// ║   - Unsafe  : also from non-text types, written to every field                     ║
+// ║   - Never   : from text, and from a structured type to any other                   ║
+// ║                                                                                    ║
+// ║   Returns: (DType, error) – error on a bad field list                              ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   d, _ := Struct(Field{Name: "id", DType: Int64},                                  ║
+// ║                  Field{Name: "value", DType: Float32})                             ║
+// ║   d.String()       → "[('id', 'int64'), ('value', 'float32')]"                     ║
+// ║   d.ItemSize()     → 12                                                            ║
+// ║   d.Fields()[1]    → {value float32 8}                                             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Struct(fields ...Field) (DType, error) {
+
+	packed := make([]Field, len(fields))
+	size := 0
+	for k, f := range fields {
+		packed[k] = Field{f.Name, f.DType, size}
+		size += f.DType.ItemSize()
+	}
+	return StructAt(size, packed...)
+}
+
+func StructAt(itemSize int, fields ...Field) (DType, error) {
+
+	if len(fields) == 0 {
+		return -1, fmt.Errorf("a structured dtype needs at least one field")
+	}
+
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		_, ok := f.DType.info()
+		switch {
+		case f.Name == "":
+			return -1, fmt.Errorf("field names must not be empty")
+		case seen[f.Name]:
+			return -1, fmt.Errorf("field '%s' occurs more than once", f.Name)
+		case !ok:
+			return -1, fmt.Errorf("data type %s of field '%s' not understood", f.DType, f.Name)
+		case f.Offset < 0 || f.Offset+f.DType.ItemSize() > itemSize:
+			return -1, fmt.Errorf("field '%s' of %d bytes at offset %d does not fit in an itemsize of %d", f.Name, f.DType.ItemSize(), f.Offset, itemSize)
+		}
+		seen[f.Name] = true
+	}
+
+	fields = append([]Field(nil), fields...)
+	return register(&layout{fields, itemSize, layoutName(fields, itemSize)})
+}
+
+// The structured dtypes are numbered from recordBase, after the text types, in the
+// order they are first made; records holds their layouts.
+const recordBase DType = 3 << 20
+
+// layout describes the records of a structured dtype.
+type layout struct {
+	fields []Field
+	size   int
+	name   string
+}
+
+var records struct {
+	sync.RWMutex
+	layouts []*layout
+	byName  map[string]DType
+}
+
+// register returns the DType of a layout, numbering it when it is new. The name
+// spells out every field and offset, so equal layouts share a DType.
+func register(l *layout) (DType, error) {
+
+	records.Lock()
+	defer records.Unlock()
+
+	if d, ok := records.byName[l.name]; ok {
+		return d, nil
+	}
+	if len(records.layouts) > maxWidth {
+		return -1, fmt.Errorf("too many structured dtypes")
+	}
+	if records.byName == nil {
+		records.byName = make(map[string]DType)
+	}
+
+	d := recordBase + DType(len(records.layouts))
+	records.layouts = append(records.layouts, l)
+	records.byName[l.name] = d
+	return d, nil
+}
+
+// layoutName is NumPy's name of a structured dtype: the list of (name, type) pairs
+// when the fields are packed, the dict of names, formats, offsets and itemsize
+// otherwise.
+func layoutName(fields []Field, size int) string {
+
+	pairs := make([]string, len(fields))
+	names := make([]string, len(fields))
+	formats := make([]string, len(fields))
+	offsets := make([]string, len(fields))
+	packed, end := true, 0
+	for k, f := range fields {
+		format := f.DType.String()
+		if !f.DType.isRecord() {
+			format = "'" + format + "'"
+		}
+		pairs[k] = fmt.Sprintf("('%s', %s)", f.Name, format)
+		names[k] = "'" + f.Name + "'"
+		formats[k] = format
+		offsets[k] = strconv.Itoa(f.Offset)
+		packed = packed && f.Offset == end
+		end = f.Offset + f.DType.ItemSize()
+	}
+
+	if packed && end == size {
+		return "[" + strings.Join(pairs, ", ") + "]"
+	}
+	return fmt.Sprintf("{'names': [%s], 'formats': [%s], 'offsets': [%s], 'itemsize': %d}",
+		strings.Join(names, ", "), strings.Join(formats, ", "), strings.Join(offsets, ", "), size)
+}
+
+// layout returns the layout of a structured dtype, nil for the other types.
+func (d DType) layout() *layout {
+
+	records.RLock()
+	defer records.RUnlock()
+
+	k := int(d - recordBase)
+	if d < recordBase || k >= len(records.layouts) {
+		return nil
+	}
+	return records.layouts[k]
+}
+
+// Fields returns the fields of a structured dtype in order, nil for other types.
+func (d DType) Fields() []Field {
+	if l := d.layout(); l != nil {
+		return append([]Field(nil), l.fields...)
+	}
+	return nil
+}
+
+// isRecord reports whether d is a structured type.
+func (d DType) isRecord() bool { return d.kind() == 'V' }
+
+// recordCast is CanCast when either side is a structured type.
+func recordCast(from, to DType, casting Casting) bool {
+
+	switch {
+	case from == to:
+		return true
+	case !to.isRecord() || from.isText():
+		return false
+	case !from.isRecord():
+		if casting != CastingUnsafe {
+			return false
+		}
+		for _, f := range to.layout().fields {
+			if !CanCast(from, f.DType, casting) {
+				return false
+			}
+		}
+		return true
+	}
+
+	src, dst := from.layout().fields, to.layout().fields
+	if len(src) != len(dst) {
+		return false
+	}
+	for k := range dst {
+		if !CanCast(src[k].DType, dst[k].DType, casting) {
+			return false
+		}
+	}
+	return true
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: recordBuf – Storage of structured elements                                 ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   The bytes of the records back to back, each laid out as its dtype                ║
+// ║   says. A field value takes the bytes its own buffer type holds it                 ║
+// ║   in (see bytesOf), so a float32 field is 4 bytes of float32.                      ║
+// ║                                                                                    ║
+// ║   - Numbers written to a record go to every field, as in NumPy                     ║
+// ║   - copyFrom copies whole records of the same dtype, and converts                  ║
+// ║     other structured types field by field, matched by position                     ║
+// ║   - Read as numbers, records are NaN; value gives a map from each                  ║
+// ║     field name to the field's value                                                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   d = [('id', 'int64'), ('value', 'float32')]                                      ║
+// ║   buf := makeStorage(d, 2)   → recordBuf{24 bytes, size 12}                        ║
+// ║   buf.setFloat(1, 3)         → records (0, 0) and (3, 3)                           ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type recordBuf struct {
+	bytes []byte
+	d     DType
+	size  int
+}
+
+// newRecords returns a zeroed buffer of n records of type d.
+func newRecords(d DType, n int) recordBuf {
+	return recordBuf{make([]byte, n*d.ItemSize()), d, d.ItemSize()}
+}
+
+func (b recordBuf) len() int                 { return len(b.bytes) / b.size }
+func (b recordBuf) dtype() DType             { return b.d }
+func (b recordBuf) float(i int) float64      { return math.NaN() }
+func (b recordBuf) complex(i int) complex128 { return complex(math.NaN(), 0) }
+func (b recordBuf) fresh(n int) storage      { return newRecords(b.d, n) }
+func (b recordBuf) slice() any               { return b.bytes }
+
+func (b recordBuf) setFloat(i int, v float64) {
+	for _, f := range b.d.layout().fields {
+		b.field(f).setFloat(i, v)
+	}
+}
+
+func (b recordBuf) setComplex(i int, v complex128) {
+	for _, f := range b.d.layout().fields {
+		b.field(f).setComplex(i, v)
+	}
+}
+
+func (b recordBuf) value(i int) any {
+	fields := b.d.layout().fields
+	out := make(map[string]any, len(fields))
+	for _, f := range fields {
+		out[f.Name] = b.field(f).value(i)
+	}
+	return out
+}
+
+func (b recordBuf) copyFrom(i int, src storage, j int) {
+
+	d := src.dtype()
+	switch {
+	case d == b.d:
+		copy(asField(b).raw(i), asField(src).raw(j))
+	case d.isRecord():
+		from := d.layout().fields
+		for k, f := range b.d.layout().fields {
+			if k < len(from) {
+				b.field(f).copyFrom(i, asField(src).sub(from[k]), j)
+			}
+		}
+	default:
+		for _, f := range b.d.layout().fields {
+			b.field(f).copyFrom(i, src, j)
+		}
+	}
+}
+
+// field returns the storage of one field of every record.
+func (b recordBuf) field(f Field) storage { return asField(b).sub(f) }
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: fieldBuf – One field of every record of a recordBuf                        ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   The storage of a.Field(name): element i is the field of record i,                ║
+// ║   the bytes at offset `off` of it. Elements are decoded into a                     ║
+// ║   one-element buffer of the field's type and encoded back, so writes               ║
+// ║   go straight to the records, and every conversion is that buffer's.               ║
+// ║                                                                                    ║
+// ║   - Integer and time fields are intFields, text fields textFields:                 ║
+// ║     they add the optional interfaces of their own buffers                          ║
+// ║   - fresh gives a plain buffer of the field type, so copies of a                   ║
+// ║     field view are ordinary arrays                                                 ║
+// ║   - Kernels that index the typed buffer of an array take a copy of                 ║
+// ║     field views first (see direct)                                                 ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   d = [('id', 'int64'), ('value', 'float32')]                                      ║
+// ║   v := fieldBuf{rec, 8, Float32}   → the values of rec                             ║
+// ║   v.setFloat(1, 2.5)               → bytes 20 … 23 of rec hold float32(2.5)        ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type fieldBuf struct {
+	rec recordBuf
+	off int
+	d   DType
+}
+
+// intField and textField are the fieldBufs of integer and time types, and of text
+// types.
+type (
+	intField  struct{ fieldBuf }
+	textField struct{ fieldBuf }
+)
+
+// newField returns the field of type d at byte offset off of every record of rec.
+func newField(rec recordBuf, off int, d DType) storage {
+	f := fieldBuf{rec, off, d}
+	switch d.kind() {
+	case 'i', 'u', 'M', 'm':
+		return intField{f}
+	case 'U', 'S':
+		return textField{f}
+	}
+	return f
+}
+
+// asField views a buffer of structured elements, a recordBuf or the fieldBuf of a
+// structured field, as a fieldBuf.
+func asField(buf storage) fieldBuf {
+	if r, ok := buf.(recordBuf); ok {
+		return fieldBuf{r, 0, r.d}
+	}
+	return buf.(fieldBuf)
+}
+
+func (b fieldBuf) len() int                 { return b.rec.len() }
+func (b fieldBuf) dtype() DType             { return b.d }
+func (b fieldBuf) float(i int) float64      { return b.load(i).float(0) }
+func (b fieldBuf) complex(i int) complex128 { return b.load(i).complex(0) }
+func (b fieldBuf) value(i int) any          { return b.load(i).value(0) }
+func (b fieldBuf) fresh(n int) storage      { return makeStorage(b.d, n) }
+func (b fieldBuf) slice() any               { return b.rec.bytes }
+func (b intField) int(i int) int64          { return b.load(i).(integers).int(0) }
+func (b textField) text(i int) string       { return b.load(i).(texts).text(0) }
+
+func (b fieldBuf) setFloat(i int, v float64) {
+	b.update(i, func(e storage) { e.setFloat(0, v) })
+}
+
+func (b fieldBuf) setComplex(i int, v complex128) {
+	b.update(i, func(e storage) { e.setComplex(0, v) })
+}
+
+func (b fieldBuf) copyFrom(i int, src storage, j int) {
+	b.update(i, func(e storage) { e.copyFrom(0, src, j) })
+}
+
+func (b intField) setInt(i int, v int64) {
+	b.update(i, func(e storage) { e.(integers).setInt(0, v) })
+}
+
+func (b textField) setText(i int, s string) {
+	b.update(i, func(e storage) { e.(texts).setText(0, s) })
+}
+
+// raw returns the bytes of element i.
+func (b fieldBuf) raw(i int) []byte {
+	start := i*b.rec.size + b.off
+	return b.rec.bytes[start : start+b.d.ItemSize()]
+}
+
+// load decodes element i into a one-element buffer of the field type.
+func (b fieldBuf) load(i int) storage {
+	e := makeStorage(b.d, 1)
+	copy(bytesOf(e), b.raw(i))
+	return e
+}
+
+// update decodes element i, lets set change it and encodes it back.
+func (b fieldBuf) update(i int, set func(e storage)) {
+	e := b.load(i)
+	set(e)
+	copy(b.raw(i), bytesOf(e))
+}
+
+// sub returns the storage of a field of b's structured type.
+func (b fieldBuf) sub(f Field) storage { return newField(b.rec, b.off+f.Offset, f.DType) }
+
+// part is the fieldBuf of the real (0) or imaginary (1) halves of a complex field.
+func (b fieldBuf) part(which int) fieldBuf {
+	d := Float64
+	if b.d == Complex64 {
+		d = Float32
+	}
+	return fieldBuf{b.rec, b.off + which*d.ItemSize(), d}
+}
+
+// bytesOf returns the memory of a buffer as bytes, without copying.
+func bytesOf(buf storage) []byte {
+	v := reflect.ValueOf(buf.slice())
+	if v.Len() == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(v.UnsafePointer()), v.Len()*int(v.Type().Elem().Size()))
+}
+
+// direct returns a, or a copy of it when it is a field view, for the kernels that
+// index the typed buffer of an array directly.
+func direct(a *NDArray) *NDArray {
+	switch a.data.(type) {
+	case fieldBuf, intField, textField:
+		return a.Copy()
+	}
+	return a
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Field – View of one field of a structured array                            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a['name']`: an array of the field's dtype with a's                ║
+// ║   shape and strides, whose element i is the field of a's record i.                 ║
+// ║                                                                                    ║
+// ║   - It is a view: writes through it change the records, and so do                  ║
+// ║     writes through its own views (slices, transposes, Real / Imag                  ║
+// ║     of a complex field, Field of a structured field)                               ║
+// ║   - Copies of it (Copy, AsType, arithmetic results) are plain arrays               ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – error if a has no field of that name                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   d, _ := Struct(Field{Name: "id", DType: Int64},                                  ║
+// ║                  Field{Name: "value", DType: Float32})                             ║
+// ║   a, _ := ZerosOf(d, 3)                                                            ║
+// ║   v, _ := a.Field("value")                                                         ║
+// ║   v.SetValue(2.5, 1)       → a.Value(1) = map[id:0 value:2.5]                      ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Field(name string) (*NDArray, error) {
+
+	l := a.dtype.layout()
+	if l == nil {
+		return nil, fmt.Errorf("dtype %s has no fields", a.dtype)
+	}
+	for _, f := range l.fields {
+		if f.Name == name {
+			return a.viewOf(asField(a.data).sub(f)), nil
+		}
+	}
+	return nil, fmt.Errorf("no field of name %s", name)
+}
+
+// viewOf views the elements of a, at the same positions, through another buffer.
+func (a *NDArray) viewOf(buf storage) *NDArray {
+	v := a.view(append([]int(nil), a.shape...), append([]int(nil), a.strides...), a.offset)
+	v.data = buf
+	v.dtype = buf.dtype()
+	return v
+}
+
+// formatRecords lists the elements of a record buffer for String, as tuples of
+// their fields.
+func formatRecords(buf storage) string {
+	parts := make([]string, buf.len())
+	for i := range parts {
+		parts[i] = recordText(buf, i)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// recordText prints record i of buf: texts quoted, other fields as elemText does.
+func recordText(buf storage, i int) string {
+
+	rec := asField(buf)
+	fields := rec.d.layout().fields
+	parts := make([]string, len(fields))
+	for k, f := range fields {
+		field := rec.sub(f)
+		switch {
+		case f.DType.isRecord():
+			parts[k] = recordText(field, i)
+		case f.DType.isText():
+			parts[k] = strconv.Quote(field.(texts).text(i))
+		default:
+			parts[k] = elemText(field, i)
+		}
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: FromStructs – Build a structured array from Go structs                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.array(rows, dtype=...)` for a list of tuples, with             ║
+// ║   the dtype read off the struct type T: each exported field becomes                ║
+// ║   a field of the record, in order, laid out like a C struct (every                 ║
+// ║   field at a multiple of its alignment, as `align=True` does).                     ║
+// ║                                                                                    ║
+// ║   The `ndarray` struct tag renames or skips a field, and sets the unit             ║
+// ║   of times or the width of texts:                                                  ║
+// ║                                                                                    ║
+// ║     - `ndarray:"name"`     : the name in the dtype (default: Go's)                 ║
+// ║     - `ndarray:"-"`        : the field is left out                                 ║
+// ║     - `ndarray:"ts,s"`     : a time.Time or time.Duration in that unit             ║
+// ║                              (Y, M, D, s, ms, us, ns; default ns)                  ║
+// ║     - `ndarray:"name,16"`  : a string or []byte that wide (default:                ║
+// ║                              the longest value, at least 1)                        ║
+// ║                                                                                    ║
+// ║   Fields may be bool, integers (int and uint as int64 and uint64),                 ║
+// ║   floats, complex numbers, string (unicode), []byte (bytes), time.Time             ║
+// ║   (datetime64) and time.Duration (timedelta64), or types defined on                ║
+// ║   them. Shape rules are those of FromSlice; the data is copied.                    ║
+// ║   SetValue and ScalarOf take single structs the same way.                          ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – error on a field of another type, a bad             ║
+// ║            tag, a time its field's unit cannot count or a bad shape                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   type reading struct {                                                            ║
+// ║       ID    int64     `ndarray:"id"`                                               ║
+// ║       At    time.Time `ndarray:"ts,s"`                                             ║
+// ║       Value float32   `ndarray:"value"`                                            ║
+// ║   }                                                                                ║
+// ║   a, _ := FromStructs([]reading{{1, t0, 0.5}, {2, t1, 0.25}})                      ║
+// ║   a.DType().ItemSize(), a.DType().Fields()[2]   → 24, {value float32 16}           ║
+// ║   v, _ := a.Field("value")                      → [0.5 0.25] (float32)             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func FromStructs[T any](rows []T, shape ...int) (*NDArray, error) {
+
+	buf, err := structRecords(reflect.ValueOf(rows))
+	if err != nil {
+		return nil, err
+	}
+	return fromBuffer(buf, len(rows), shape)
+}
+
+// column is a field of a struct type that FromStructs stores: its index in the
+// struct and the Field it becomes.
+type column struct {
+	index []int
+	field Field
+}
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+)
+
+// goKinds maps the kinds of Go bools and numbers to their dtypes.
+var goKinds = map[reflect.Kind]DType{
+	reflect.Bool: Bool, reflect.Int: Int64, reflect.Int64: Int64, reflect.Int32: Int32,
+	reflect.Int16: Int16, reflect.Int8: Int8, reflect.Uint: Uint64, reflect.Uint64: Uint64,
+	reflect.Uint32: Uint32, reflect.Uint16: Uint16, reflect.Uint8: Uint8,
+	reflect.Float64: Float64, reflect.Float32: Float32,
+	reflect.Complex128: Complex128, reflect.Complex64: Complex64,
+}
+
+// structRecords stores a slice of structs as records, see FromStructs.
+func structRecords(rows reflect.Value) (recordBuf, error) {
+
+	t := rows.Type().Elem()
+	if t.Kind() != reflect.Struct {
+		return recordBuf{}, fmt.Errorf("cannot build records from values of type %s", t)
+	}
+
+	var columns []column
+	for k := 0; k < t.NumField(); k++ {
+		sf := t.Field(k)
+		name, option, _ := strings.Cut(sf.Tag.Get("ndarray"), ",")
+		if !sf.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		d, err := columnType(sf, option, rows)
+		if err != nil {
+			return recordBuf{}, err
+		}
+		columns = append(columns, column{sf.Index, Field{Name: name, DType: d}})
+	}
+
+	fields := make([]Field, len(columns))
+	for k, c := range columns {
+		fields[k] = c.field
+	}
+	d, err := alignedStruct(fields)
+	if err != nil {
+		return recordBuf{}, err
+	}
+
+	buf := newRecords(d, rows.Len())
+	for k, f := range d.layout().fields {
+		dst := buf.field(f)
+		for i := 0; i < rows.Len(); i++ {
+			v := goValue(rows.Index(i).FieldByIndex(columns[k].index))
+			if t, ok := v.(time.Time); ok {
+				// Count straight in the column's unit so instants it cannot hold are refused
+				ticks, err := timeToTicks(t, f.DType.unit())
+				if err != nil {
+					return recordBuf{}, fmt.Errorf("field %s: %w", f.Name, err)
+				}
+				dst.(integers).setInt(i, ticks)
+				continue
+			}
+			src, err := scalarStorage(v)
+			if err != nil {
+				return recordBuf{}, err
+			}
+			dst.copyFrom(i, src, 0)
+		}
+	}
+	return buf, nil
+}
+
+// columnType is the dtype of struct field sf with tag option `option`; texts without
+// a width take that of the longest value among rows.
+func columnType(sf reflect.StructField, option string, rows reflect.Value) (DType, error) {
+
+	t := sf.Type
+	switch {
+	case t == timeType || t == durationType:
+		unit := Nanoseconds
+		if option != "" {
+			k := slices.Index(unitNames[:], option)
+			if k < 0 {
+				return -1, fmt.Errorf("field %s: unknown time unit '%s'", sf.Name, option)
+			}
+			unit = TimeUnit(k)
+		}
+		if t == timeType {
+			return Datetime64(unit), nil
+		}
+		return Timedelta64(unit), nil
+
+	case t.Kind() == reflect.String || (t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8):
+		isBytes := t.Kind() == reflect.Slice
+		width := 1
+		if option != "" {
+			w, err := strconv.Atoi(option)
+			if err != nil || w < 1 || w > maxWidth {
+				return -1, fmt.Errorf("field %s: bad width '%s'", sf.Name, option)
+			}
+			width = w
+		} else {
+			for i := 0; i < rows.Len(); i++ {
+				v := rows.Index(i).FieldByIndex(sf.Index)
+				if isBytes {
+					width = max(width, v.Len())
+				} else {
+					width = max(width, utf8.RuneCountInString(v.String()))
+				}
+			}
+		}
+		if isBytes {
+			return Bytes(width), nil
+		}
+		return Unicode(width), nil
+	}
+
+	d, ok := goKinds[t.Kind()]
+	switch {
+	case !ok:
+		return -1, fmt.Errorf("cannot store field %s of type %s in an array", sf.Name, t)
+	case option != "":
+		return -1, fmt.Errorf("field %s: tag option '%s' only applies to times and texts", sf.Name, option)
+	}
+	return d, nil
+}
+
+// goValue reads a struct field as the Go type scalarStorage stores for its kind.
+func goValue(v reflect.Value) any {
+
+	switch v.Type() {
+	case timeType:
+		return v.Interface()
+	case durationType:
+		return time.Duration(v.Int())
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
+		return v.Int()
+	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
+		return v.Uint()
+	case reflect.Float64, reflect.Float32:
+		return v.Float()
+	case reflect.Complex128, reflect.Complex64:
+		return v.Complex()
+	case reflect.String:
+		return v.String()
+	default:
+		return v.Bytes()
+	}
+}
+
+// alignedStruct is Struct with every field at a multiple of its alignment, and the
+// item size a multiple of the largest one, as np.dtype(..., align=True) lays out
+// C structs.
+func alignedStruct(fields []Field) (DType, error) {
+
+	placed := make([]Field, len(fields))
+	size, most := 0, 1
+	for k, f := range fields {
+		a := alignment(f.DType)
+		size = (size + a - 1) / a * a
+		placed[k] = Field{f.Name, f.DType, size}
+		size += f.DType.ItemSize()
+		most = max(most, a)
+	}
+	return StructAt((size+most-1)/most*most, placed...)
+}
+
+// alignment is the alignment of elements of type d in a C struct: their size, or
+// that of the parts they are made of.
+func alignment(d DType) int {
+
+	if l := d.layout(); l != nil {
+		a := 1
+		for _, f := range l.fields {
+			a = max(a, alignment(f.DType))
+		}
+		return a
+	}
+
+	switch d.kind() {
+	case 'U':
+		return 4
+	case 'S':
+		return 1
+	case 'c':
+		return d.ItemSize() / 2
+	}
+	return max(d.ItemSize(), 1)
+}
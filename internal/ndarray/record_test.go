@@ -0,0 +1,240 @@
+package ndarray_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+// reading is a row of a structured array built with FromStructs.
+type reading struct {
+	ID    int64     `ndarray:"id"`
+	At    time.Time `ndarray:"ts,s"`
+	Value float32   `ndarray:"value"`
+	Note  string
+	skip  int
+	Raw   []byte `ndarray:"-"`
+}
+
+func TestStructDTypes(t *testing.T) {
+	d, err := ndarray.Struct(
+		ndarray.Field{Name: "id", DType: ndarray.Int64},
+		ndarray.Field{Name: "value", DType: ndarray.Float32, Offset: 99},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := d.String(), "[('id', 'int64'), ('value', 'float32')]"; got != want || d.ItemSize() != 12 {
+		t.Errorf("got %s (%d bytes), want %s (12 bytes)", got, d.ItemSize(), want)
+	}
+	if f := d.Fields(); len(f) != 2 || f[1] != (ndarray.Field{Name: "value", DType: ndarray.Float32, Offset: 8}) {
+		t.Errorf("fields: got %v", f)
+	}
+	if again, _ := ndarray.Struct(d.Fields()...); again != d {
+		t.Errorf("equal field lists gave %s and %s", d, again)
+	}
+
+	padded, err := ndarray.StructAt(16,
+		ndarray.Field{Name: "id", DType: ndarray.Int64},
+		ndarray.Field{Name: "value", DType: ndarray.Float32, Offset: 8},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "{'names': ['id', 'value'], 'formats': ['int64', 'float32'], 'offsets': [0, 8], 'itemsize': 16}"
+	if padded.String() != want || padded == d {
+		t.Errorf("padded: got %s, want %s", padded, want)
+	}
+	nested, _ := ndarray.Struct(ndarray.Field{Name: "pos", DType: d}, ndarray.Field{Name: "ok", DType: ndarray.Bool})
+	if got, want := nested.String(), "[('pos', [('id', 'int64'), ('value', 'float32')]), ('ok', 'bool')]"; got != want {
+		t.Errorf("nested: got %s, want %s", got, want)
+	}
+
+	one, _ := ndarray.Struct(ndarray.Field{Name: "x", DType: ndarray.Int8})
+
+	bad := [][]ndarray.Field{
+		nil,
+		{{Name: "", DType: ndarray.Int8}},
+		{{Name: "a", DType: ndarray.Int8}, {Name: "a", DType: ndarray.Int8}},
+		{{Name: "a", DType: ndarray.Unicode(0)}},
+	}
+	for _, fields := range bad {
+		if _, err := ndarray.Struct(fields...); err == nil {
+			t.Errorf("expected error for fields %v", fields)
+		}
+	}
+	if _, err := ndarray.StructAt(4, ndarray.Field{Name: "a", DType: ndarray.Int32, Offset: 2}); err == nil {
+		t.Errorf("expected error for a field past the itemsize")
+	}
+
+	casts := []struct {
+		from, to ndarray.DType
+		casting  ndarray.Casting
+		want     bool
+	}{
+		{d, padded, ndarray.CastingSafe, true},
+		{padded, nested, ndarray.CastingUnsafe, true},
+		{padded, nested, ndarray.CastingSameKind, false},
+		{nested, one, ndarray.CastingUnsafe, false},
+		{ndarray.Int64, d, ndarray.CastingSameKind, false},
+		{ndarray.Int64, d, ndarray.CastingUnsafe, true},
+		{ndarray.Unicode(3), d, ndarray.CastingUnsafe, false},
+		{d, ndarray.Float64, ndarray.CastingUnsafe, false},
+	}
+	for _, tt := range casts {
+		if got := ndarray.CanCast(tt.from, tt.to, tt.casting); got != tt.want {
+			t.Errorf("CanCast(%s, %s, %s) = %v, want %v", tt.from, tt.to, tt.casting, got, tt.want)
+		}
+	}
+}
+
+func TestFieldViews(t *testing.T) {
+	d, _ := ndarray.Struct(
+		ndarray.Field{Name: "id", DType: ndarray.Int64},
+		ndarray.Field{Name: "z", DType: ndarray.Complex64},
+		ndarray.Field{Name: "name", DType: ndarray.Unicode(3)},
+	)
+	a, err := ndarray.ZerosOf(d, 2, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids, err := a.Field("id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids.DType() != ndarray.Int64 || len(ids.Shape()) != 2 {
+		t.Fatalf("got %s %v, want int64 [2 2]", ids.DType(), ids.Shape())
+	}
+	if err := ids.SetValue(int64(1)<<60+1, 1, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names, _ := a.Field("name")
+	col, _ := names.Slice(ndarray.Span(0, 2), ndarray.At(1))
+	col.SetValue("abcd", 1)
+	z, _ := a.Field("z")
+	im := z.Imag()
+	im.SetValue(float32(-2), 0, 1)
+
+	if got, want := a.String(), `NDArray(shape=[2 2], data=[(0, 0+0i, "") (0, 0-2i, "") (1152921504606846977, 0+0i, "") (0, 0+0i, "abc")])`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if v, _ := a.Value(1, 0); v.(map[string]any)["id"] != int64(1)<<60+1 {
+		t.Errorf("Value: got %v", v)
+	}
+
+	// Kernels see the field values, and their results are plain arrays
+	sum, err := ndarray.Add(ids, ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := sum.Value(1, 0); v != int64(1)<<61+2 {
+		t.Errorf("Add on a field: got %v", v)
+	}
+	if f, _ := ids.AsType(ndarray.Float64); f.String() != "NDArray(shape=[2 2], data=[0 0 1.152921504606847e+18 0])" {
+		t.Errorf("AsType on a field: got %s", f)
+	}
+	half, _ := ndarray.Mul(im, ndarray.Scalar(0.5))
+	if got, want := half.String(), "NDArray(shape=[2 2], data=[0 -1 0 0])"; got != want {
+		t.Errorf("Mul on an imaginary part: got %s, want %s", got, want)
+	}
+
+	if _, err := a.Field("missing"); err == nil {
+		t.Errorf("expected error for a missing field")
+	}
+	if _, err := ids.Field("id"); err == nil {
+		t.Errorf("expected error for a field of an int64 array")
+	}
+	if _, err := ndarray.Add(a, a); err == nil {
+		t.Errorf("expected error adding records")
+	}
+	if _, err := a.Get(0, 0); err == nil {
+		t.Errorf("expected error reading a record as float64")
+	}
+}
+
+func TestFromStructs(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	rows := []reading{
+		{ID: 1, At: t0, Value: 0.5, Note: "ok"},
+		{ID: 2, At: t0.Add(90 * time.Second), Value: 0.25, Note: "late", skip: 7},
+	}
+	a, err := ndarray.FromStructs(rows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []ndarray.Field{
+		{Name: "id", DType: ndarray.Int64, Offset: 0},
+		{Name: "ts", DType: ndarray.Datetime64(ndarray.Seconds), Offset: 8},
+		{Name: "value", DType: ndarray.Float32, Offset: 16},
+		{Name: "Note", DType: ndarray.Unicode(4), Offset: 20},
+	}
+	fields := a.DType().Fields()
+	if len(fields) != len(want) || a.DType().ItemSize() != 40 {
+		t.Fatalf("got %v (%d bytes), want %v (40 bytes)", fields, a.DType().ItemSize(), want)
+	}
+	for k := range want {
+		if fields[k] != want[k] {
+			t.Errorf("field %d: got %v, want %v", k, fields[k], want[k])
+		}
+	}
+	if got, want := a.String(), `NDArray(shape=[2], data=[(1, 2024-03-01T12:00:00, 0.5, "ok") (2, 2024-03-01T12:01:30, 0.25, "late")])`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	ts, _ := a.Field("ts")
+	if got, _ := ts.Value(1); !got.(time.Time).Equal(t0.Add(90 * time.Second)) {
+		t.Errorf("ts field: got %v", got)
+	}
+	if err := a.SetValue(reading{ID: 9, At: t0, Value: 4, Note: "replaced"}, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := a.String(); got != `NDArray(shape=[2], data=[(9, 2024-03-01T12:00:00, 4, "repl") (2, 2024-03-01T12:01:30, 0.25, "late")])` {
+		t.Errorf("SetValue(struct): got %s", got)
+	}
+
+	grid, err := ndarray.FromStructs(append(rows, rows...), 2, 2)
+	if err != nil || len(grid.Shape()) != 2 {
+		t.Errorf("shaped: got %v, %v", grid, err)
+	}
+	type celsius float64
+	type sample struct {
+		T    celsius `ndarray:"t"`
+		Code []byte  `ndarray:"code,2"`
+	}
+	s, err := ndarray.FromStructs([]sample{{21.5, []byte("abc")}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := s.String(), `NDArray(shape=[1], data=[(21.5, "ab")])`; got != want || s.DType().Fields()[1].DType != ndarray.Bytes(2) {
+		t.Errorf("defined types and widths: got %s (%s), want %s", got, s.DType(), want)
+	}
+
+	if _, err := ndarray.FromStructs([]struct{ M map[string]int }{{}}); err == nil {
+		t.Errorf("expected error for a map field")
+	}
+	if _, err := ndarray.FromStructs([]struct {
+		At time.Time `ndarray:"at,weeks"`
+	}{{}}); err == nil {
+		t.Errorf("expected error for an unknown time unit")
+	}
+
+	// Far-off instants are counted in the column's own unit
+	type event struct {
+		Day time.Time `ndarray:"day,D"`
+	}
+	far := time.Date(3000, 6, 1, 0, 0, 0, 0, time.UTC)
+	if e, err := ndarray.FromStructs([]event{{far}}); err != nil || e.String() != "NDArray(shape=[1], data=[(3000-06-01)])" {
+		t.Errorf("far date in days: got %v, %v", e, err)
+	}
+	if _, err := ndarray.FromStructs([]struct {
+		At time.Time `ndarray:"at,ns"`
+	}{{far}}); err == nil {
+		t.Errorf("expected error for a date past 2262 in nanoseconds")
+	}
+	if _, err := ndarray.FromStructs([]int{1}); err == nil {
+		t.Errorf("expected error for a slice of ints")
+	}
+}
@@ -0,0 +1,401 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║     ███████╗███████╗██╗     ███████╗ ██████╗████████╗                              ║
+// ║     ██╔════╝██╔════╝██║     ██╔════╝██╔════╝╚══██╔══╝                              ║
+// ║     ███████╗█████╗  ██║     █████╗  ██║        ██║                                 ║
+// ║     ╚════██║██╔══╝  ██║     ██╔══╝  ██║        ██║                                 ║
+// ║     ███████║███████╗███████╗███████╗╚██████╗   ██║                                 ║
+// ║     ╚══════╝╚══════╝╚══════╝╚══════╝ ╚═════╝   ╚═╝                                 ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Conditional selection for NDArray: where, select, choose,                         ║
+// ║  nonzero/argwhere, extract and masked in-place updates.                            ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/select.go                ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"fmt"
+	"math"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Where – Pick from x or y depending on a condition                          ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.where(cond, x, y)`: x where cond is true (non-zero),           ║
+// ║   y elsewhere.                                                                     ║
+// ║                                                                                    ║
+// ║   - cond, x and y are broadcast together                                           ║
+// ║   - The result is Bool only when x and y both are                                  ║
+// ║   - For the one-argument form `np.where(cond)` use WhereIndices                    ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = [-1 2 -3]                                                                    ║
+// ║   neg, _ := Less(a, Scalar(0))                                                     ║
+// ║   Where(neg, Scalar(0), a) → [0 2 0]                                               ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Where(cond, x, y *NDArray) (*NDArray, error) {
+
+	arrays, err := BroadcastArrays(cond, x, y)
+	if err != nil {
+		return nil, fmt.Errorf("operands could not be broadcast together with shapes %v %v %v", cond.shape, x.shape, y.shape)
+	}
+
+	c, xs, ys := gatherData(arrays[0]), gather(arrays[1]), gather(arrays[2])
+	common, err := commonDType([]*NDArray{x, y})
+	if err != nil {
+		return nil, err
+	}
+	out := allocOf(append([]int(nil), arrays[0].shape...), common)
+	for i, v := range c {
+		if v != 0 {
+			out.data.copyFrom(i, xs, i)
+		} else {
+			out.data.copyFrom(i, ys, i)
+		}
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Nonzero, WhereIndices – Indices of the non-zero elements                   ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.nonzero(a)` (and of `np.where(a)`, which is the                ║
+// ║   same thing): one 1-D index array per dimension, in row-major order.              ║
+// ║                                                                                    ║
+// ║   - The arrays can be passed straight to Gather / Scatter:                         ║
+// ║     a.Gather(Indices(idx[0]), Indices(idx[1])) selects the non-zeros               ║
+// ║   - The index arrays are Int64, like NumPy's intp                                  ║
+// ║   - A 0-d input is treated as 1-D                                                  ║
+// ║                                                                                    ║
+// ║   Returns: []*NDArray                                                              ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = [[3 0 0] [0 4 0] [5 6 0]]                                                    ║
+// ║   Nonzero(a) → [0 1 2 2], [0 1 0 1]                                                ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Nonzero(a *NDArray) []*NDArray {
+
+	a = AtLeast1D(a)
+	coords := make([][]int64, len(a.shape))
+	index := make([]int, len(a.shape))
+	for _, v := range gatherData(a) {
+		if v != 0 {
+			for axis, i := range index {
+				coords[axis] = append(coords[axis], int64(i))
+			}
+		}
+		nextIndex(index, a.shape)
+	}
+
+	out := make([]*NDArray, len(coords))
+	for axis, c := range coords {
+		out[axis] = newStorage(intBuf[int64](c), []int{len(c)}, []int{1})
+	}
+	return out
+}
+
+func WhereIndices(cond *NDArray) []*NDArray {
+	return Nonzero(cond)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: ArgWhere – Coordinates of the non-zero elements, one per row               ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.argwhere(a)`: the transpose of Nonzero, shaped                 ║
+// ║   [count, ndim].                                                                   ║
+// ║                                                                                    ║
+// ║   Returns: *NDArray                                                                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = [[3 0] [0 4]]                                                                ║
+// ║   ArgWhere(a) → [[0 0] [1 1]]                                                      ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func ArgWhere(a *NDArray) *NDArray {
+
+	coords := Nonzero(a)
+	if len(a.shape) == 0 {
+		// np.argwhere of a 0-d array has no columns
+		return allocOf([]int{coords[0].shape[0], 0}, Int64)
+	}
+
+	out, _ := Stack(coords, -1)
+	return out
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Extract – Elements where a condition holds, flattened                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.extract(cond, a)`: both are read in row-major                  ║
+// ║   order and the elements of `a` at the non-zero positions of `cond`                ║
+// ║   are returned as a 1-D copy.                                                      ║
+// ║                                                                                    ║
+// ║   - Unlike Mask, `cond` may be of any dtype and only its size matters              ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – error if cond selects past the end of a             ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = [[0 1 2] [3 4 5]],  cond = Mod(a, 3) == 0                                    ║
+// ║   Extract(cond, a) → [0 3]                                                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Extract(cond, a *NDArray) (*NDArray, error) {
+
+	positions := []int{}
+	for i, c := range gatherData(cond) {
+		if c == 0 {
+			continue
+		}
+		if i >= a.Size() {
+			return nil, fmt.Errorf("index %d is out of bounds for axis 0 with size %d", i, a.Size())
+		}
+		positions = append(positions, i)
+	}
+
+	values := gather(a)
+	out := values.fresh(len(positions))
+	for k, i := range positions {
+		out.copyFrom(k, values, i)
+	}
+	return newStorage(out, []int{len(positions)}, []int{1}), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Select – Pick from several choices by the first true condition             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.select(condlist, choicelist, default)`.                        ║
+// ║                                                                                    ║
+// ║   - Conditions must be Bool arrays, one per choice                                 ║
+// ║   - All conditions and choices are broadcast together                              ║
+// ║   - Where several conditions hold, the first one wins; where none                  ║
+// ║     does, the result is `def`                                                      ║
+// ║   - The result type promotes the choices with that of `def`: Int64                 ║
+// ║     for a whole number, like a Python int, and Float64 otherwise                   ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   x = [0 1 2 3 4 5]                                                                ║
+// ║   Select({x < 3, x > 4}, {x, x²}, 42) → [0 1 2 42 42 25]                           ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Select(condlist, choicelist []*NDArray, def float64) (*NDArray, error) {
+
+	if len(condlist) != len(choicelist) {
+		return nil, fmt.Errorf("list of cases must be same length as list of conditions")
+	}
+	if len(condlist) == 0 {
+		return nil, fmt.Errorf("select with an empty condition list is not possible")
+	}
+	for i, c := range condlist {
+		if c.dtype != Bool {
+			return nil, fmt.Errorf("invalid entry %d in condlist: should be boolean ndarray", i)
+		}
+	}
+
+	arrays, err := BroadcastArrays(append(append([]*NDArray{Scalar(def)}, condlist...), choicelist...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(condlist)
+	types := make([]DType, 0, n+1)
+	for _, c := range choicelist {
+		types = append(types, c.dtype)
+	}
+	common, err := ResultType(append(types, defaultDType(def))...)
+	if err != nil {
+		return nil, err
+	}
+	out := allocOf(append([]int(nil), arrays[0].shape...), common)
+	for i := 0; i < out.data.len(); i++ {
+		out.data.setFloat(i, def)
+	}
+
+	// Apply the conditions last to first, so the first true one is written last
+	for k := n - 1; k >= 0; k-- {
+		cond, choice := gatherData(arrays[1+k]), gather(arrays[1+n+k])
+		for i, c := range cond {
+			if c != 0 {
+				out.data.copyFrom(i, choice, i)
+			}
+		}
+	}
+	return out, nil
+}
+
+// defaultDType is the dtype np.asarray gives Select's default: Int64 for a whole
+// number, as for a Python int, and Float64 otherwise.
+func defaultDType(def float64) DType {
+	if def == math.Trunc(def) && math.Abs(def) < 1<<63 {
+		return Int64
+	}
+	return Float64
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Choose – Build an array from an index array and a list of choices          ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.choose(a, choices, mode=mode)`: out[i] =                       ║
+// ║   choices[a[i]][i].                                                                ║
+// ║                                                                                    ║
+// ║   - `a` and every choice are broadcast together                                    ║
+// ║   - Elements of `a` must be whole numbers; the mode decides what                   ║
+// ║     happens to values outside [0, len(choices)), as in Take                        ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   choices = {[0 1 2 3], [10 11 12 13], [20 21 22 23]}                              ║
+// ║   Choose([2 0 1 0], choices, ModeRaise) → [20 1 12 3]                              ║
+// ║   Choose([2 4 1 0], choices, ModeClip)  → [20 21 12 3]                             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Choose(a *NDArray, choices []*NDArray, mode IndexMode) (*NDArray, error) {
+
+	if len(choices) == 0 {
+		return nil, fmt.Errorf("choices must not be empty")
+	}
+
+	arrays, err := BroadcastArrays(append([]*NDArray{a}, choices...)...)
+	if err != nil {
+		return nil, fmt.Errorf("shape mismatch: objects cannot be broadcast to a single shape")
+	}
+
+	data := make([]storage, len(choices))
+	for k := range choices {
+		data[k] = gather(arrays[1+k])
+	}
+
+	common, err := commonDType(choices)
+	if err != nil {
+		return nil, err
+	}
+	out := allocOf(append([]int(nil), arrays[0].shape...), common)
+	for i, v := range gatherData(arrays[0]) {
+		k, ok := resolveIndex(int(v), len(choices), mode)
+		if !ok || float64(int(v)) != v || (mode == ModeRaise && v < 0) {
+			return nil, fmt.Errorf("invalid entry in choice array: %v", v)
+		}
+		out.data.copyFrom(i, data[k], i)
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Place – Write values, in order, where a mask is true                       ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.place(a, mask, vals)`, in place.                               ║
+// ║                                                                                    ║
+// ║   - `mask` must have as many elements as `a`; both are read in                     ║
+// ║     row-major order and any non-zero mask element selects                          ║
+// ║   - The k-th selected element gets vals[k % len(vals)]                             ║
+// ║   - Works on views (writes go through to the base)                                 ║
+// ║                                                                                    ║
+// ║   Returns: error                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = [0 1 2 3 4 5],  mask = a > 2                                                 ║
+// ║   a.Place(mask, []float64{44, 55}) → a = [0 1 2 44 55 44]                          ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Place(mask *NDArray, vals []float64) error {
+
+	offsets, err := a.flatMask(mask, false)
+	if err != nil {
+		return err
+	}
+	if len(offsets) > 0 && len(vals) == 0 {
+		return fmt.Errorf("cannot insert from an empty array")
+	}
+
+	for k, offset := range offsets {
+		a.data.setFloat(offset, vals[k%len(vals)])
+	}
+	return nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: PutMask – Write values by position where a mask is true                    ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.putmask(a, mask, values)`, in place.                           ║
+// ║                                                                                    ║
+// ║   - `mask` must have the same shape as `a`                                         ║
+// ║   - Unlike Place, the element at flat position n gets                              ║
+// ║     values[n % len(values)], whether or not earlier ones were selected             ║
+// ║                                                                                    ║
+// ║   Returns: error                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = [0 1 2 3 4 5],  mask = a > 2                                                 ║
+// ║   a.PutMask(mask, []float64{-33, -44}) → a = [0 1 2 -44 -33 -44]                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) PutMask(mask *NDArray, values []float64) error {
+
+	offsets, err := a.flatMask(mask, true)
+	if err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	for n, offset := range offsets {
+		if offset >= 0 {
+			a.data.setFloat(offset, values[n%len(values)])
+		}
+	}
+	return nil
+}
+
+// flatMask returns, for each selected flat position of a, its data offset. With
+// positional set, the result has one entry per element of a, -1 where not selected.
+func (a *NDArray) flatMask(mask *NDArray, positional bool) ([]int, error) {
+
+	if !a.flags.Writeable {
+		return nil, fmt.Errorf("assignment destination is read-only")
+	}
+	if positional && !sameShape(mask.shape, a.shape) {
+		return nil, fmt.Errorf("putmask: mask and data must be the same shape, got %v and %v", mask.shape, a.shape)
+	}
+	if mask.Size() != a.Size() {
+		return nil, fmt.Errorf("place: mask and data must be the same size, got %d and %d", mask.Size(), a.Size())
+	}
+
+	offsets := []int{}
+	for n, m := range gatherData(mask) {
+		switch {
+		case m != 0:
+			offsets = append(offsets, flatOffset(a, n))
+		case positional:
+			offsets = append(offsets, -1)
+		}
+	}
+	return offsets, nil
+}
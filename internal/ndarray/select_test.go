@@ -0,0 +1,211 @@
+package ndarray_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestWhere(t *testing.T) {
+	a, _ := ndarray.FromSlice([]float64{-1, 2, -3, 4}, 2, 2)
+	neg, _ := ndarray.Less(a, ndarray.Scalar(0))
+
+	res, err := ndarray.Where(neg, ndarray.Scalar(0), a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := res.String(), "NDArray(shape=[2 2], data=[0 2 0 4])"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	// The condition broadcasts against x and y
+	cols, _ := ndarray.FromNested([]bool{true, false})
+	res, _ = ndarray.Where(cols, a, ndarray.Scalar(9))
+	if got, want := res.String(), "NDArray(shape=[2 2], data=[-1 9 -3 9])"; got != want {
+		t.Errorf("broadcast: got %s, want %s", got, want)
+	}
+
+	other, _ := ndarray.FromSlice([]float64{1, 2, 3})
+	if _, err := ndarray.Where(neg, a, other); err == nil {
+		t.Errorf("expected error for incompatible shapes")
+	}
+}
+
+func TestNonzeroArgWhere(t *testing.T) {
+	a, _ := ndarray.FromNested([][]int{{3, 0, 0}, {0, 4, 0}, {5, 6, 0}})
+
+	idx := ndarray.Nonzero(a)
+	if len(idx) != 2 {
+		t.Fatalf("got %d index arrays, want 2", len(idx))
+	}
+	if got, want := idx[0].String(), "NDArray(shape=[4], data=[0 1 2 2])"; got != want {
+		t.Errorf("rows: got %s, want %s", got, want)
+	}
+	if got, want := idx[1].String(), "NDArray(shape=[4], data=[0 1 0 1])"; got != want {
+		t.Errorf("cols: got %s, want %s", got, want)
+	}
+
+	// The index arrays select the non-zero elements
+	res, err := a.Gather(ndarray.Indices(idx[0]), ndarray.Indices(idx[1]))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := res.String(), "NDArray(shape=[4], data=[3 4 5 6])"; got != want {
+		t.Errorf("gather: got %s, want %s", got, want)
+	}
+
+	if got, want := ndarray.WhereIndices(a)[1].String(), idx[1].String(); got != want {
+		t.Errorf("WhereIndices: got %s, want %s", got, want)
+	}
+
+	if got, want := ndarray.ArgWhere(a).String(), "NDArray(shape=[4 2], data=[0 0 1 1 2 0 2 1])"; got != want {
+		t.Errorf("ArgWhere: got %s, want %s", got, want)
+	}
+	if got, want := ndarray.ArgWhere(ndarray.ZerosLike(a)).String(), "NDArray(shape=[0 2], data=[])"; got != want {
+		t.Errorf("ArgWhere empty: got %s, want %s", got, want)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	a := seq(t, 2, 3)
+	cond, _ := ndarray.FromSlice([]float64{1, 0, 0, 1, 0, 2})
+
+	res, err := ndarray.Extract(cond, a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := res.String(), "NDArray(shape=[3], data=[0 3 5])"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	long, _ := ndarray.FromSlice([]float64{0, 0, 0, 0, 0, 0, 1})
+	if _, err := ndarray.Extract(long, a); err == nil {
+		t.Errorf("expected error when cond selects past the end")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	x := seq(t, 6)
+	low, _ := ndarray.Less(x, ndarray.Scalar(3))
+	high, _ := ndarray.Greater(x, ndarray.Scalar(4))
+	mid, _ := ndarray.Less(x, ndarray.Scalar(4))
+	sq, _ := ndarray.Mul(x, x)
+
+	res, err := ndarray.Select([]*ndarray.NDArray{low, high, mid}, []*ndarray.NDArray{x, sq, ndarray.Scalar(-1)}, 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := res.String(), "NDArray(shape=[6], data=[0 1 2 -1 42 25])"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	// The default takes part in the result type, as np.asarray(default) does
+	ints := typed[int8](t, 1, 2, 3)
+	first, _ := ndarray.Less(ints, ndarray.Scalar(2))
+	tests := []struct {
+		def   float64
+		dtype ndarray.DType
+		want  string
+	}{
+		{2.5, ndarray.Float64, "[1 2.5 2.5]"},
+		{math.NaN(), ndarray.Float64, "[1 NaN NaN]"},
+		{-7, ndarray.Int64, "[1 -7 -7]"},
+	}
+	for _, tt := range tests {
+		got, err := ndarray.Select([]*ndarray.NDArray{first}, []*ndarray.NDArray{ints}, tt.def)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "NDArray(shape=[3], data=" + tt.want + ")"; got.String() != want || got.DType() != tt.dtype {
+			t.Errorf("default %v: got %s (%s), want %s (%s)", tt.def, got, got.DType(), want, tt.dtype)
+		}
+	}
+
+	if _, err := ndarray.Select([]*ndarray.NDArray{x}, []*ndarray.NDArray{x}, 0); err == nil {
+		t.Errorf("expected error for non-bool condition")
+	}
+	if _, err := ndarray.Select([]*ndarray.NDArray{low}, nil, 0); err == nil {
+		t.Errorf("expected error for mismatched lists")
+	}
+}
+
+func TestChoose(t *testing.T) {
+	choices := make([]*ndarray.NDArray, 3)
+	for i := range choices {
+		c, _ := ndarray.Add(seq(t, 4), ndarray.Scalar(float64(10*i)))
+		choices[i] = c
+	}
+
+	tests := []struct {
+		name  string
+		index []float64
+		mode  ndarray.IndexMode
+		want  string
+	}{
+		{"raise", []float64{2, 0, 1, 0}, ndarray.ModeRaise, "NDArray(shape=[4], data=[20 1 12 3])"},
+		{"clip", []float64{2, 4, 1, -1}, ndarray.ModeClip, "NDArray(shape=[4], data=[20 21 12 3])"},
+		{"wrap", []float64{2, 4, 1, -1}, ndarray.ModeWrap, "NDArray(shape=[4], data=[20 11 12 23])"},
+	}
+
+	for _, tt := range tests {
+		a, _ := ndarray.FromSlice(tt.index)
+		res, err := ndarray.Choose(a, choices, tt.mode)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got := res.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	// Index and choices broadcast together
+	col, _ := ndarray.FromSlice([]float64{0, 1}, 2, 1)
+	res, _ := ndarray.Choose(col, []*ndarray.NDArray{ndarray.Scalar(-10), seq(t, 3)}, ndarray.ModeRaise)
+	if got, want := res.String(), "NDArray(shape=[2 3], data=[-10 -10 -10 0 1 2])"; got != want {
+		t.Errorf("broadcast: got %s, want %s", got, want)
+	}
+
+	for _, bad := range []float64{3, -1, 0.5} {
+		a, _ := ndarray.FromSlice([]float64{bad})
+		if _, err := ndarray.Choose(a, choices, ndarray.ModeRaise); err == nil {
+			t.Errorf("expected error for index %v", bad)
+		}
+	}
+}
+
+func TestPlacePutMask(t *testing.T) {
+	a := seq(t, 6)
+	mask, _ := ndarray.Greater(a, ndarray.Scalar(2))
+	if err := a.Place(mask, []float64{44, 55}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := a.String(), "NDArray(shape=[6], data=[0 1 2 44 55 44])"; got != want {
+		t.Errorf("Place: got %s, want %s", got, want)
+	}
+
+	b := seq(t, 6)
+	if err := b.PutMask(mask, []float64{-33, -44}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := b.String(), "NDArray(shape=[6], data=[0 1 2 -44 -33 -44])"; got != want {
+		t.Errorf("PutMask: got %s, want %s", got, want)
+	}
+
+	// Writes go through views; Place only needs matching sizes
+	c := seq(t, 2, 3)
+	flat, _ := ndarray.FromSlice([]float64{1, 0, 1, 0, 1, 0})
+	if err := c.T().Place(flat, []float64{7}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := c.String(), "NDArray(shape=[2 3], data=[7 7 7 3 4 5])"; got != want {
+		t.Errorf("Place view: got %s, want %s", got, want)
+	}
+	if err := c.PutMask(flat, []float64{1}); err == nil {
+		t.Errorf("expected PutMask shape error")
+	}
+	if err := a.Place(mask, nil); err == nil {
+		t.Errorf("expected error for empty values")
+	}
+}
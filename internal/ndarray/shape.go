@@ -17,3 +17,919 @@
 // ╚════════════════════════════════════════════════════════════════════════════════════╝
 
 package ndarray
+
+import (
+	"fmt"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: shapeSize – Number of elements described by a shape                        ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Multiplies all dimensions together. An empty shape describes a single            ║
+// ║   element (a scalar), exactly like NumPy's `prod(())`.                             ║
+// ║                                                                                    ║
+// ║   Returns: int                                                                     ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   shapeSize([]int{2, 3, 4}) → 24                                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func shapeSize(shape []int) int {
+	size := 1
+	for _, dim := range shape {
+		size *= dim
+	}
+	return size
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: cStrides – Row-major strides for a shape                                   ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Computes the strides of a freshly allocated, C-ordered buffer: the last          ║
+// ║   axis moves by one element and every other axis jumps over the block              ║
+// ║   formed by the axes to its right.                                                 ║
+// ║                                                                                    ║
+// ║   Returns: []int                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   cStrides([]int{3, 4}) → [4, 1]                                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func cStrides(shape []int) []int {
+	strides := make([]int, len(shape))
+	stride := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		strides[i] = stride
+		stride *= shape[i]
+	}
+	return strides
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: resolveShape – Validate a requested shape and infer `-1`                   ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Checks a target shape against the number of elements it must hold.               ║
+// ║                                                                                    ║
+// ║   - At most one dimension may be `-1`; it is inferred from `size`                  ║
+// ║   - Every other dimension must be positive                                         ║
+// ║   - The product of the final shape must equal `size`                               ║
+// ║                                                                                    ║
+// ║   Returns: ([]int, error) – a fresh slice, never the caller's                      ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   resolveShape(12, []int{3, -1}) → [3, 4]                                          ║
+// ║   resolveShape(12, []int{5, -1}) → error                                           ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func resolveShape(size int, shape []int) ([]int, error) {
+
+	if len(shape) == 0 {
+		return nil, fmt.Errorf("shape must have at least one dimension")
+	}
+
+	if len(shape) > 32 {
+		return nil, fmt.Errorf("shape has too many dimensions (max 32)")
+	}
+
+	resolved := make([]int, len(shape))
+	unknown := -1
+	known := 1
+	for i, dim := range shape {
+		switch {
+		case dim == -1:
+			if unknown >= 0 {
+				return nil, fmt.Errorf("can only specify one unknown dimension")
+			}
+			unknown = i
+		case dim <= 0:
+			return nil, fmt.Errorf("dimension size must be positive, got %d", dim)
+		default:
+			known *= dim
+		}
+		resolved[i] = dim
+	}
+
+	if unknown >= 0 {
+		if size%known != 0 {
+			return nil, fmt.Errorf("cannot reshape array of size %d into shape %v", size, shape)
+		}
+		resolved[unknown] = size / known
+	}
+
+	if shapeSize(resolved) != size {
+		return nil, fmt.Errorf("cannot reshape array of size %d into shape %v", size, shape)
+	}
+
+	return resolved, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: reshapeStrides – Strides for a reshape that avoids copying                 ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Port of NumPy's `_attempt_nocopy_reshape`. Axes of the old shape are             ║
+// ║   grouped with axes of the new shape so that both groups cover the same            ║
+// ║   number of elements; a group can be re-strided only if its old axes are           ║
+// ║   laid out contiguously with respect to one another.                               ║
+// ║                                                                                    ║
+// ║   - Size-1 axes are ignored, their stride is irrelevant                            ║
+// ║   - Works for any strides, not only C-contiguous ones                              ║
+// ║   - Empty arrays always succeed, with C-order strides                              ║
+// ║                                                                                    ║
+// ║   Returns: ([]int, bool) – false means the data must be copied                     ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   shape [2, 6], strides [6, 1] → new [3, 4] → strides [4, 1], true                 ║
+// ║   shape [3, 2], strides [1, 3] → new [6]    → nil, false (transposed)              ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func reshapeStrides(oldShape, oldStrides, newShape []int) ([]int, bool) {
+
+	// An empty array has no elements to lay out, and a 0 would stall the grouping
+	if shapeSize(oldShape) == 0 || shapeSize(newShape) == 0 {
+		return cStrides(newShape), true
+	}
+
+	// Drop size-1 axes from the old layout, they never constrain the result
+	var oShape, oStrides []int
+	for i, dim := range oldShape {
+		if dim != 1 {
+			oShape = append(oShape, dim)
+			oStrides = append(oStrides, oldStrides[i])
+		}
+	}
+
+	newStrides := make([]int, len(newShape))
+	oi, oj := 0, 1
+	ni, nj := 0, 1
+	for ni < len(newShape) && oi < len(oShape) {
+		np := newShape[ni]
+		op := oShape[oi]
+
+		// Grow whichever side is smaller until both groups hold the same count
+		for np != op {
+			if np < op {
+				np *= newShape[nj]
+				nj++
+			} else {
+				op *= oShape[oj]
+				oj++
+			}
+		}
+
+		// The old axes of the group must be contiguous relative to each other
+		for k := oi; k < oj-1; k++ {
+			if oStrides[k] != oShape[k+1]*oStrides[k+1] {
+				return nil, false
+			}
+		}
+
+		// Lay the new axes of the group out over the innermost old stride
+		newStrides[nj-1] = oStrides[oj-1]
+		for k := nj - 1; k > ni; k-- {
+			newStrides[k-1] = newStrides[k] * newShape[k]
+		}
+
+		ni = nj
+		nj++
+		oi = oj
+		oj++
+	}
+
+	// Trailing size-1 axes of the new shape get a harmless stride
+	last := 1
+	if ni >= 1 {
+		last = newStrides[ni-1]
+	}
+	for k := ni; k < len(newShape); k++ {
+		newStrides[k] = last
+	}
+
+	return newStrides, true
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: isCContiguous / isFContiguous – Layout checks for a strided view           ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   A layout is C-contiguous when walking it in row-major order visits               ║
+// ║   consecutive elements of `data` (F-contiguous: column-major order).               ║
+// ║                                                                                    ║
+// ║   - Axes of length 1 are skipped, their stride is never used                       ║
+// ║   - Empty arrays are contiguous in both orders                                     ║
+// ║                                                                                    ║
+// ║   Returns: bool                                                                    ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   shape [3, 4], strides [4, 1] → C: true,  F: false                                ║
+// ║   shape [4, 3], strides [1, 4] → C: false, F: true  (a transpose)                  ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func isCContiguous(shape, strides []int) bool {
+	if shapeSize(shape) == 0 {
+		return true
+	}
+
+	expected := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		if shape[i] == 1 {
+			continue
+		}
+		if strides[i] != expected {
+			return false
+		}
+		expected *= shape[i]
+	}
+	return true
+}
+
+func isFContiguous(shape, strides []int) bool {
+	if shapeSize(shape) == 0 {
+		return true
+	}
+
+	expected := 1
+	for i := 0; i < len(shape); i++ {
+		if shape[i] == 1 {
+			continue
+		}
+		if strides[i] != expected {
+			return false
+		}
+		expected *= shape[i]
+	}
+	return true
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: normalizeAxis – Validate an axis number, allowing negatives                ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Maps an axis in [-ndim, ndim) onto [0, ndim).                                    ║
+// ║                                                                                    ║
+// ║   Returns: (int, error)                                                            ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   normalizeAxis(-1, 3) → 2                                                         ║
+// ║   normalizeAxis(3, 3)  → error                                                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func normalizeAxis(axis, ndim int) (int, error) {
+	if axis < -ndim || axis >= ndim {
+		return 0, fmt.Errorf("axis %d is out of bounds for array of dimension %d", axis, ndim)
+	}
+	if axis < 0 {
+		axis += ndim
+	}
+	return axis, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: BroadcastShapes – Common shape of several operands                         ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Applies NumPy's broadcasting rules:                                              ║
+// ║                                                                                    ║
+// ║   - Shapes are aligned on their trailing (rightmost) axes                          ║
+// ║   - Missing leading axes behave as length 1                                        ║
+// ║   - Along each axis the lengths must be equal, or one of them must be 1            ║
+// ║                                                                                    ║
+// ║   Returns: ([]int, error) – error names the first incompatible pair                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   DIAGRAM:                                                                         ║
+// ║                                                                                    ║
+// ║        [8, 1, 6, 1]                                                                ║
+// ║           [7, 1, 5]                                                                ║
+// ║      ──────────────                                                                ║
+// ║        [8, 7, 6, 5]                                                                ║
+// ║                                                                                    ║
+// ║   EXAMPLE:                                                                         ║
+// ║   BroadcastShapes([]int{2, 3}, []int{3})    → [2, 3]                               ║
+// ║   BroadcastShapes([]int{2, 3}, []int{4})    → error                                ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func BroadcastShapes(shapes ...[]int) ([]int, error) {
+
+	ndim := 0
+	for _, shape := range shapes {
+		if len(shape) > ndim {
+			ndim = len(shape)
+		}
+	}
+
+	if ndim > 32 {
+		return nil, fmt.Errorf("shape has too many dimensions (max 32)")
+	}
+
+	result := make([]int, ndim)
+	for i := range result {
+		result[i] = 1
+	}
+
+	// owner[i] remembers which argument fixed result[i], for error messages
+	owner := make([]int, ndim)
+	for arg, shape := range shapes {
+		pad := ndim - len(shape)
+		for i, dim := range shape {
+			axis := pad + i
+			switch {
+			case dim == result[axis] || dim == 1:
+			case result[axis] == 1:
+				result[axis] = dim
+				owner[axis] = arg
+			default:
+				first := owner[axis]
+				return nil, fmt.Errorf("shape mismatch: objects cannot be broadcast to a single shape. Mismatch is between arg %d with shape %v and arg %d with shape %v", first, shapes[first], arg, shape)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: broadcastStrides – Strides that stretch an array over a larger shape       ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Length-1 and missing axes get stride 0, so every position along them             ║
+// ║   reads the same element. No data is touched.                                      ║
+// ║                                                                                    ║
+// ║   Returns: ([]int, error)                                                          ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   shape [3, 1], strides [1, 1] → target [2, 3, 4] → strides [0, 1, 0]              ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func broadcastStrides(shape, strides, target []int) ([]int, error) {
+
+	if len(shape) > len(target) {
+		return nil, fmt.Errorf("input operand has more dimensions than allowed by the axis remapping: cannot broadcast shape %v to %v", shape, target)
+	}
+
+	result := make([]int, len(target))
+	pad := len(target) - len(shape)
+	for i, dim := range shape {
+		switch {
+		case dim == target[pad+i]:
+			result[pad+i] = strides[i]
+		case dim == 1:
+			result[pad+i] = 0
+		default:
+			return nil, fmt.Errorf("operands could not be broadcast together with remapped shapes [original->remapped]: %v and requested shape %v", shape, target)
+		}
+	}
+
+	return result, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: BroadcastTo – Read-only view of an array stretched to a shape              ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.broadcast_to(a, shape)`.                                       ║
+// ║                                                                                    ║
+// ║   - Repeated elements are not copied, their axes get stride 0                      ║
+// ║   - The view is read-only: one memory cell backs many positions                    ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   a = [1 2 3]               (shape [3],    strides [1])                            ║
+// ║   b, _ := BroadcastTo(a, 2, 3)                                                     ║
+// ║   b = [[1 2 3] [1 2 3]]     (shape [2, 3], strides [0, 1])                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func BroadcastTo(a *NDArray, shape ...int) (*NDArray, error) {
+
+	if len(shape) > 32 {
+		return nil, fmt.Errorf("shape has too many dimensions (max 32)")
+	}
+
+	for _, dim := range shape {
+		if dim < 0 {
+			return nil, fmt.Errorf("all elements of broadcast shape must be non-negative, got %v", shape)
+		}
+	}
+
+	target := append([]int(nil), shape...)
+	strides, err := broadcastStrides(a.shape, a.strides, target)
+	if err != nil {
+		return nil, err
+	}
+
+	v := a.view(target, strides, a.offset)
+	v.flags.Writeable = false
+	return v, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: BroadcastArrays – Broadcast several arrays against each other              ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.broadcast_arrays(*arrays)`.                                    ║
+// ║                                                                                    ║
+// ║   - Computes the common shape with BroadcastShapes                                 ║
+// ║   - Returns one read-only BroadcastTo view per input, in the same order            ║
+// ║                                                                                    ║
+// ║   Returns: ([]*NDArray, error)                                                     ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║                                                                                    ║
+// ║   x.Shape() → [3, 1],  y.Shape() → [4]                                             ║
+// ║   out, _ := BroadcastArrays(x, y)                                                  ║
+// ║   out[0].Shape(), out[1].Shape() → [3, 4], [3, 4]                                  ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func BroadcastArrays(arrays ...*NDArray) ([]*NDArray, error) {
+
+	shapes := make([][]int, len(arrays))
+	for i, a := range arrays {
+		shapes[i] = a.shape
+	}
+
+	shape, err := BroadcastShapes(shapes...)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*NDArray, len(arrays))
+	for i, a := range arrays {
+		if out[i], err = BroadcastTo(a, shape...); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// sameShape reports whether two shapes (or stride lists) are identical.
+func sameShape(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// permute returns values reordered so that out[i] = values[perm[i]].
+func permute(values, perm []int) []int {
+	out := make([]int, len(perm))
+	for i, p := range perm {
+		out[i] = values[p]
+	}
+	return out
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Transpose – Permute the axes of an array (view)                            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a.transpose(*axes)`. Output axis i is input axis                  ║
+// ║   axes[i]; only shape and strides are reordered, no data moves.                    ║
+// ║                                                                                    ║
+// ║   - Without axes, the order is reversed (matrix transpose for 2-D)                 ║
+// ║   - Axes may be negative; each must appear exactly once                            ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   DIAGRAM:                                                                         ║
+// ║                                                                                    ║
+// ║   shape [2, 3, 4], strides [12, 4, 1]                                              ║
+// ║        │  Transpose(1, 2, 0)                                                       ║
+// ║        ▼                                                                           ║
+// ║   shape [3, 4, 2], strides [4, 1, 12]                                              ║
+// ║                                                                                    ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a.Transpose()          → same as a.T()                                           ║
+// ║   a.Transpose(0, 0, 1)   → error (repeated axis)                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Transpose(axes ...int) (*NDArray, error) {
+
+	ndim := len(a.shape)
+	if len(axes) == 0 {
+		perm := identityPerm(ndim)
+		for i, j := 0, ndim-1; i < j; i, j = i+1, j-1 {
+			perm[i], perm[j] = perm[j], perm[i]
+		}
+		return a.permuted(perm), nil
+	}
+
+	if len(axes) != ndim {
+		return nil, fmt.Errorf("axes don't match array: got %d axes for %d dimensions", len(axes), ndim)
+	}
+
+	perm, err := normalizeAxes(axes, ndim)
+	if err != nil {
+		return nil, err
+	}
+	return a.permuted(perm), nil
+}
+
+// T returns the array with its axes reversed, like `a.T`.
+func (a *NDArray) T() *NDArray {
+	v, _ := a.Transpose()
+	return v
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: SwapAxes – Interchange two axes (view)                                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.swapaxes(a, axis1, axis2)`.                                    ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – error if an axis is out of range                    ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a.Shape()         → [2, 3, 4]                                                    ║
+// ║   a.SwapAxes(0, -1) → shape [4, 3, 2]                                              ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) SwapAxes(axis1, axis2 int) (*NDArray, error) {
+
+	ndim := len(a.shape)
+	i, err := normalizeAxis(axis1, ndim)
+	if err != nil {
+		return nil, err
+	}
+	j, err := normalizeAxis(axis2, ndim)
+	if err != nil {
+		return nil, err
+	}
+
+	perm := identityPerm(ndim)
+	perm[i], perm[j] = perm[j], perm[i]
+	return a.permuted(perm), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: MoveAxis – Move axes to new positions (view)                               ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.moveaxis(a, source, destination)`.                             ║
+// ║                                                                                    ║
+// ║   - source[i] ends up at position destination[i]                                   ║
+// ║   - The remaining axes keep their relative order                                   ║
+// ║   - Both lists must have the same length and no repeated axes                      ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a.Shape()                              → [3, 4, 5]                               ║
+// ║   a.MoveAxis([]int{0}, []int{-1})        → shape [4, 5, 3]                         ║
+// ║   a.MoveAxis([]int{0, 1}, []int{-1, -2}) → shape [5, 4, 3]                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) MoveAxis(source, destination []int) (*NDArray, error) {
+
+	if len(source) != len(destination) {
+		return nil, fmt.Errorf("source and destination must have the same number of elements")
+	}
+
+	ndim := len(a.shape)
+	src, err := normalizeAxes(source, ndim)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := normalizeAxes(destination, ndim)
+	if err != nil {
+		return nil, err
+	}
+
+	moved := make([]bool, ndim)
+	for _, s := range src {
+		moved[s] = true
+	}
+
+	// Fill the free positions with the untouched axes, in order
+	perm := make([]int, ndim)
+	for i := range perm {
+		perm[i] = -1
+	}
+	for i, d := range dst {
+		perm[d] = src[i]
+	}
+	next := 0
+	for axis := 0; axis < ndim; axis++ {
+		if moved[axis] {
+			continue
+		}
+		for perm[next] >= 0 {
+			next++
+		}
+		perm[next] = axis
+	}
+
+	return a.permuted(perm), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: RollAxis – Roll an axis backwards to a position (view)                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.rollaxis(a, axis, start)`: `axis` is moved so that             ║
+// ║   it lies before the axis currently at position `start`.                           ║
+// ║                                                                                    ║
+// ║   - start may be in [-ndim, ndim]; ndim means "to the end"                         ║
+// ║   - Kept for NumPy parity; MoveAxis is usually clearer                             ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a.Shape()           → [3, 4, 5, 6]                                               ║
+// ║   a.RollAxis(3, 1)    → shape [3, 6, 4, 5]                                         ║
+// ║   a.RollAxis(1, 4)    → shape [3, 5, 6, 4]                                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) RollAxis(axis, start int) (*NDArray, error) {
+
+	ndim := len(a.shape)
+	axis, err := normalizeAxis(axis, ndim)
+	if err != nil {
+		return nil, err
+	}
+
+	if start < -ndim || start > ndim {
+		return nil, fmt.Errorf("start %d is out of bounds for array of dimension %d", start, ndim)
+	}
+	if start < 0 {
+		start += ndim
+	}
+	if axis < start {
+		start--
+	}
+
+	perm := make([]int, 0, ndim)
+	for i := 0; i < ndim; i++ {
+		if i != axis {
+			perm = append(perm, i)
+		}
+	}
+	perm = append(perm[:start], append([]int{axis}, perm[start:]...)...)
+
+	return a.permuted(perm), nil
+}
+
+// permuted returns a view whose axis i is axis perm[i] of a.
+func (a *NDArray) permuted(perm []int) *NDArray {
+	return a.view(permute(a.shape, perm), permute(a.strides, perm), a.offset)
+}
+
+// identityPerm returns [0, 1, ..., n-1].
+func identityPerm(n int) []int {
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	return perm
+}
+
+// normalizeAxes validates a list of axes, resolving negatives and rejecting repeats.
+func normalizeAxes(axes []int, ndim int) ([]int, error) {
+
+	out := make([]int, len(axes))
+	seen := make([]bool, ndim)
+	for i, axis := range axes {
+		ax, err := normalizeAxis(axis, ndim)
+		if err != nil {
+			return nil, err
+		}
+		if seen[ax] {
+			return nil, fmt.Errorf("repeated axis %d", axis)
+		}
+		seen[ax] = true
+		out[i] = ax
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Squeeze – Remove axes of length one (view)                                 ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.squeeze(a, axis)`.                                             ║
+// ║                                                                                    ║
+// ║   - Without axes, every length-1 axis is removed                                   ║
+// ║   - With axes, only those are removed; each must have length 1                     ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a.Shape()          → [1, 3, 1]                                                   ║
+// ║   a.Squeeze()        → shape [3]                                                   ║
+// ║   a.Squeeze(-1)      → shape [1, 3]                                                ║
+// ║   a.Squeeze(1)       → error (axis 1 has length 3)                                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Squeeze(axes ...int) (*NDArray, error) {
+
+	drop := make([]bool, len(a.shape))
+	if len(axes) == 0 {
+		for i, dim := range a.shape {
+			drop[i] = dim == 1
+		}
+	} else {
+		normalized, err := normalizeAxes(axes, len(a.shape))
+		if err != nil {
+			return nil, err
+		}
+		for _, axis := range normalized {
+			if a.shape[axis] != 1 {
+				return nil, fmt.Errorf("cannot select an axis to squeeze out which has size not equal to one")
+			}
+			drop[axis] = true
+		}
+	}
+
+	shape := []int{}
+	strides := []int{}
+	for i := range a.shape {
+		if !drop[i] {
+			shape = append(shape, a.shape[i])
+			strides = append(strides, a.strides[i])
+		}
+	}
+	return a.view(shape, strides, a.offset), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: ExpandDims – Insert a new axis of length one (view)                        ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.expand_dims(a, axis)`: the new axis sits at                    ║
+// ║   position `axis` of the result.                                                   ║
+// ║                                                                                    ║
+// ║   - axis may be in [-(ndim+1), ndim]                                               ║
+// ║   - The result may not exceed 32 dimensions, the same cap as `New`                 ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error)                                                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a.Shape()           → [2, 3]                                                     ║
+// ║   a.ExpandDims(0)     → shape [1, 2, 3]                                            ║
+// ║   a.ExpandDims(-1)    → shape [2, 3, 1]                                            ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) ExpandDims(axis int) (*NDArray, error) {
+
+	ndim := len(a.shape) + 1
+	if ndim > 32 {
+		return nil, fmt.Errorf("shape has too many dimensions (max 32)")
+	}
+
+	axis, err := normalizeAxis(axis, ndim)
+	if err != nil {
+		return nil, err
+	}
+
+	shape := make([]int, 0, ndim)
+	strides := make([]int, 0, ndim)
+	shape = append(append(append(shape, a.shape[:axis]...), 1), a.shape[axis:]...)
+	strides = append(append(append(strides, a.strides[:axis]...), 0), a.strides[axis:]...)
+	return a.view(shape, strides, a.offset), nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: Order – Memory layout used when flattening or copying                      ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║     - OrderC : row-major, last axis varies fastest                                 ║
+// ║     - OrderF : column-major (Fortran), first axis varies fastest                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type Order int
+
+const (
+	OrderC Order = iota
+	OrderF
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Ravel – Flatten to 1-D, as a view whenever possible                        ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a.ravel(order)`.                                                  ║
+// ║                                                                                    ║
+// ║   - Returns a view when the elements can be reached with a single                  ║
+// ║     stride in the requested order (e.g. a contiguous array);                       ║
+// ║     otherwise a copy                                                               ║
+// ║   - Use Flatten when an independent copy is required                               ║
+// ║                                                                                    ║
+// ║   Returns: *NDArray                                                                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = [[0 1 2] [3 4 5]]                                                            ║
+// ║   a.Ravel(OrderC) → [0 1 2 3 4 5]   (view)                                         ║
+// ║   a.Ravel(OrderF) → [0 3 1 4 2 5]   (copy)                                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Ravel(order Order) *NDArray {
+
+	src := a
+	if order == OrderF {
+		src = a.T()
+	}
+
+	flat, _ := src.Reshape(-1)
+	return flat
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Flatten – Copy the elements into a new 1-D array                           ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `a.flatten(order)`. Unlike Ravel, the result never                 ║
+// ║   shares memory with `a`.                                                          ║
+// ║                                                                                    ║
+// ║   Returns: *NDArray                                                                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a = [[0 1 2] [3 4 5]]                                                            ║
+// ║   a.Flatten(OrderF) → [0 3 1 4 2 5]                                                ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Flatten(order Order) *NDArray {
+
+	src := a
+	if order == OrderF {
+		src = a.T()
+	}
+
+	shape := []int{a.Size()}
+	return newStorage(gather(src), shape, cStrides(shape))
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: AtLeast1D, AtLeast2D, AtLeast3D – Ensure a minimum rank (view)             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalents of `np.atleast_1d/2d/3d` for a single array. Arrays that             ║
+// ║   already have enough dimensions are returned as plain views.                      ║
+// ║                                                                                    ║
+// ║   - AtLeast2D pads at the front:        [N]    → [1, N]                            ║
+// ║   - AtLeast3D wraps the data in the middle:                                        ║
+// ║                                         []     → [1, 1, 1]                         ║
+// ║                                         [N]    → [1, N, 1]                         ║
+// ║                                         [M, N] → [M, N, 1]                         ║
+// ║                                                                                    ║
+// ║   Returns: *NDArray                                                                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   AtLeast1D(Scalar(5)).Shape() → [1]                                               ║
+// ║   AtLeast3D(v).Shape()         → [1, 4, 1]   (v has shape [4])                     ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func AtLeast1D(a *NDArray) *NDArray {
+	if len(a.shape) == 0 {
+		return a.view([]int{1}, []int{0}, a.offset)
+	}
+	return a.view(a.shape, a.strides, a.offset)
+}
+
+func AtLeast2D(a *NDArray) *NDArray {
+	a = AtLeast1D(a)
+	if len(a.shape) == 1 {
+		v, _ := a.ExpandDims(0)
+		return v
+	}
+	return a
+}
+
+func AtLeast3D(a *NDArray) *NDArray {
+	switch len(a.shape) {
+	case 0, 1:
+		v, _ := AtLeast2D(a).ExpandDims(-1)
+		return v
+	case 2:
+		v, _ := a.ExpandDims(-1)
+		return v
+	default:
+		return a.view(a.shape, a.strides, a.offset)
+	}
+}
@@ -0,0 +1,271 @@
+package ndarray_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestBroadcastShapes(t *testing.T) {
+	tests := []struct {
+		shapes [][]int
+		want   string
+	}{
+		{[][]int{{2, 3}, {3}}, "[2 3]"},
+		{[][]int{{8, 1, 6, 1}, {7, 1, 5}}, "[8 7 6 5]"},
+		{[][]int{{1}, {4, 1}, {3}}, "[4 3]"},
+		{[][]int{{5, 4}}, "[5 4]"},
+	}
+
+	for _, tt := range tests {
+		got, err := ndarray.BroadcastShapes(tt.shapes...)
+		if err != nil {
+			t.Fatalf("BroadcastShapes(%v) failed: %v", tt.shapes, err)
+		}
+		if fmt.Sprint(got) != tt.want {
+			t.Errorf("BroadcastShapes(%v) = %v, want %s", tt.shapes, got, tt.want)
+		}
+	}
+
+	if _, err := ndarray.BroadcastShapes([]int{2, 3}, []int{4}); err == nil {
+		t.Error("expected mismatch error, got nil")
+	}
+}
+
+func TestBroadcastTo(t *testing.T) {
+	a := seq(t, 3)
+
+	b, err := ndarray.BroadcastTo(a, 2, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := b.String(); got != "NDArray(shape=[2 3], data=[0 1 2 0 1 2])" {
+		t.Errorf("unexpected broadcast: %s", got)
+	}
+
+	if fmt.Sprint(b.Strides()) != "[0 1]" {
+		t.Errorf("expected zero stride on the new axis, got %v", b.Strides())
+	}
+
+	if b.Flags().Writeable {
+		t.Error("expected broadcast view to be read-only")
+	}
+
+	if _, err := ndarray.BroadcastTo(a, 2, 4); err == nil {
+		t.Error("expected error broadcasting [3] to [2 4], got nil")
+	}
+}
+
+func TestBroadcastArrays(t *testing.T) {
+	x := seq(t, 3, 1)
+	y := seq(t, 4)
+
+	out, err := ndarray.BroadcastArrays(x, y)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, arr := range out {
+		if fmt.Sprint(arr.Shape()) != "[3 4]" {
+			t.Errorf("output %d: unexpected shape %v", i, arr.Shape())
+		}
+	}
+
+	if val, _ := out[0].Get(2, 3); val != 2 {
+		t.Errorf("expected x[2, 0] = 2, got %f", val)
+	}
+	if val, _ := out[1].Get(2, 3); val != 3 {
+		t.Errorf("expected y[3] = 3, got %f", val)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	a := seq(t, 2, 3)
+
+	at := a.T()
+	if got := at.String(); got != "NDArray(shape=[3 2], data=[0 3 1 4 2 5])" {
+		t.Errorf("unexpected transpose: %s", got)
+	}
+	if at.Base() != a.Base() || !at.Flags().FContiguous || at.Flags().CContiguous {
+		t.Errorf("expected an F-contiguous view, got flags %+v", at.Flags())
+	}
+
+	// Writes through the view land in the original
+	_ = at.Set(42, 2, 1)
+	if v, _ := a.Get(1, 2); v != 42 {
+		t.Errorf("expected write through transpose, got %v", v)
+	}
+
+	b := seq(t, 2, 3, 4)
+	p, err := b.Transpose(1, -1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := p.Strides(); got[0] != 4 || got[1] != 1 || got[2] != 12 {
+		t.Errorf("unexpected strides: %v", got)
+	}
+	if v, _ := p.Get(2, 3, 1); v != 23 {
+		t.Errorf("expected b[1, 2, 3] = 23, got %v", v)
+	}
+
+	for _, axes := range [][]int{{0, 0, 1}, {0, 1}, {0, 1, 3}} {
+		if _, err := b.Transpose(axes...); err == nil {
+			t.Errorf("Transpose(%v): expected error, got nil", axes)
+		}
+	}
+}
+
+func TestSwapMoveRollAxis(t *testing.T) {
+	a := seq(t, 3, 4, 5, 6)
+
+	tests := []struct {
+		name string
+		fn   func() (*ndarray.NDArray, error)
+		want []int
+	}{
+		{"SwapAxes(0, -1)", func() (*ndarray.NDArray, error) { return a.SwapAxes(0, -1) }, []int{6, 4, 5, 3}},
+		{"MoveAxis([0], [-1])", func() (*ndarray.NDArray, error) { return a.MoveAxis([]int{0}, []int{-1}) }, []int{4, 5, 6, 3}},
+		{"MoveAxis([0 1], [-1 -2])", func() (*ndarray.NDArray, error) { return a.MoveAxis([]int{0, 1}, []int{-1, -2}) }, []int{5, 6, 4, 3}},
+		{"MoveAxis([3], [1])", func() (*ndarray.NDArray, error) { return a.MoveAxis([]int{3}, []int{1}) }, []int{3, 6, 4, 5}},
+		{"RollAxis(3, 1)", func() (*ndarray.NDArray, error) { return a.RollAxis(3, 1) }, []int{3, 6, 4, 5}},
+		{"RollAxis(2, 0)", func() (*ndarray.NDArray, error) { return a.RollAxis(2, 0) }, []int{5, 3, 4, 6}},
+		{"RollAxis(1, 4)", func() (*ndarray.NDArray, error) { return a.RollAxis(1, 4) }, []int{3, 5, 6, 4}},
+	}
+
+	for _, tt := range tests {
+		v, err := tt.fn()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got := v.Shape(); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: got shape %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := a.SwapAxes(0, 4); err == nil {
+		t.Error("expected out-of-range error from SwapAxes, got nil")
+	}
+	if _, err := a.MoveAxis([]int{0, 0}, []int{1, 2}); err == nil {
+		t.Error("expected repeated-axis error from MoveAxis, got nil")
+	}
+	if _, err := a.MoveAxis([]int{0}, []int{1, 2}); err == nil {
+		t.Error("expected length mismatch error from MoveAxis, got nil")
+	}
+	if _, err := a.RollAxis(0, 5); err == nil {
+		t.Error("expected out-of-range start from RollAxis, got nil")
+	}
+}
+
+func TestSqueezeExpandDims(t *testing.T) {
+	a, _ := seq(t, 3).Reshape(1, 3, 1)
+
+	tests := []struct {
+		axes []int
+		want string
+	}{
+		{nil, "[3]"},
+		{[]int{-1}, "[1 3]"},
+		{[]int{0, 2}, "[3]"},
+	}
+	for _, tt := range tests {
+		v, err := a.Squeeze(tt.axes...)
+		if err != nil {
+			t.Errorf("Squeeze(%v): unexpected error: %v", tt.axes, err)
+			continue
+		}
+		if got := fmt.Sprint(v.Shape()); got != tt.want {
+			t.Errorf("Squeeze(%v): got shape %s, want %s", tt.axes, got, tt.want)
+		}
+	}
+	if _, err := a.Squeeze(1); err == nil {
+		t.Error("expected error squeezing an axis of length 3, got nil")
+	}
+
+	b := seq(t, 2, 3)
+	for axis, want := range map[int]string{0: "[1 2 3]", 1: "[2 1 3]", -1: "[2 3 1]", -3: "[1 2 3]"} {
+		v, err := b.ExpandDims(axis)
+		if err != nil {
+			t.Errorf("ExpandDims(%d): unexpected error: %v", axis, err)
+			continue
+		}
+		if got := fmt.Sprint(v.Shape()); got != want {
+			t.Errorf("ExpandDims(%d): got shape %s, want %s", axis, got, want)
+		}
+		if !v.Flags().CContiguous || v.Base() != b.Base() {
+			t.Errorf("ExpandDims(%d): expected a contiguous view", axis)
+		}
+	}
+	if _, err := b.ExpandDims(3); err == nil {
+		t.Error("expected out-of-range axis error, got nil")
+	}
+
+	dims := make([]int, 32)
+	for i := range dims {
+		dims[i] = 1
+	}
+	full, _ := ndarray.New(dims...)
+	if _, err := full.ExpandDims(0); err == nil {
+		t.Error("expected error exceeding 32 dimensions, got nil")
+	}
+}
+
+func TestRavelFlatten(t *testing.T) {
+	a := seq(t, 2, 3)
+
+	r := a.Ravel(ndarray.OrderC)
+	if got := r.String(); got != "NDArray(shape=[6], data=[0 1 2 3 4 5])" {
+		t.Errorf("unexpected Ravel: %s", got)
+	}
+	if r.Base() != a.Base() {
+		t.Error("expected Ravel of a contiguous array to be a view")
+	}
+
+	if got := a.Ravel(ndarray.OrderF).String(); got != "NDArray(shape=[6], data=[0 3 1 4 2 5])" {
+		t.Errorf("unexpected Ravel(F): %s", got)
+	}
+	if a.T().Ravel(ndarray.OrderF).Base() != a.Base() {
+		t.Error("expected F-order Ravel of a transpose to be a view")
+	}
+
+	f := a.Flatten(ndarray.OrderC)
+	_ = f.Set(100, 0)
+	if v, _ := a.Get(0, 0); v != 0 {
+		t.Error("expected Flatten to copy")
+	}
+	if got := a.T().Flatten(ndarray.OrderC).String(); got != "NDArray(shape=[6], data=[0 3 1 4 2 5])" {
+		t.Errorf("unexpected Flatten of transpose: %s", got)
+	}
+}
+
+func TestAtLeastND(t *testing.T) {
+	s := ndarray.Scalar(5)
+	v := seq(t, 4)
+	m := seq(t, 2, 3)
+
+	tests := []struct {
+		name string
+		got  *ndarray.NDArray
+		want string
+	}{
+		{"AtLeast1D(scalar)", ndarray.AtLeast1D(s), "[1]"},
+		{"AtLeast2D(scalar)", ndarray.AtLeast2D(s), "[1 1]"},
+		{"AtLeast2D(vector)", ndarray.AtLeast2D(v), "[1 4]"},
+		{"AtLeast3D(scalar)", ndarray.AtLeast3D(s), "[1 1 1]"},
+		{"AtLeast3D(vector)", ndarray.AtLeast3D(v), "[1 4 1]"},
+		{"AtLeast3D(matrix)", ndarray.AtLeast3D(m), "[2 3 1]"},
+		{"AtLeast1D(matrix)", ndarray.AtLeast1D(m), "[2 3]"},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprint(tt.got.Shape()); got != tt.want {
+			t.Errorf("%s: got shape %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	if val, _ := ndarray.AtLeast3D(v).Get(0, 2, 0); val != 2 {
+		t.Errorf("expected element 2, got %v", val)
+	}
+}
@@ -0,0 +1,436 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║     ███████╗████████╗ ██████╗ ██████╗  █████╗  ██████╗ ███████╗                    ║
+// ║     ██╔════╝╚══██╔══╝██╔═══██╗██╔══██╗██╔══██╗██╔════╝ ██╔════╝                    ║
+// ║     ███████╗   ██║   ██║   ██║██████╔╝███████║██║  ███╗█████╗                      ║
+// ║     ╚════██║   ██║   ██║   ██║██╔══██╗██╔══██║██║   ██║██╔══╝                      ║
+// ║     ███████║   ██║   ╚██████╔╝██║  ██║██║  ██║╚██████╔╝███████╗                    ║
+// ║     ╚══════╝   ╚═╝    ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝ ╚═════╝ ╚══════╝                    ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Typed element buffers behind NDArray: one Go slice                                ║
+// ║  type per dtype, with element conversion between them.                             ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/storage.go               ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: storage – The flat buffer an NDArray and its views point into              ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Each dtype is backed by a plain Go slice of its own element type, so             ║
+// ║   a Uint8 array of n elements takes n bytes:                                       ║
+// ║                                                                                    ║
+// ║     - floatBuf[T]   : float64, float32                                             ║
+// ║     - intBuf[T]     : int8 … int64, uint8 … uint64                                 ║
+// ║     - boolBuf       : bool                                                         ║
+// ║     - complexBuf[T] : complex128, complex64                                        ║
+// ║     - halfBuf[T]    : float16, bfloat16 (16-bit patterns, see half.go)             ║
+// ║     - timeBuf       : datetime64, timedelta64 (int64 ticks, see datetime.go)       ║
+// ║     - textBuf[T]    : unicode, bytes (fixed-width runes / bytes, see text.go)      ║
+// ║     - recordBuf     : structured types (raw record bytes, see record.go)           ║
+// ║                                                                                    ║
+// ║   Elements are addressed by data offset, exactly like the old                      ║
+// ║   `[]float64`. Every buffer can read and write its elements as float64             ║
+// ║   or complex128, which is what the numeric kernels use; copyFrom moves             ║
+// ║   one element between buffers, exactly when both have the same type.               ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   buf := makeStorage(Uint8, 3)   → intBuf[uint8]{0, 0, 0}                          ║
+// ║   buf.setFloat(1, 300)           → intBuf[uint8]{0, 44, 0}  (wraps)                ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type storage interface {
+	len() int
+	dtype() DType
+
+	float(i int) float64
+	setFloat(i int, v float64)
+	complex(i int) complex128
+	setComplex(i int, v complex128)
+
+	// value returns element i as its own Go type (uint8, bool, ...).
+	value(i int) any
+
+	// copyFrom sets element i to element j of src, converting when the types differ.
+	copyFrom(i int, src storage, j int)
+
+	// fresh returns a zeroed buffer of n elements of the same type.
+	fresh(n int) storage
+
+	// slice returns the underlying Go slice.
+	slice() any
+}
+
+// integers is implemented by the buffers of integer types, so values can move
+// between them without a round trip through float64.
+type integers interface {
+	int(i int) int64
+	setInt(i int, v int64)
+}
+
+// Element is the set of Go types an NDArray can hold.
+type Element interface {
+	float64 | float32 | int64 | int32 | int16 | int8 | uint64 | uint32 | uint16 | uint8 | bool | complex128 | complex64
+}
+
+type floatBuf[T float64 | float32] []T
+
+func (b floatBuf[T]) len() int                       { return len(b) }
+func (b floatBuf[T]) float(i int) float64            { return float64(b[i]) }
+func (b floatBuf[T]) setFloat(i int, v float64)      { b[i] = T(v) }
+func (b floatBuf[T]) complex(i int) complex128       { return complex(float64(b[i]), 0) }
+func (b floatBuf[T]) setComplex(i int, v complex128) { b[i] = T(real(v)) }
+func (b floatBuf[T]) value(i int) any                { return b[i] }
+func (b floatBuf[T]) fresh(n int) storage            { return make(floatBuf[T], n) }
+func (b floatBuf[T]) slice() any                     { return []T(b) }
+
+func (b floatBuf[T]) dtype() DType {
+	if _, ok := any(T(0)).(float32); ok {
+		return Float32
+	}
+	return Float64
+}
+
+func (b floatBuf[T]) copyFrom(i int, src storage, j int) {
+	if s, ok := src.(floatBuf[T]); ok {
+		b[i] = s[j]
+		return
+	}
+	convertElem(b, i, src, j)
+}
+
+type intBuf[T int64 | int32 | int16 | int8 | uint64 | uint32 | uint16 | uint8] []T
+
+func (b intBuf[T]) len() int                       { return len(b) }
+func (b intBuf[T]) float(i int) float64            { return float64(b[i]) }
+func (b intBuf[T]) setFloat(i int, v float64)      { b[i] = T(truncate(v)) }
+func (b intBuf[T]) complex(i int) complex128       { return complex(float64(b[i]), 0) }
+func (b intBuf[T]) setComplex(i int, v complex128) { b[i] = T(truncate(real(v))) }
+func (b intBuf[T]) int(i int) int64                { return int64(b[i]) }
+func (b intBuf[T]) setInt(i int, v int64)          { b[i] = T(v) }
+func (b intBuf[T]) value(i int) any                { return b[i] }
+func (b intBuf[T]) fresh(n int) storage            { return make(intBuf[T], n) }
+func (b intBuf[T]) slice() any                     { return []T(b) }
+
+func (b intBuf[T]) dtype() DType {
+	switch any(T(0)).(type) {
+	case int8:
+		return Int8
+	case int16:
+		return Int16
+	case int32:
+		return Int32
+	case uint8:
+		return Uint8
+	case uint16:
+		return Uint16
+	case uint32:
+		return Uint32
+	case uint64:
+		return Uint64
+	default:
+		return Int64
+	}
+}
+
+// truncate converts a float to the 64-bit pattern of its integer part, so that
+// narrowing it to any integer type wraps; values up to 2^64 keep their uint64 bits.
+func truncate(v float64) int64 {
+	if v >= 0x1p63 {
+		return int64(uint64(v))
+	}
+	return int64(v)
+}
+
+func (b intBuf[T]) copyFrom(i int, src storage, j int) {
+	if s, ok := src.(intBuf[T]); ok {
+		b[i] = s[j]
+		return
+	}
+	convertElem(b, i, src, j)
+}
+
+type boolBuf []bool
+
+func (b boolBuf) len() int                       { return len(b) }
+func (b boolBuf) dtype() DType                   { return Bool }
+func (b boolBuf) float(i int) float64            { return truth(b[i]) }
+func (b boolBuf) setFloat(i int, v float64)      { b[i] = v != 0 }
+func (b boolBuf) complex(i int) complex128       { return complex(truth(b[i]), 0) }
+func (b boolBuf) setComplex(i int, v complex128) { b[i] = v != 0 }
+func (b boolBuf) value(i int) any                { return b[i] }
+func (b boolBuf) fresh(n int) storage            { return make(boolBuf, n) }
+func (b boolBuf) slice() any                     { return []bool(b) }
+
+func (b boolBuf) copyFrom(i int, src storage, j int) {
+	if s, ok := src.(boolBuf); ok {
+		b[i] = s[j]
+		return
+	}
+	convertElem(b, i, src, j)
+}
+
+type complexBuf[T complex128 | complex64] []T
+
+func (b complexBuf[T]) len() int                       { return len(b) }
+func (b complexBuf[T]) float(i int) float64            { return real(complex128(b[i])) }
+func (b complexBuf[T]) setFloat(i int, v float64)      { b[i] = T(complex(v, 0)) }
+func (b complexBuf[T]) complex(i int) complex128       { return complex128(b[i]) }
+func (b complexBuf[T]) setComplex(i int, v complex128) { b[i] = T(v) }
+func (b complexBuf[T]) value(i int) any                { return b[i] }
+func (b complexBuf[T]) fresh(n int) storage            { return make(complexBuf[T], n) }
+func (b complexBuf[T]) slice() any                     { return []T(b) }
+
+func (b complexBuf[T]) dtype() DType {
+	if _, ok := any(T(0)).(complex64); ok {
+		return Complex64
+	}
+	return Complex128
+}
+
+// parts reinterprets the buffer, without copying, as twice as many floats of half
+// the size: element i has its real part at 2i and its imaginary part at 2i+1,
+// which is how Go lays out complex values in memory.
+func (b complexBuf[T]) parts() storage {
+	switch c := any(b).(type) {
+	case complexBuf[complex64]:
+		if len(c) == 0 {
+			return floatBuf[float32]{}
+		}
+		return floatBuf[float32](unsafe.Slice((*float32)(unsafe.Pointer(&c[0])), 2*len(c)))
+	default:
+		c128 := any(b).(complexBuf[complex128])
+		if len(c128) == 0 {
+			return floatBuf[float64]{}
+		}
+		return floatBuf[float64](unsafe.Slice((*float64)(unsafe.Pointer(&c128[0])), 2*len(c128)))
+	}
+}
+
+func (b complexBuf[T]) copyFrom(i int, src storage, j int) {
+	if s, ok := src.(complexBuf[T]); ok {
+		b[i] = s[j]
+		return
+	}
+	convertElem(b, i, src, j)
+}
+
+type halfBuf[T float16 | bfloat16] []T
+
+func (b halfBuf[T]) len() int                       { return len(b) }
+func (b halfBuf[T]) complex(i int) complex128       { return complex(b.float(i), 0) }
+func (b halfBuf[T]) setComplex(i int, v complex128) { b.setFloat(i, real(v)) }
+func (b halfBuf[T]) value(i int) any                { return float32(b.float(i)) }
+func (b halfBuf[T]) fresh(n int) storage            { return make(halfBuf[T], n) }
+func (b halfBuf[T]) slice() any                     { return []T(b) }
+
+func (b halfBuf[T]) dtype() DType {
+	if _, ok := any(T(0)).(bfloat16); ok {
+		return BFloat16
+	}
+	return Float16
+}
+
+func (b halfBuf[T]) float(i int) float64 {
+	if _, ok := any(T(0)).(bfloat16); ok {
+		return BFloat16FromBits(uint16(b[i]))
+	}
+	return Float16FromBits(uint16(b[i]))
+}
+
+func (b halfBuf[T]) setFloat(i int, v float64) {
+	if _, ok := any(T(0)).(bfloat16); ok {
+		b[i] = T(BFloat16Bits(v))
+		return
+	}
+	b[i] = T(Float16Bits(v))
+}
+
+func (b halfBuf[T]) copyFrom(i int, src storage, j int) {
+	if s, ok := src.(halfBuf[T]); ok {
+		b[i] = s[j]
+		return
+	}
+	convertElem(b, i, src, j)
+}
+
+// convertElem sets dst[i] to src[j] across element types: texts are parsed, complex
+// values keep their imaginary part when dst can hold it, integers move exactly, the
+// rest go through float64.
+func convertElem(dst storage, i int, src storage, j int) {
+
+	if t, ok := src.(texts); ok {
+		// Unparsable texts store zero; AsType and SetValue check them first
+		_ = parseElem(dst, i, t.text(j))
+		return
+	}
+
+	if src.dtype().kind() == 'c' {
+		dst.setComplex(i, src.complex(j))
+		return
+	}
+
+	di, dok := dst.(integers)
+	si, sok := src.(integers)
+	if dok && sok {
+		di.setInt(i, si.int(j))
+		return
+	}
+
+	dst.setFloat(i, src.float(j))
+}
+
+// makeStorage returns a zeroed buffer of n elements of type d.
+func makeStorage(d DType, n int) storage {
+	switch d {
+	case Float32:
+		return make(floatBuf[float32], n)
+	case Int64:
+		return make(intBuf[int64], n)
+	case Int32:
+		return make(intBuf[int32], n)
+	case Int16:
+		return make(intBuf[int16], n)
+	case Int8:
+		return make(intBuf[int8], n)
+	case Uint64:
+		return make(intBuf[uint64], n)
+	case Uint32:
+		return make(intBuf[uint32], n)
+	case Uint16:
+		return make(intBuf[uint16], n)
+	case Uint8:
+		return make(intBuf[uint8], n)
+	case Bool:
+		return make(boolBuf, n)
+	case Complex128:
+		return make(complexBuf[complex128], n)
+	case Complex64:
+		return make(complexBuf[complex64], n)
+	case Float16:
+		return make(halfBuf[float16], n)
+	case BFloat16:
+		return make(halfBuf[bfloat16], n)
+	default:
+		switch {
+		case d.isTime():
+			return timeBuf{make([]int64, n), d}
+		case d.kind() == 'U':
+			return textBuf[rune]{make([]rune, n*d.width()), d.width()}
+		case d.kind() == 'S':
+			return textBuf[byte]{make([]byte, n*d.width()), d.width()}
+		case d.isRecord():
+			return newRecords(d, n)
+		}
+		return make(floatBuf[float64], n)
+	}
+}
+
+// storageOf wraps a Go slice, without copying it, in the matching buffer type.
+func storageOf[T Element](data []T) storage {
+	switch s := any(data).(type) {
+	case []float32:
+		return floatBuf[float32](s)
+	case []int64:
+		return intBuf[int64](s)
+	case []int32:
+		return intBuf[int32](s)
+	case []int16:
+		return intBuf[int16](s)
+	case []int8:
+		return intBuf[int8](s)
+	case []uint64:
+		return intBuf[uint64](s)
+	case []uint32:
+		return intBuf[uint32](s)
+	case []uint16:
+		return intBuf[uint16](s)
+	case []uint8:
+		return intBuf[uint8](s)
+	case []bool:
+		return boolBuf(s)
+	case []complex128:
+		return complexBuf[complex128](s)
+	case []complex64:
+		return complexBuf[complex64](s)
+	default:
+		return floatBuf[float64](any(data).([]float64))
+	}
+}
+
+// scalarStorage wraps a single Go value in a one-element buffer of its own type.
+// A plain Go int is stored as Int64 and a uint as Uint64, a time.Time as
+// datetime64 in the finest unit that can count it (ns within about 1678–2262), a
+// time.Duration as timedelta64[ns], a string as unicode and a []byte as bytes, as
+// wide as the value, and a struct as a record (see FromStructs).
+func scalarStorage(v any) (storage, error) {
+	switch x := v.(type) {
+	case float64:
+		return floatBuf[float64]{x}, nil
+	case float32:
+		return floatBuf[float32]{x}, nil
+	case int:
+		return intBuf[int64]{int64(x)}, nil
+	case int64:
+		return intBuf[int64]{x}, nil
+	case int32:
+		return intBuf[int32]{x}, nil
+	case int16:
+		return intBuf[int16]{x}, nil
+	case int8:
+		return intBuf[int8]{x}, nil
+	case uint:
+		return intBuf[uint64]{uint64(x)}, nil
+	case uint64:
+		return intBuf[uint64]{x}, nil
+	case uint32:
+		return intBuf[uint32]{x}, nil
+	case uint16:
+		return intBuf[uint16]{x}, nil
+	case uint8:
+		return intBuf[uint8]{x}, nil
+	case bool:
+		return boolBuf{x}, nil
+	case complex128:
+		return complexBuf[complex128]{x}, nil
+	case complex64:
+		return complexBuf[complex64]{x}, nil
+	case time.Time:
+		return timeScalar(x), nil
+	case time.Duration:
+		return timeBuf{[]int64{int64(x)}, Timedelta64(Nanoseconds)}, nil
+	case string:
+		return newText[rune]([]string{x})
+	case []byte:
+		return newText[byte]([]string{string(x)})
+	default:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Struct {
+			rows := reflect.MakeSlice(reflect.SliceOf(rv.Type()), 1, 1)
+			rows.Index(0).Set(rv)
+			return structRecords(rows)
+		}
+		return nil, fmt.Errorf("cannot store a value of type %T in an array", v)
+	}
+}
+
+// convertStorage returns a copy of buf with every element converted to type d.
+func convertStorage(buf storage, d DType) storage {
+	out := makeStorage(d, buf.len())
+	for i := 0; i < buf.len(); i++ {
+		out.copyFrom(i, buf, i)
+	}
+	return out
+}
@@ -0,0 +1,482 @@
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║     ████████╗███████╗██╗  ██╗████████╗                                             ║
+// ║     ╚══██╔══╝██╔════╝╚██╗██╔╝╚══██╔══╝                                             ║
+// ║        ██║   █████╗   ╚███╔╝    ██║                                                ║
+// ║        ██║   ██╔══╝   ██╔██╗    ██║                                                ║
+// ║        ██║   ███████╗██╔╝ ██╗   ██║                                                ║
+// ║        ╚═╝   ╚══════╝╚═╝  ╚═╝   ╚═╝                                                ║
+// ║                                                                                    ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Fixed-width text: unicode and byte-string dtypes, their                           ║
+// ║  storage, conversions to and from numbers, and printing.                           ║
+// ║  ───────────────────────────────────────────────────────────────────────────────   ║
+// ║  Module  : github.com/arnaizaitor/gondor/internal/ndarray/text.go                  ║
+// ║  Author  : Aitor Arnaiz                                                            ║
+// ║  License : TBD                                                                     ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+
+package ndarray
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Unicode, Bytes – The text dtypes of a width                                ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.dtype('U<width>')` and `np.dtype('S<width>')`:                 ║
+// ║   every element holds up to `width` characters, shorter strings are                ║
+// ║   padded with NULs and longer ones truncated, as in NumPy.                         ║
+// ║                                                                                    ║
+// ║     - Unicode(n) : "<Un", kind 'U', n code points of 4 bytes each                  ║
+// ║     - Bytes(n)   : "|Sn", kind 'S', n raw bytes                                    ║
+// ║                                                                                    ║
+// ║   Trailing NULs are not part of an element, so "ab" stored in a                    ║
+// ║   Unicode(4) array reads back as "ab". Casting follows NumPy:                      ║
+// ║                                                                                    ║
+// ║   - Safe    : to a wider type of the same kind, and bytes → unicode                ║
+// ║   - Same kind : any width change within those                                      ║
+// ║   - Unsafe  : unicode → bytes (non-ASCII text is stored as UTF-8                   ║
+// ║               bytes), and from and to bool, numbers and complex                    ║
+// ║               numbers, which are formatted like String and parsed back             ║
+// ║   - Never   : from or to datetime64 / timedelta64                                  ║
+// ║                                                                                    ║
+// ║   Widths outside 1 … 2^20-1 give a DType no constructor accepts.                   ║
+// ║                                                                                    ║
+// ║   Returns: DType                                                                   ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   d := Unicode(5)                                                                  ║
+// ║   d.String(), d.ItemSize()           → "<U5", 20                                   ║
+// ║   CanCast(Bytes(3), d, CastingSafe)  → true                                        ║
+// ║   ResultType(Unicode(2), Bytes(7))   → <U7                                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func Unicode(width int) DType { return textDType(unicodeBase, width) }
+func Bytes(width int) DType   { return textDType(bytesBase, width) }
+
+// The text dtypes are numbered from these, plus their width, after every other dtype.
+const (
+	unicodeBase DType = 1 << 20
+	bytesBase   DType = 2 << 20
+	maxWidth          = 1<<20 - 1
+)
+
+// textDType numbers the text type of a width from its base.
+func textDType(base DType, width int) DType {
+	if width < 1 || width > maxWidth {
+		return -1
+	}
+	return base + DType(width)
+}
+
+// textInfo is the typeInfo of a text dtype; the others are in dtypeInfo.
+func textInfo(d DType) (typeInfo, bool) {
+	w := d.width()
+	switch {
+	case w == 0:
+		return typeInfo{}, false
+	case d/unicodeBase == 1:
+		return typeInfo{"<U" + strconv.Itoa(w), 'U', 4 * w}, true
+	case d/unicodeBase == 2:
+		return typeInfo{"|S" + strconv.Itoa(w), 'S', w}, true
+	default:
+		return typeInfo{}, false
+	}
+}
+
+// isText reports whether d is a unicode or bytes type.
+func (d DType) isText() bool {
+	k := d.kind()
+	return k == 'U' || k == 'S'
+}
+
+// width returns the number of characters of a text type.
+func (d DType) width() int { return int(d % unicodeBase) }
+
+// textCast is CanCast when either side is a text type.
+func textCast(from, to DType, casting Casting) bool {
+
+	switch {
+	case from == to:
+		return true
+	case from.isTime() || to.isTime():
+		return false
+	case !from.isText() || !to.isText() || (from.kind() == 'U' && to.kind() == 'S'):
+		return casting == CastingUnsafe
+	case casting == CastingSafe:
+		return to.width() >= from.width()
+	default:
+		return true
+	}
+}
+
+// promoteText is promoteTypes for two text types: unicode when either is, as wide
+// as the wider one.
+func promoteText(a, b DType) DType {
+	width := max(a.width(), b.width())
+	if a.kind() == 'U' || b.kind() == 'U' {
+		return Unicode(width)
+	}
+	return Bytes(width)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   TYPE: textBuf – Storage of unicode and bytes elements                            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   `width` characters per element, back to back: runes for unicode                  ║
+// ║   (UCS-4, like NumPy) and bytes for byte strings, so an element can be             ║
+// ║   overwritten in place and views slice the buffer like any other.                  ║
+// ║                                                                                    ║
+// ║   - As texts, elements read without their trailing NULs and are                    ║
+// ║     truncated to the width when written                                            ║
+// ║   - Numbers written to it are formatted as String prints them (see                 ║
+// ║     elemText); read as numbers, elements are parsed (see parseElem)                ║
+// ║   - value gives a string for unicode and a []byte for bytes                        ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   buf := makeStorage(Unicode(3), 2)   → textBuf[rune]{width 3, 6 runes}            ║
+// ║   buf.setText(1, "héllo")             → elements "", "hél"                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+type textBuf[T rune | byte] struct {
+	chars []T
+	width int
+}
+
+// texts is implemented by the buffers of text types.
+type texts interface {
+	text(i int) string
+	setText(i int, s string)
+}
+
+func (b textBuf[T]) len() int                           { return len(b.chars) / b.width }
+func (b textBuf[T]) setFloat(i int, v float64)          { b.setText(i, strconv.FormatFloat(v, 'g', -1, 64)) }
+func (b textBuf[T]) setComplex(i int, v complex128)     { b.setText(i, formatComplex(v, 64)) }
+func (b textBuf[T]) setInt(i int, v int64)              { b.setText(i, strconv.FormatInt(v, 10)) }
+func (b textBuf[T]) copyFrom(i int, src storage, j int) { b.setText(i, elemText(src, j)) }
+func (b textBuf[T]) fresh(n int) storage                { return textBuf[T]{make([]T, n*b.width), b.width} }
+func (b textBuf[T]) slice() any                         { return b.chars }
+
+func (b textBuf[T]) dtype() DType {
+	if _, ok := any(T(0)).(byte); ok {
+		return Bytes(b.width)
+	}
+	return Unicode(b.width)
+}
+
+func (b textBuf[T]) float(i int) float64 {
+	v, _ := parseFloat(b.text(i))
+	return v
+}
+
+func (b textBuf[T]) complex(i int) complex128 {
+	v, _ := parseComplex(b.text(i))
+	return v
+}
+
+func (b textBuf[T]) value(i int) any {
+	if _, ok := any(T(0)).(byte); ok {
+		return []byte(b.text(i))
+	}
+	return b.text(i)
+}
+
+func (b textBuf[T]) text(i int) string {
+
+	elem := b.chars[i*b.width : (i+1)*b.width]
+	n := len(elem)
+	for n > 0 && elem[n-1] == 0 {
+		n--
+	}
+
+	if e, ok := any(elem[:n]).([]byte); ok {
+		return string(e)
+	}
+	return string(any(elem[:n]).([]rune))
+}
+
+func (b textBuf[T]) setText(i int, s string) {
+
+	elem := b.chars[i*b.width : (i+1)*b.width]
+	n := 0
+	switch e := any(elem).(type) {
+	case []byte:
+		n = copy(e, s)
+	case []rune:
+		for _, r := range s {
+			if n == len(e) {
+				break
+			}
+			e[n] = r
+			n++
+		}
+	}
+	clear(elem[n:])
+}
+
+// newText copies values into a buffer as wide as the longest of them (at least
+// one character), counted in runes for unicode and in bytes for bytes.
+func newText[T rune | byte](values []string) (textBuf[T], error) {
+
+	_, isBytes := any(T(0)).(byte)
+	width := 1
+	for _, s := range values {
+		if isBytes {
+			width = max(width, len(s))
+		} else {
+			width = max(width, utf8.RuneCountInString(s))
+		}
+	}
+	if width > maxWidth {
+		return textBuf[T]{}, fmt.Errorf("strings of %d characters exceed the maximum width %d", width, maxWidth)
+	}
+
+	buf := textBuf[T]{make([]T, len(values)*width), width}
+	for i, s := range values {
+		buf.setText(i, s)
+	}
+	return buf, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: elemText – Format one element of any type as text                          ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   What a text buffer stores when an element of another type is                     ║
+// ║   converted to it, the way String prints that type: true / false,                  ║
+// ║   decimal integers, the shortest float that reads back as the same                 ║
+// ║   value at the element's precision, re+imi complexes, and ISO dates.               ║
+// ║                                                                                    ║
+// ║   Returns: string                                                                  ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   elemText(floatBuf[float32]{0.1}, 0)    → "0.1"                                   ║
+// ║   elemText(intBuf[uint64]{1 << 63}, 0)   → "9223372036854775808"                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func elemText(src storage, j int) string {
+
+	d := src.dtype()
+	switch d.kind() {
+	case 'U', 'S':
+		return src.(texts).text(j)
+	case 'b':
+		return strconv.FormatBool(src.float(j) != 0)
+	case 'i':
+		return strconv.FormatInt(src.(integers).int(j), 10)
+	case 'u':
+		return strconv.FormatUint(uint64(src.(integers).int(j)), 10)
+	case 'c':
+		return formatComplex(src.complex(j), 4*d.ItemSize())
+	case 'M', 'm':
+		return formatTime(src.(integers).int(j), d)
+	}
+
+	if d.ItemSize() == 2 {
+		return formatHalf(src.float(j), d)
+	}
+	return strconv.FormatFloat(src.float(j), 'g', -1, 8*d.ItemSize())
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: parseElem – Store a text as an element of another type                     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   The inverse of elemText, ignoring surrounding whitespace:                        ║
+// ║                                                                                    ║
+// ║   - Bool      : strconv.ParseBool ("true", "False", "1", "f", ...)                 ║
+// ║   - Integers  : decimal, wrapping to narrow types like AsType does;                ║
+// ║                 values up to 2^64-1 are accepted for uint64                        ║
+// ║   - Floats    : strconv.ParseFloat; out-of-range values become ±Inf                ║
+// ║   - Complex   : strconv.ParseComplex, with "j" accepted for "i"                    ║
+// ║                                                                                    ║
+// ║   Returns: error – the text is not a number of dst's kind; dst[i] is               ║
+// ║            then zero (NaN for floats)                                              ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   parseElem(intBuf[int8]{0}, 0, " 300 ")   → nil, element 44                       ║
+// ║   parseElem(intBuf[int8]{0}, 0, "1.5")     → error                                 ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func parseElem(dst storage, i int, s string) error {
+
+	s = strings.TrimSpace(s)
+	var err error
+	switch dst.dtype().kind() {
+	case 'b':
+		var v bool
+		v, err = strconv.ParseBool(s)
+		dst.setFloat(i, truth(v))
+	case 'i', 'u', 'M', 'm':
+		var v int64
+		v, err = parseInt(s)
+		dst.(integers).setInt(i, v)
+	case 'c':
+		var v complex128
+		v, err = parseComplex(s)
+		dst.setComplex(i, v)
+	default:
+		var v float64
+		v, err = parseFloat(s)
+		dst.setFloat(i, v)
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not convert string '%s' to %s", s, dst.dtype())
+	}
+	return nil
+}
+
+// parseInt parses a decimal int64, or a uint64 beyond it as its bit pattern.
+func parseInt(s string) (int64, error) {
+	v, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		u, uerr := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+		if uerr != nil {
+			return 0, err
+		}
+		return int64(u), nil
+	}
+	return v, nil
+}
+
+// parseFloat parses a float64, NaN when s is not a number.
+func parseFloat(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	switch {
+	case errors.Is(err, strconv.ErrRange):
+		return v, nil
+	case err != nil:
+		return math.NaN(), err
+	}
+	return v, nil
+}
+
+// parseComplex parses a complex128 written with "i" or "j", NaN when s is not one.
+func parseComplex(s string) (complex128, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "j") || strings.HasSuffix(s, "j)") {
+		s = strings.Replace(s, "j", "i", 1)
+	}
+	v, err := strconv.ParseComplex(s, 128)
+	switch {
+	case errors.Is(err, strconv.ErrRange):
+		return v, nil
+	case err != nil:
+		return complex(math.NaN(), 0), err
+	}
+	return v, nil
+}
+
+// parseTexts is AsType from a text type to any other: unlike convertElem, it
+// fails on the first element that does not parse.
+func parseTexts(a *NDArray, d DType) (*NDArray, error) {
+
+	src := gather(a).(texts)
+	out := allocOf(append([]int(nil), a.shape...), d)
+	for i := 0; i < out.data.len(); i++ {
+		if err := parseElem(out.data, i, src.text(i)); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: FromStrings, FromByteStrings – Build text arrays from Go values            ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Equivalent of `np.array(values)` for a list of str or of bytes.                  ║
+// ║                                                                                    ║
+// ║   - The dtype is as wide as the longest value: Unicode(characters)                 ║
+// ║     for strings, Bytes(bytes) for byte strings, at least 1                         ║
+// ║   - Trailing NULs of a value are dropped, as NumPy does                            ║
+// ║   - Shape rules are those of FromSlice; the data is copied                         ║
+// ║   - Single values can also be made with ScalarOf, which stores a                   ║
+// ║     string as unicode and a []byte as bytes                                        ║
+// ║                                                                                    ║
+// ║   Returns: (*NDArray, error) – error on a bad shape                                ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   FromStrings([]string{"red", "green", "blue"})   → <U5, ["red" "green" "blue"]    ║
+// ║   FromByteStrings([][]byte{[]byte("id")}, 1, 1)    → |S2, shape [1, 1]             ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func FromStrings(values []string, shape ...int) (*NDArray, error) {
+
+	buf, err := newText[rune](values)
+	if err != nil {
+		return nil, err
+	}
+	return fromBuffer(buf, len(values), shape)
+}
+
+func FromByteStrings(values [][]byte, shape ...int) (*NDArray, error) {
+
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = string(v)
+	}
+
+	buf, err := newText[byte](strs)
+	if err != nil {
+		return nil, err
+	}
+	return fromBuffer(buf, len(values), shape)
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: Strings – Copy text elements out as Go strings                             ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   A flat slice in row-major (C) order, like ToSliceOf, without the                 ║
+// ║   elements' trailing NULs. Bytes elements come out as strings holding              ║
+// ║   their raw bytes.                                                                 ║
+// ║                                                                                    ║
+// ║   Returns: ([]string, error) – error unless a is unicode or bytes                  ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   a, _ := FromStrings([]string{"a", "bc", "d"})                                    ║
+// ║   b, _ := a.Slice(Span(1, 3))                                                      ║
+// ║   b.Strings() → ["bc" "d"]                                                         ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func (a *NDArray) Strings() ([]string, error) {
+
+	buf, ok := gather(a).(texts)
+	if !ok {
+		return nil, fmt.Errorf("cannot read a %s array as []string", a.dtype)
+	}
+
+	out := make([]string, a.Size())
+	for i := range out {
+		out[i] = buf.text(i)
+	}
+	return out, nil
+}
+
+// formatTexts lists the elements of a text buffer for String, quoted as Go strings.
+func formatTexts(buf storage) string {
+
+	t := buf.(texts)
+	parts := make([]string, buf.len())
+	for i := range parts {
+		parts[i] = strconv.Quote(t.text(i))
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
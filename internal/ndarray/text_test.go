@@ -0,0 +1,198 @@
+package ndarray_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/arnaizaitor/gondor/internal/ndarray"
+)
+
+func TestTextDTypes(t *testing.T) {
+	tests := []struct {
+		d    ndarray.DType
+		name string
+		size int
+	}{
+		{ndarray.Unicode(1), "<U1", 4},
+		{ndarray.Unicode(5), "<U5", 20},
+		{ndarray.Bytes(7), "|S7", 7},
+	}
+	for _, tt := range tests {
+		if tt.d.String() != tt.name || tt.d.ItemSize() != tt.size {
+			t.Errorf("got %s (%d bytes), want %s (%d bytes)", tt.d, tt.d.ItemSize(), tt.name, tt.size)
+		}
+	}
+	if _, err := ndarray.ZerosOf(ndarray.Unicode(0), 2); err == nil {
+		t.Errorf("expected error for a zero width")
+	}
+
+	casts := []struct {
+		from, to ndarray.DType
+		casting  ndarray.Casting
+		want     bool
+	}{
+		{ndarray.Unicode(3), ndarray.Unicode(5), ndarray.CastingSafe, true},
+		{ndarray.Unicode(5), ndarray.Unicode(3), ndarray.CastingSafe, false},
+		{ndarray.Unicode(5), ndarray.Unicode(3), ndarray.CastingSameKind, true},
+		{ndarray.Bytes(3), ndarray.Unicode(3), ndarray.CastingSafe, true},
+		{ndarray.Unicode(3), ndarray.Bytes(3), ndarray.CastingSameKind, false},
+		{ndarray.Unicode(3), ndarray.Bytes(3), ndarray.CastingUnsafe, true},
+		{ndarray.Int64, ndarray.Unicode(21), ndarray.CastingSameKind, false},
+		{ndarray.Unicode(3), ndarray.Float64, ndarray.CastingUnsafe, true},
+		{ndarray.Datetime64(ndarray.Days), ndarray.Unicode(32), ndarray.CastingUnsafe, false},
+	}
+	for _, tt := range casts {
+		if got := ndarray.CanCast(tt.from, tt.to, tt.casting); got != tt.want {
+			t.Errorf("CanCast(%s, %s, %s) = %v, want %v", tt.from, tt.to, tt.casting, got, tt.want)
+		}
+	}
+
+	if got, err := ndarray.ResultType(ndarray.Unicode(2), ndarray.Bytes(7)); err != nil || got != ndarray.Unicode(7) {
+		t.Errorf("ResultType(<U2, |S7) = %s, %v, want <U7", got, err)
+	}
+	if got, err := ndarray.ResultType(ndarray.Bytes(2), ndarray.Bytes(4)); err != nil || got != ndarray.Bytes(4) {
+		t.Errorf("ResultType(|S2, |S4) = %s, %v, want |S4", got, err)
+	}
+}
+
+func TestTextArrays(t *testing.T) {
+	a, err := ndarray.FromStrings([]string{"red", "green", "blue", "héllo"}, 2, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := a.String(), `NDArray(shape=[2 2], data=["red" "green" "blue" "héllo"])`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if a.DType() != ndarray.Unicode(5) || a.Nbytes() != 80 {
+		t.Errorf("got %s, %d bytes, want <U5, 80 bytes", a.DType(), a.Nbytes())
+	}
+
+	// Views, reshapes and copies see the same fixed-width elements
+	col, _ := a.Slice(ndarray.Span(0, 2), ndarray.At(1))
+	if got, _ := col.Strings(); len(got) != 2 || got[0] != "green" || got[1] != "héllo" {
+		t.Errorf("column: got %q", got)
+	}
+	flat, _ := a.T().Reshape(-1)
+	if got, _ := flat.Strings(); len(got) != 4 || got[1] != "blue" {
+		t.Errorf("reshape of the transpose: got %q", got)
+	}
+	if err := col.SetValue("a much longer string", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := a.Value(0, 1); v != "a muc" {
+		t.Errorf("truncated write through a view: got %q, want %q", v, "a muc")
+	}
+
+	b, _ := ndarray.FromByteStrings([][]byte{[]byte("ab"), {0xff, 0}})
+	if b.DType() != ndarray.Bytes(2) {
+		t.Errorf("got %s, want |S2", b.DType())
+	}
+	if v, _ := b.Value(1); string(v.([]byte)) != "\xff" {
+		t.Errorf("bytes element: got %q, want %q", v, "\xff")
+	}
+
+	row, _ := a.Slice(ndarray.At(1))
+	cat, err := ndarray.Concatenate([]*ndarray.NDArray{row, b}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := cat.String(), `NDArray(shape=[4], data=["blue" "héllo" "ab" "�"])`; got != want || cat.DType() != ndarray.Unicode(5) {
+		t.Errorf("concatenate: got %s (%s), want %s", got, cat.DType(), want)
+	}
+	if _, err := ndarray.Concatenate([]*ndarray.NDArray{row, ndarray.AtLeast1D(ndarray.Scalar(1))}, 0); err == nil {
+		t.Errorf("expected error concatenating text and numbers")
+	}
+
+	s, err := ndarray.ScalarOf("x")
+	if err != nil || s.DType() != ndarray.Unicode(1) || len(s.Shape()) != 0 {
+		t.Errorf("ScalarOf(string): got %v, %v", s, err)
+	}
+	if _, err := ndarray.Add(a, a); err == nil {
+		t.Errorf("expected error adding strings")
+	}
+	if _, err := a.Get(0, 0); err == nil {
+		t.Errorf("expected error reading a string as float64")
+	}
+	if _, err := ndarray.Scalar(1).Strings(); err == nil {
+		t.Errorf("expected error reading float64 as strings")
+	}
+}
+
+func TestTextConversions(t *testing.T) {
+	nums, _ := ndarray.FromStrings([]string{" 1.5", "-2e3 ", "inf", "1e400"})
+	f, err := nums.AsType(ndarray.Float64)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := f.String(), "NDArray(shape=[4], data=[1.5 -2000 +Inf +Inf])"; got != want {
+		t.Errorf("parse floats: got %s, want %s", got, want)
+	}
+	if _, err := nums.AsType(ndarray.Int64); err == nil {
+		t.Errorf("expected error parsing 1.5 as an integer")
+	}
+
+	ints, _ := ndarray.FromStrings([]string{"300", "18446744073709551615"})
+	u8, err := ints.AsType(ndarray.Uint8)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := u8.String(), "NDArray(shape=[2], data=[44 255])"; got != want {
+		t.Errorf("parse uint8: got %s, want %s", got, want)
+	}
+	bools, _ := ndarray.FromByteStrings([][]byte{[]byte("true"), []byte("0"), []byte("F")})
+	if b, err := bools.AsType(ndarray.Bool); err != nil || b.String() != "NDArray(shape=[3], data=[true false false])" {
+		t.Errorf("parse bools: got %v, %v", b, err)
+	}
+	cplx, _ := ndarray.FromStrings([]string{"1+2i", "(3-4j)"})
+	if c, err := cplx.AsType(ndarray.Complex128); err != nil || c.String() != "NDArray(shape=[2], data=[1+2i 3-4i])" {
+		t.Errorf("parse complex: got %v, %v", c, err)
+	}
+
+	// Numbers format as String prints them, at their own precision
+	tests := []struct {
+		a    *ndarray.NDArray
+		want string
+	}{
+		{typed[float32](t, 0.1, -2.5), `["0.1" "-2.5"]`},
+		{typed(t, 1e20, math.NaN()), `["1e+20" "NaN"]`},
+		{typed[uint64](t, 1<<63), `["9223372036854775808"]`},
+		{typed(t, true, false), `["true" "false"]`},
+		{typed(t, complex64(1-2i)), `["1-2i"]`},
+	}
+	for _, tt := range tests {
+		s, err := tt.a.AsType(ndarray.Unicode(20))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got, _ := s.Strings(); fmt.Sprintf("%q", got) != tt.want {
+			t.Errorf("format %s: got %q, want %s", tt.a.DType(), got, tt.want)
+		}
+	}
+	half, _ := typed(t, 0.1).AsType(ndarray.Float16)
+	if s, _ := half.AsType(ndarray.Bytes(4)); s.String() != `NDArray(shape=[1], data=["0.1"])` {
+		t.Errorf("format float16: got %s", s)
+	}
+
+	// Text widths change by truncating or padding
+	words, _ := ndarray.FromStrings([]string{"naïve", "ok"})
+	short, _ := words.AsType(ndarray.Unicode(3))
+	if got, _ := short.Strings(); got[0] != "naï" || got[1] != "ok" {
+		t.Errorf("narrow unicode: got %q", got)
+	}
+	raw, _ := words.AsType(ndarray.Bytes(4))
+	if got, _ := raw.Strings(); got[0] != "na\xc3\xaf" {
+		t.Errorf("unicode to bytes: got %q", got)
+	}
+
+	x, _ := ndarray.ZerosOf(ndarray.Float64, 2)
+	if err := x.SetValue("2.25", 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := x.Get(1); v != 2.25 {
+		t.Errorf("SetValue(string): got %v, want 2.25", v)
+	}
+	if err := x.SetValue("abc", 0); err == nil {
+		t.Errorf("expected error storing abc in a float64 array")
+	}
+}
@@ -17,3 +17,255 @@
 // ╚════════════════════════════════════════════════════════════════════════════════════╝
 
 package ndarray
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: nextIndex – Advance a multi-index in row-major order                       ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Treats `index` like an odometer over `shape`: the last axis spins fastest        ║
+// ║   and carries into the axis before it when it wraps around.                        ║
+// ║                                                                                    ║
+// ║   Returns: bool – false once every position has been visited                       ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   shape [2, 3]: [0 0] → [0 1] → [0 2] → [1 0] → ... → [1 2] → false                ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func nextIndex(index, shape []int) bool {
+	for axis := len(shape) - 1; axis >= 0; axis-- {
+		index[axis]++
+		if index[axis] < shape[axis] {
+			return true
+		}
+		index[axis] = 0
+	}
+	return false
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: gather, gatherData – Copy the elements of an array, C-ordered              ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Walks the logical elements of `a` in row-major order through its strides,        ║
+// ║   regardless of how they are laid out in memory.                                   ║
+// ║                                                                                    ║
+// ║   - gather keeps the element type (a fresh buffer like a's own)                    ║
+// ║   - gatherData converts every element to float64, for numeric code                 ║
+// ║                                                                                    ║
+// ║   Returns: storage / []float64 (length a.Size())                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func gather(a *NDArray) storage {
+
+	out := a.data.fresh(shapeSize(a.shape))
+	i := 0
+	walk(a.shape, [][]int{a.strides}, []int{a.offset}, func(offs, inner []int, n int) {
+		for x := offs[0]; n > 0; n-- {
+			out.copyFrom(i, a.data, x)
+			x += inner[0]
+			i++
+		}
+	})
+	return out
+}
+
+func gatherData(a *NDArray) []float64 {
+
+	data, ok := a.data.(floatBuf[float64])
+	if !ok {
+		return convertStorage(gather(a), Float64).(floatBuf[float64])
+	}
+	out := make([]float64, 0, shapeSize(a.shape))
+	if shapeSize(a.shape) == 0 {
+		return out
+	}
+
+	index := make([]int, len(a.shape))
+	for {
+		offset := a.offset
+		for i, idx := range index {
+			offset += idx * a.strides[i]
+		}
+		out = append(out, data[offset])
+
+		if !nextIndex(index, a.shape) {
+			return out
+		}
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: flatOffset – Position in `data` of the n-th element in row-major order     ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   Unravels a flat (C-order) index into per-axis indices and applies the            ║
+// ║   strides and offset of the view.                                                  ║
+// ║                                                                                    ║
+// ║   Returns: int                                                                     ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   EXAMPLE:                                                                         ║
+// ║   shape [2, 3], strides [1, 2] (a transpose): flat 4 → [1, 1] → 1*1 + 1*2 = 3      ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func flatOffset(a *NDArray, flat int) int {
+	offset := a.offset
+	for axis := len(a.shape) - 1; axis >= 0; axis-- {
+		offset += (flat % a.shape[axis]) * a.strides[axis]
+		flat /= a.shape[axis]
+	}
+	return offset
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: walk – Lock-step strided iteration over several operands                   ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   The engine behind every elementwise loop. All operands share `shape`             ║
+// ║   (already broadcast) but each has its own strides and start offset.               ║
+// ║                                                                                    ║
+// ║   - Axes that are contiguous for every operand are merged first, so a              ║
+// ║     C-ordered array is walked as one long row                                      ║
+// ║   - `fn` is called once per innermost row with the row's start offset for          ║
+// ║     each operand, the per-operand inner stride and the row length                  ║
+// ║   - 0-d arrays produce a single row of length 1                                    ║
+// ║                                                                                    ║
+// ║────────────────────────────────────────────────────────────────────────────        ║
+// ║   DIAGRAM (shape [2, 3], one operand, strides [3, 1]):                             ║
+// ║                                                                                    ║
+// ║     merged → shape [6], strides [1]                                                ║
+// ║     fn(offs=[0], inner=[1], n=6)                                                   ║
+// ║                                                                                    ║
+// ║   (shape [2, 3], strides [1, 2], a transpose — nothing merges):                    ║
+// ║                                                                                    ║
+// ║     fn(offs=[0], inner=[2], n=3)                                                   ║
+// ║     fn(offs=[1], inner=[2], n=3)                                                   ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func walk(shape []int, strides [][]int, offsets []int, fn func(offs, inner []int, n int)) {
+
+	if shapeSize(shape) == 0 {
+		return
+	}
+
+	// Coalesce axes: dims[i] and st[k][i] describe the merged layout
+	dims := make([]int, 0, len(shape))
+	st := make([][]int, len(strides))
+	for axis, dim := range shape {
+
+		// Length-1 axes never move the offsets
+		if dim == 1 {
+			continue
+		}
+
+		merge := len(dims) > 0
+		for k := range strides {
+			if merge && st[k][len(st[k])-1] != dim*strides[k][axis] {
+				merge = false
+			}
+		}
+
+		if merge {
+			dims[len(dims)-1] *= dim
+			for k := range strides {
+				st[k][len(st[k])-1] = strides[k][axis]
+			}
+			continue
+		}
+
+		dims = append(dims, dim)
+		for k := range strides {
+			st[k] = append(st[k], strides[k][axis])
+		}
+	}
+
+	if len(dims) == 0 {
+		dims = append(dims, 1)
+		for k := range st {
+			st[k] = append(st[k], 0)
+		}
+	}
+
+	last := len(dims) - 1
+	inner := make([]int, len(st))
+	for k := range st {
+		inner[k] = st[k][last]
+	}
+
+	outer := dims[:last]
+	index := make([]int, len(outer))
+	offs := make([]int, len(offsets))
+	for {
+		for k := range offs {
+			offs[k] = offsets[k]
+			for i, idx := range index {
+				offs[k] += idx * st[k][i]
+			}
+		}
+		fn(offs, inner, dims[last])
+
+		if !nextIndex(index, outer) {
+			return
+		}
+	}
+}
+
+// ╔════════════════════════════════════════════════════════════════════════════════════╗
+// ║                                                                                    ║
+// ║   FUNC: assign – Copy the elements of src into dst with broadcasting               ║
+// ║   ───────────────────────────────────────────────────────────────                  ║
+// ║   The `dst[...] = src` of the engine: src is broadcast to dst's shape and          ║
+// ║   both are walked through their own strides, so either may be a view.              ║
+// ║   Overlapping memory is handled by copying src first.                              ║
+// ║                                                                                    ║
+// ║   Returns: error – shapes that do not broadcast, or a read-only dst                ║
+// ║                                                                                    ║
+// ╚════════════════════════════════════════════════════════════════════════════════════╝
+func assign(dst, src *NDArray) error {
+
+	if !dst.flags.Writeable {
+		return fmt.Errorf("assignment destination is read-only")
+	}
+
+	strides, err := broadcastStrides(src.shape, src.strides, dst.shape)
+	if err != nil {
+		return fmt.Errorf("could not broadcast input array from shape %v into shape %v", src.shape, dst.shape)
+	}
+
+	if sharesMemory(dst, src) {
+		src = src.Copy()
+		strides, _ = broadcastStrides(src.shape, src.strides, dst.shape)
+	}
+
+	walk(dst.shape, [][]int{dst.strides, strides}, []int{dst.offset, src.offset}, func(offs, inner []int, n int) {
+		o, x := offs[0], offs[1]
+		for i := 0; i < n; i++ {
+			dst.data.copyFrom(o, src.data, x)
+			o += inner[0]
+			x += inner[1]
+		}
+	})
+	return nil
+}
+
+// sharesMemory reports whether two arrays are views on the same buffer.
+func sharesMemory(a, b *NDArray) bool {
+	if a.data.len() == 0 || b.data.len() == 0 {
+		return false
+	}
+	return reflect.ValueOf(a.data.slice()).Pointer() == reflect.ValueOf(b.data.slice()).Pointer()
+}
+
+// abs returns the absolute value of an int.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}